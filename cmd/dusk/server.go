@@ -76,7 +76,7 @@ func LaunchChain(ctx context.Context, cl *loop.Consensus, proxy transactions.Pro
 
 func (s *Server) launchKadcastPeer(ctx context.Context, p *peer.MessageProcessor, g *protocol.Gossip) {
 	// launch kadcast client
-	kadPeer := kadcast.NewKadcastPeer(ctx, s.eventBus, p, g)
+	kadPeer := kadcast.NewKadcastPeer(ctx, s.eventBus, s.rpcBus, p, g)
 	kadPeer.Launch()
 	s.kadPeer = kadPeer
 }
@@ -91,11 +91,20 @@ func Setup() *Server {
 	eventBus := eventbus.New()
 	rpcBus := rpcbus.New()
 
+	// Components subscribing after the first block was accepted still need to
+	// know about the current round.
+	eventBus.Retain(topics.AcceptedBlock)
+
 	driver, db := heavy.CreateDBConnection()
 
 	processor := peer.NewMessageProcessor(eventBus)
 	registerPeerServices(processor, db, eventBus, rpcBus)
 
+	observer := cfg.Get().Node.ObserverMode
+	if !observer {
+		registerConsensusServices(processor, eventBus)
+	}
+
 	// Instantiate gRPC client
 	// TODO: get address from config
 	gctx, cancel := context.WithTimeout(parentCtx, time.Duration(cfg.Get().RPC.Rusk.ConnectionTimeout)*time.Millisecond)
@@ -123,20 +132,28 @@ func Setup() *Server {
 		}
 	}
 
-	keys, err := loadConsensusKeys()
-	if err != nil {
-		log.WithError(err).Fatal("could not load consensus keys")
-	}
+	// An observer neither generates blocks nor votes, hence it needs no
+	// consensus keys nor loop
+	var cl *loop.Consensus
 
-	e := &consensus.Emitter{
-		EventBus:    eventBus,
-		RPCBus:      rpcBus,
-		Keys:        keys,
-		TimerLength: time.Duration(cfg.Get().Consensus.ConsensusTimeOut) * time.Second,
-	}
+	if observer {
+		log.Info("observer mode, consensus disabled")
+	} else {
+		keys, err := loadConsensusKeys()
+		if err != nil {
+			log.WithError(err).Fatal("could not load consensus keys")
+		}
 
-	cl := loop.New(e)
-	processor.Register(topics.Candidate, cl.ProcessCandidate)
+		e := &consensus.Emitter{
+			EventBus:    eventBus,
+			RPCBus:      rpcBus,
+			Keys:        keys,
+			TimerLength: time.Duration(cfg.Get().Consensus.ConsensusTimeOut) * time.Second,
+		}
+
+		cl = loop.New(e)
+		processor.Register(topics.Candidate, cl.ProcessCandidate)
+	}
 
 	c, err := LaunchChain(parentCtx, cl, proxy, eventBus, rpcBus, nil, db)
 	if err != nil {
@@ -193,7 +210,7 @@ func Setup() *Server {
 		m.RequestUpdates()
 	}()
 
-	if err := c.RestartConsensus(); err != nil {
+	if err := c.StartConsensus(); err != nil {
 		log.WithError(err).Warn("StartConsensus returned err")
 		// If we can not start consensus, we shouldn't be able to start at all.
 		panic(err)
@@ -202,8 +219,23 @@ func Setup() *Server {
 	return srv
 }
 
-// Close the chain and the connections created through the RPC bus.
+// Close shuts the node down. Components are stopped in order, each one only
+// once the ones publishing to it have returned:
+//   - the consensus and the chain, which publish blocks and consensus messages
+//   - the kadcast peer, whose writers are subscribed to the event bus
+//   - the remaining long-lived goroutines, bound to the server context
+//   - the RPC and the event buses
 func (s *Server) Close() {
+	// Stop the consensus and wait for the chain goroutines
+	if s.c != nil {
+		s.c.Close()
+	}
+
+	// kadcast client grpc
+	if s.kadPeer != nil {
+		s.kadPeer.Close()
+	}
+
 	// Cancel all goroutines long-lived loops
 	s.cancel()
 
@@ -219,11 +251,8 @@ func (s *Server) Close() {
 	}
 
 	// Close Rusk client connection
-	_ = s.ruskConn.Close()
-
-	// kadcast client grpc
-	if s.kadPeer != nil {
-		s.kadPeer.Close()
+	if s.ruskConn != nil {
+		_ = s.ruskConn.Close()
 	}
 
 	if s.dbDriver != nil {
@@ -242,7 +271,6 @@ func registerPeerServices(processor *peer.MessageProcessor, db database.DB, even
 	dataRequestor := responding.NewDataRequestor(db, rpcBus)
 	bhb := responding.NewBlockHashBroker(db)
 	cb := responding.NewCandidateBroker(db)
-	cp := consensus.NewPublisher(eventBus)
 
 	processor.Register(topics.GetData, dataBroker.MarshalObjects)
 	processor.Register(topics.MemPool, dataBroker.MarshalMempoolTxs)
@@ -251,11 +279,18 @@ func registerPeerServices(processor *peer.MessageProcessor, db database.DB, even
 	processor.Register(topics.Inv, dataRequestor.RequestMissingItems)
 	processor.Register(topics.GetBlocks, bhb.AdvertiseMissingBlocks)
 	processor.Register(topics.GetCandidate, cb.ProvideCandidate)
+	processor.Register(topics.Challenge, responding.CompleteChallenge)
+}
+
+// registerConsensusServices forwards the consensus messages received from the
+// network to the consensus loop.
+func registerConsensusServices(processor *peer.MessageProcessor, eventBus *eventbus.EventBus) {
+	cp := consensus.NewPublisher(eventBus)
+
 	processor.Register(topics.NewBlock, cp.Process)
 	processor.Register(topics.Reduction, cp.Process)
 	processor.Register(topics.Agreement, cp.Process)
 	processor.Register(topics.AggrAgreement, cp.Process)
-	processor.Register(topics.Challenge, responding.CompleteChallenge)
 }
 
 func setupGRPCClients(ctx context.Context) (transactions.Proxy, *grpc.ClientConn) {
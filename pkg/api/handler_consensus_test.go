@@ -7,8 +7,15 @@
 package api
 
 import (
+	"bytes"
+	"encoding/csv"
+	"encoding/hex"
+	"encoding/json"
 	"fmt"
+	"net/http"
 	"os"
+	"strconv"
+	"strings"
 	"testing"
 	"time"
 
@@ -18,6 +25,10 @@ import (
 	"github.com/drewolson/testflight"
 	cfg "github.com/dusk-network/dusk-blockchain/pkg/config"
 	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/database"
+	"github.com/dusk-network/dusk-blockchain/pkg/p2p/wire/encoding"
+	"github.com/dusk-network/dusk-blockchain/pkg/p2p/wire/topics"
+	"github.com/dusk-network/dusk-blockchain/pkg/util/nativeutils/rpcbus"
 	"github.com/stretchr/testify/require"
 )
 
@@ -59,6 +70,47 @@ func TestConsensusAPISmokeTest(t *testing.T) {
 	})
 }
 
+func TestChainAPIBlockAtHeight(t *testing.T) {
+	rb := rpcbus.New()
+
+	// Serve a single block, at height 1
+	reqChan := make(chan rpcbus.Request, 1)
+	require.NoError(t, rb.Register(topics.GetBlockAtHeight, reqChan))
+
+	go func() {
+		for r := range reqChan {
+			params := r.Params.(bytes.Buffer)
+
+			var height uint64
+			_ = encoding.ReadUint64LE(&params, &height)
+
+			if height != 1 {
+				r.RespChan <- rpcbus.NewResponse(bytes.Buffer{}, database.ErrBlockNotFound)
+				continue
+			}
+
+			r.RespChan <- rpcbus.NewResponse(*bytes.NewBufferString("block"), nil)
+		}
+	}()
+
+	defer close(reqChan)
+
+	apiServer, err := NewHTTPServer(nil, rb)
+	require.Nil(t, err)
+
+	testflight.WithServer(apiServer.Server.Handler, func(r *testflight.Requester) {
+		response := r.Get("/chain/block?height=1")
+		require.Equal(t, http.StatusOK, response.StatusCode)
+		require.Equal(t, "block", response.Body)
+
+		response = r.Get("/chain/block?height=2")
+		require.Equal(t, http.StatusNotFound, response.StatusCode)
+
+		response = r.Get("/chain/block?height=tip")
+		require.Equal(t, http.StatusBadRequest, response.StatusCode)
+	})
+}
+
 func TestConsensusAPIProvisioners(t *testing.T) {
 	// setup viper timeout
 	cwd, err := os.Getwd()
@@ -120,6 +172,86 @@ func TestConsensusAPIProvisioners(t *testing.T) {
 	})
 }
 
+func TestConsensusAPIExportProvisioners(t *testing.T) {
+	// setup viper timeout
+	cwd, err := os.Getwd()
+	require.Nil(t, err)
+
+	r, err := cfg.LoadFromFile(cwd + "/../../dusk.toml")
+	require.Nil(t, err)
+	cfg.Mock(&r)
+
+	apiServer, err := NewHTTPServer(nil, nil)
+	require.Nil(t, err)
+
+	// Two snapshots, the latest holding a larger provisioner set truncated
+	// to its first 4 members
+	for height, size := range map[uint64]int{1: 3, 2: 5} {
+		provisioners, _ := consensus.MockProvisioners(size)
+		members := make([]*capi.Member, 0, size)
+
+		for i := 0; i < size; i++ {
+			m, err := provisioners.MemberAt(i)
+			require.Nil(t, err)
+
+			member := capi.Member{PublicKeyBLS: m.PublicKeyBLS}
+			for _, s := range m.Stakes {
+				member.Stakes = append(member.Stakes, capi.Stake{Value: s.Value, Eligibility: s.Eligibility})
+			}
+
+			members = append(members, &member)
+		}
+
+		snapshot := capi.ProvisionerJSON{ID: height, Set: provisioners.Set, Members: members, Total: size}
+		if size > 4 {
+			snapshot.Set = snapshot.Set[:4]
+			snapshot.Members = snapshot.Members[:4]
+		}
+
+		require.Nil(t, apiServer.store.Save(&snapshot))
+	}
+
+	var latest capi.ProvisionerJSON
+	require.Nil(t, apiServer.store.Find("ID", uint64(2), &latest))
+
+	testflight.WithServer(apiServer.Server.Handler, func(r *testflight.Requester) {
+		// The latest snapshot is exported as CSV by default
+		response := r.Get("/consensus/provisioners/export")
+		require.Equal(t, http.StatusOK, response.StatusCode)
+
+		records, err := csv.NewReader(strings.NewReader(response.Body)).ReadAll()
+		require.Nil(t, err)
+		require.Equal(t, "2", response.Header.Get(capi.HeaderSnapshotHeight))
+		require.Equal(t, "5", response.Header.Get(capi.HeaderProvisionersTotal))
+		require.Equal(t, "true", response.Header.Get(capi.HeaderProvisionersTruncated))
+		require.Len(t, records, len(latest.Members)+1)
+		require.Equal(t, []string{"bls_key", "total_stake", "stake_count", "earliest_eligibility", "latest_eligibility"}, records[0])
+
+		for i, m := range latest.Members {
+			var total uint64
+			for _, s := range m.Stakes {
+				total += s.Value
+			}
+
+			require.Equal(t, hex.EncodeToString(m.PublicKeyBLS), records[i+1][0])
+			require.Equal(t, strconv.FormatUint(total, 10), records[i+1][1])
+			require.Equal(t, strconv.Itoa(len(m.Stakes)), records[i+1][2])
+		}
+
+		response = r.Get("/consensus/provisioners/export?height=1&format=json")
+		require.Equal(t, http.StatusOK, response.StatusCode)
+
+		var rows []capi.ProvisionerRow
+		require.Nil(t, json.Unmarshal(response.RawBody, &rows))
+		require.Len(t, rows, 3)
+		require.Equal(t, "3", response.Header.Get(capi.HeaderProvisionersTotal))
+		require.Equal(t, "false", response.Header.Get(capi.HeaderProvisionersTruncated))
+
+		response = r.Get("/consensus/provisioners/export?format=xml")
+		require.Equal(t, http.StatusBadRequest, response.StatusCode)
+	})
+}
+
 func TestConsensusAPIRoundInfo(t *testing.T) {
 	// setup viper timeout
 	cwd, err := os.Getwd()
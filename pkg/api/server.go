@@ -113,9 +113,15 @@ func (s *Server) InitRouting() *pat.Router {
 	// init consensus API services
 	capi.StartAPI(s.eventBus, s.rpcBus)
 
+	// registered first, as routes are matched by path prefix
+	r.HandleFunc("/consensus/provisioners/export", capi.ExportProvisionersHandler).Methods("GET")
 	r.HandleFunc("/consensus/provisioners", capi.GetProvisionersHandler).Methods("GET")
 	r.HandleFunc("/consensus/roundinfo", capi.GetRoundInfoHandler).Methods("GET")
 	r.HandleFunc("/consensus/eventqueuestatus", capi.GetEventQueueStatusHandler).Methods("GET")
+	r.HandleFunc("/consensus/state", capi.GetConsensusStateHandler).Methods("GET")
+	r.HandleFunc("/consensus/steptimings", capi.GetStepTimingsHandler).Methods("GET")
+	r.HandleFunc("/chain/syncstatus", capi.GetSyncStatusHandler).Methods("GET")
+	r.HandleFunc("/chain/block", capi.GetBlockAtHeightHandler).Methods("GET")
 	r.HandleFunc("/p2p/logs", capi.GetP2PLogsHandler).Methods("GET")
 	r.HandleFunc("/p2p/count", capi.GetP2PCountHandler).Methods("GET")
 
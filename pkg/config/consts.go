@@ -24,6 +24,14 @@ const (
 
 	MaxBlockTime = 360 // maximum block time in seconds
 
+	// MaxStakesPerProvisioner is the maximum number of stakes a provisioner
+	// can hold. Blocks exceeding it are rejected.
+	MaxStakesPerProvisioner = 32
+
+	// MaxBlockTimestamp is the upper bound (Unix seconds) of a block header
+	// timestamp. Set to 3000-01-01, far beyond any honest block timestamp.
+	MaxBlockTimestamp = int64(32503680000)
+
 	// KadcastInitialHeight sets the default initial height for Kadcast broadcast algorithm.
 	KadcastInitialHeight byte = 128
 
@@ -34,6 +42,27 @@ const (
 	// Protocol-based consensus step time.
 	DefaultConsensusTimeOutSeconds = 5
 
+	// DefaultMaxRecentBlocks is the default maximum number of blocks returned
+	// by a single recent blocks query.
+	DefaultMaxRecentBlocks = 100
+
+	// DefaultMinPeersTimeout is the default number of seconds to wait for
+	// Consensus.MinPeersToStart peers before starting the consensus anyway.
+	DefaultMinPeersTimeout = 60
+
+	// DefaultMaxFutureBlocks is the default number of out-of-order blocks
+	// buffered until the blocks preceding them are accepted. It allows for a
+	// full sync batch pending acceptance while the next one is received.
+	DefaultMaxFutureBlocks = 2 * MaxInvBlocks
+
+	// DefaultMaxBlockTxs is the default maximum number of transactions
+	// decoded from a single block. Far above what the block gas limit allows.
+	DefaultMaxBlockTxs = 10_000
+
+	// DefaultMaxBlockSize is the default maximum number of bytes decoded
+	// from a single block.
+	DefaultMaxBlockSize = 16 << 20
+
 	// ConsensusTimeThreshold consensus time in seconds above which we don't throttle it.
 	ConsensusTimeThreshold = 10
 
@@ -23,22 +23,23 @@ func GetPresetConfig(name string) (Config, error) {
 	return addDefaultTxs(name, c), nil
 }
 
+// Timestamp is the timestamp of the genesis block of every preset, March 9,
+// 2022 16:10:22 GMT. No block can be timestamped before it.
+const Timestamp int64 = 1646842222
+
 var configurations = map[string]Config{
 	"testnet": {
-		// March 9, 2022 16:10:22 GMT
-		timestamp: 1646842222,
+		timestamp: Timestamp,
 		seed:      make([]byte, 33),
 	},
 	"harness": {
 		// test harness
-		// March 9, 2022 16:10:22 GMT
-		timestamp: 1646842222,
+		timestamp: Timestamp,
 		seed:      make([]byte, 33),
 	},
 	"test": {
 		// This is for unit tests
-		// March 9, 2022 16:10:22 GMT
-		timestamp: 1646842222,
+		timestamp: Timestamp,
 		seed:      make([]byte, 33),
 	},
 }
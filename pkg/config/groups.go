@@ -10,9 +10,39 @@ type generalConfiguration struct {
 	Network string
 }
 
+type nodeConfiguration struct {
+	// ObserverMode runs a node following the chain and serving queries,
+	// without taking part in the consensus. No consensus message (candidate,
+	// score, vote) is produced nor processed.
+	ObserverMode bool
+
+	// RecoverListenerPanics recovers the panics of the eventbus callbacks,
+	// logging them, so that a faulty subscriber does not crash the node.
+	RecoverListenerPanics bool
+}
+
+type genesisConfiguration struct {
+	// Expected timestamp of the genesis block of the network. The node fails
+	// to start if the stored genesis block has a different one. Disabled if
+	// zero.
+	Timestamp int64
+}
+
 type timeoutConfiguration struct {
 	TimeoutGetMempoolTXsBySize int64
 	TimeoutGetMempoolTXs       int64
+
+	// TimeoutSendResponse is the number of milliseconds an rpcbus handler
+	// waits for the requester to receive its response.
+	TimeoutSendResponse int64
+
+	// TimeoutExecuteStateTransition is the number of milliseconds a state
+	// transition executed by Rusk may take (0 = unlimited).
+	TimeoutExecuteStateTransition int64
+
+	// TimeoutGetProvisioners is the number of milliseconds to wait for Rusk
+	// to return the provisioners at startup (0 = unlimited).
+	TimeoutGetProvisioners int64
 }
 
 type loggerConfiguration struct {
@@ -26,6 +56,74 @@ type networkConfiguration struct {
 	MaxDupeMapExpire uint32
 
 	ServiceFlag uint8
+
+	// Number of blocks pending in the sync sequencer above which no more
+	// blocks are requested from peers, until half of them are accepted.
+	// Defaults to MaxInvBlocks if not set.
+	MaxPendingBlocks int
+
+	// Maximum number of transactions and of bytes decoded from a single
+	// block, whether received or stored. Default to DefaultMaxBlockTxs and
+	// DefaultMaxBlockSize if not set.
+	MaxBlockTxs  uint64
+	MaxBlockSize uint64
+
+	// CompactCertificates encodes the empty signatures of a certificate as a
+	// zero length field, instead of 33 zero bytes. Decoding accepts both.
+	CompactCertificates bool
+
+	// Penalties of the peers delivering invalid blocks.
+	Misbehavior misbehaviorConfiguration
+}
+
+// Each block rejected from a peer increases its misbehavior score by the
+// weight of the reason. A reason with a zero weight is not scored. The scores
+// are halved every couple of minutes.
+type misbehaviorConfiguration struct {
+	// Score from which all blocks from a peer are dropped, and the peer is
+	// notified over topics.PeerBanned, until its score decays below it.
+	// Disabled if zero.
+	BanScore uint32
+
+	InvalidBlock       uint32
+	InvalidCertificate uint32
+	FutureTimestamp    uint32
+	Equivocation       uint32
+}
+
+type chainConfiguration struct {
+	// Number of blocks requested per round-trip while syncing. It can not
+	// exceed MaxInvBlocks. Defaults to MaxInvBlocks if not set.
+	SyncBatchSize int
+
+	// DurableAppend flushes an accepted block to disk before advertising it
+	// to the network, instead of advertising it as soon as it is verified.
+	DurableAppend bool
+
+	// Maximum number of out-of-order blocks buffered until the blocks
+	// preceding them are accepted. The oldest one is evicted when full.
+	// Defaults to DefaultMaxFutureBlocks if not set.
+	MaxFutureBlocks int
+
+	// PublishEquivocation enables the publication of
+	// topics.EquivocationDetected when a second block is certified at the tip
+	// height and consensus iteration.
+	PublishEquivocation bool
+
+	// SyncProgressMaxLead caps the number of blocks the highest height seen
+	// from the network may lead the tip by, when computing the sync
+	// progress. Unlimited if zero.
+	SyncProgressMaxLead uint64
+
+	// MaxBlockAge is the number of rounds a certified block received from
+	// the network may be behind the tip. Older blocks are dropped before any
+	// fallback or fork check. Defaults to MaxInvBlocks if not set.
+	MaxBlockAge uint64
+
+	// VerifyWorkers is the number of goroutines verifying the headers of the
+	// blocks queued while syncing, ahead of their acceptance. Disabled if
+	// zero.
+	VerifyWorkers int
 }
 
 type clientConfiguration struct {
@@ -39,6 +137,13 @@ type kadcastConfiguration struct {
 	Address       string
 	BootstrapAddr []string
 
+	// Number of milliseconds within which an identical payload is broadcast
+	// only once. Disabled if zero.
+	DedupWindow int
+	// Maximum number of payload hashes remembered for de-duplication.
+	// Defaults to 1000 if not set.
+	DedupCapacity int
+
 	Grpc clientConfiguration
 }
 
@@ -46,6 +151,28 @@ type kadcastConfiguration struct {
 type databaseConfiguration struct {
 	Driver string
 	Dir    string
+
+	// Number of workers checking the blockchain at startup. Defaults to
+	// the number of CPUs if not set.
+	SanityCheckWorkers int
+
+	// Maximum number of blocks returned by a single recent blocks query.
+	// Defaults to DefaultMaxRecentBlocks if not set.
+	MaxRecentBlocks uint64
+
+	// Number of rounds for which the committee which finalized the block is
+	// kept. Disabled if zero.
+	CommitteeRetention uint64
+
+	// CompactCommittees stores the seed and the certificate bitsets of a
+	// finalizing committee instead of its members, which are derived again
+	// from the stored provisioners when requested.
+	CompactCommittees bool
+
+	// Height up to which the persisted blocks are trusted. Below it, only the
+	// chain linkage is verified at startup. This relies on the integrity of
+	// the local storage. Disabled if zero.
+	TrustedHeight uint64
 }
 
 // pprof configs.
@@ -96,6 +223,9 @@ type gqlConfiguration struct {
 	KeyFile   string
 
 	MaxRequestLimit uint
+	// Maximum number of queries executed at once. Queries above it are
+	// rejected. Unlimited if zero.
+	MaxConcurrentQueries uint
 
 	Notification notificationConfiguration
 }
@@ -108,6 +238,12 @@ type apiConfiguration struct {
 	KeyFile        string
 	DBFile         string
 	ExpirationTime int
+
+	// Number of blocks between two snapshots of the provisioner set. A
+	// snapshot is written on every block if not set.
+	ProvisionersSnapshotInterval uint64
+	// Maximum number of provisioners in a snapshot. Unlimited if not set.
+	MaxSnapshotProvisioners int
 }
 
 type notificationConfiguration struct {
@@ -132,6 +268,10 @@ type mempoolConfiguration struct {
 	// diskpool config
 	DiskPoolDir string
 
+	// File to persist pending txs to on shutdown, and to restore them from
+	// on startup. Disabled if empty.
+	PersistFile string
+
 	// Hashmap config
 	HashMapPreallocTxs uint32
 
@@ -157,6 +297,9 @@ type consensusConfiguration struct {
 
 	// ConsensusTimeOut is the time out for consensus step timers.
 	ConsensusTimeOut int64
+	// SelectionTimeout is the number of milliseconds the selection step waits
+	// for a candidate block. Defaults to ConsensusTimeOut if zero.
+	SelectionTimeout int64
 	// UseCompressedKeys determines if AggregatePks works with compressed or uncompressed pks.
 	UseCompressedKeys bool
 
@@ -166,6 +309,60 @@ type consensusConfiguration struct {
 
 	// ThrottleIterMilli determines number of Milliseconds to throttle VerifyST.
 	ThrottleIterMilli int64
+
+	// MinPeersToStart is the amount of network peers required before
+	// starting the consensus. Disabled if zero.
+	MinPeersToStart uint32
+	// MinPeersTimeout is the maximum number of seconds to wait for
+	// MinPeersToStart peers. Defaults to DefaultMinPeersTimeout.
+	MinPeersTimeout int64
+
+	// MempoolReadyTimeout is the maximum number of seconds to wait for the
+	// mempool to be ready before starting the consensus, so that the first
+	// candidate blocks are not built from an empty mempool. Disabled if
+	// zero.
+	MempoolReadyTimeout int64
+
+	// MaxGenerationTime is the maximum number of milliseconds the generation
+	// of a candidate block can take before being aborted. Disabled if zero.
+	MaxGenerationTime int64
+
+	// MaxConcurrentGenerations is the maximum number of candidate blocks
+	// generated at once within a round, including the generations aborted
+	// by MaxGenerationTime but still running. Generations above it are
+	// dropped. Unlimited if zero.
+	MaxConcurrentGenerations int32
+
+	// TimeoutPerMemberMilli is the number of milliseconds added to the
+	// timeout of a reduction step for each member of its committee.
+	TimeoutPerMemberMilli int64
+	// MaxTimeoutMilli caps the timeout of a reduction step once scaled by
+	// the committee size. Unlimited if zero.
+	MaxTimeoutMilli int64
+
+	// ParallelCertificateCheck enables the verification of the two batched
+	// signatures of a block certificate in parallel.
+	ParallelCertificateCheck bool
+
+	// LateAgreementMilli is the number of milliseconds after a round is
+	// finalized during which late agreement votes for it are still collected
+	// to strengthen the stored certificate. Disabled if zero.
+	LateAgreementMilli int64
+
+	// SuperQuorumThreshold is the share of the agreement committee whose
+	// votes are collected before finalizing a round. It only applies if
+	// greater than ConsensusQuorumThreshold.
+	SuperQuorumThreshold float64
+
+	// MinBlockInterval is the minimum number of seconds between the
+	// timestamp of a generated block and the previous one. It only applies
+	// to the blocks generated by this node. Disabled if zero. It must not
+	// exceed MaxBlockTime.
+	MinBlockInterval int64
+
+	// StepTimings enables the histograms of the consensus step durations,
+	// exposed by the API.
+	StepTimings bool
 }
 
 type stateConfiguration struct {
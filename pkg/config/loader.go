@@ -53,9 +53,12 @@ type Registry struct {
 
 	// All configuration groups.
 	General   generalConfiguration
+	Node      nodeConfiguration
+	Genesis   genesisConfiguration
 	Timeout   timeoutConfiguration
 	Database  databaseConfiguration
 	Network   networkConfiguration
+	Chain     chainConfiguration
 	Kadcast   kadcastConfiguration
 	Mempool   mempoolConfiguration
 	Consensus consensusConfiguration
@@ -167,10 +170,31 @@ func (r *Registry) init(secondary interface{}) error {
 		}
 	}
 
+	if err := r.validate(); err != nil {
+		return err
+	}
+
 	r.UsedConfigFile = viper.ConfigFileUsed()
 	return nil
 }
 
+// validate checks the loaded settings against their protocol bounds.
+func (r *Registry) validate() error {
+	if n := r.Chain.SyncBatchSize; n < 0 || n > MaxInvBlocks {
+		return fmt.Errorf("invalid chain.syncBatchSize %d: must be in range [0, %d]", n, MaxInvBlocks)
+	}
+
+	if n := r.Chain.MaxFutureBlocks; n < 0 {
+		return fmt.Errorf("invalid chain.maxFutureBlocks %d: must not be negative", n)
+	}
+
+	if n := r.Consensus.MinBlockInterval; n < 0 || n > MaxBlockTime {
+		return fmt.Errorf("invalid consensus.minBlockInterval %d: must be in range [0, %d]", n, MaxBlockTime)
+	}
+
+	return nil
+}
+
 func loadFlags() (string, error) {
 	pflag.CommandLine.Init("Dusk node", pflag.ExitOnError)
 
@@ -179,3 +179,55 @@ func Reset() {
 	os.Unsetenv("DUSK_GENERAL_NETWORK")
 	os.Unsetenv("DUSK_LOGGER_LEVEL")
 }
+
+func TestValidateSyncBatchSize(t *testing.T) {
+	r := Registry{}
+
+	for _, n := range []int{0, 1, MaxInvBlocks} {
+		r.Chain.SyncBatchSize = n
+		if err := r.validate(); err != nil {
+			t.Errorf("valid sync batch size %d rejected: %v", n, err)
+		}
+	}
+
+	for _, n := range []int{-1, MaxInvBlocks + 1} {
+		r.Chain.SyncBatchSize = n
+		if err := r.validate(); err == nil {
+			t.Errorf("invalid sync batch size %d accepted", n)
+		}
+	}
+}
+
+func TestValidateMaxFutureBlocks(t *testing.T) {
+	r := Registry{}
+
+	for _, n := range []int{0, 1, DefaultMaxFutureBlocks * 2} {
+		r.Chain.MaxFutureBlocks = n
+		if err := r.validate(); err != nil {
+			t.Errorf("valid max future blocks %d rejected: %v", n, err)
+		}
+	}
+
+	r.Chain.MaxFutureBlocks = -1
+	if err := r.validate(); err == nil {
+		t.Error("negative max future blocks accepted")
+	}
+}
+
+func TestValidateMinBlockInterval(t *testing.T) {
+	r := Registry{}
+
+	for _, n := range []int64{0, 1, MaxBlockTime} {
+		r.Consensus.MinBlockInterval = n
+		if err := r.validate(); err != nil {
+			t.Errorf("valid min block interval %d rejected: %v", n, err)
+		}
+	}
+
+	for _, n := range []int64{-1, MaxBlockTime + 1} {
+		r.Consensus.MinBlockInterval = n
+		if err := r.validate(); err == nil {
+			t.Errorf("invalid min block interval %d accepted", n)
+		}
+	}
+}
@@ -11,9 +11,12 @@ import (
 	"context"
 	"encoding/hex"
 	"errors"
+	"fmt"
 	"sync"
+	"time"
 
 	"github.com/dusk-network/dusk-blockchain/pkg/config"
+	"github.com/dusk-network/dusk-blockchain/pkg/config/genesis"
 	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus"
 	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus/agreement"
 	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus/capi"
@@ -26,6 +29,7 @@ import (
 	"github.com/dusk-network/dusk-blockchain/pkg/core/loop"
 	"github.com/dusk-network/dusk-blockchain/pkg/core/verifiers"
 	"github.com/dusk-network/dusk-blockchain/pkg/p2p/peer/dupemap"
+	"github.com/dusk-network/dusk-blockchain/pkg/p2p/wire/encoding"
 	"github.com/dusk-network/dusk-blockchain/pkg/p2p/wire/message"
 	"github.com/dusk-network/dusk-blockchain/pkg/p2p/wire/topics"
 	"github.com/dusk-network/dusk-blockchain/pkg/util"
@@ -42,6 +46,7 @@ import (
 var (
 	errInvalidStateHash    = errors.New("invalid state hash")
 	errUnexpectedStateHash = errors.New("unexpected state hash")
+	errInvalidCertificate  = errors.New("invalid certificate")
 
 	log = logger.WithFields(logger.Fields{"process": "chain"})
 )
@@ -49,6 +54,27 @@ var (
 // ErrBlockAlreadyAccepted block already known by blockchain state.
 var ErrBlockAlreadyAccepted = errors.New("already accepted")
 
+// ErrGenesisTimestamp the stored genesis block does not have the configured timestamp.
+var ErrGenesisTimestamp = errors.New("unexpected genesis timestamp")
+
+// ErrChainNotEmpty a genesis block is accepted by a chain already holding
+// blocks, or a genesis block of its own.
+var ErrChainNotEmpty = errors.New("chain is not empty")
+
+// ErrNotCurrentRound a committee is requested for a round other than the current one.
+var ErrNotCurrentRound = errors.New("not the current round")
+
+// ErrStateTransitionTimeout a state transition did not complete within
+// Timeout.TimeoutExecuteStateTransition.
+var ErrStateTransitionTimeout = errors.New("state transition timed out")
+
+// CommitteeMember is a provisioner drawn in a voting committee, along with the
+// amount of votes it was assigned.
+type CommitteeMember struct {
+	PubKeyBLS []byte
+	Votes     int
+}
+
 // TODO: This Verifier/Loader interface needs to be re-evaluated and most likely
 // renamed. They don't make too much sense on their own (the `Loader` also
 // appends blocks, and allows for fetching data from the DB), and potentially
@@ -67,7 +93,7 @@ type Verifier interface {
 type Loader interface {
 	// LoadTip of the chain. Returns blockchain tip and persisted hash.
 	LoadTip() (*block.Block, []byte, error)
-	// Clear removes everything from the DB.
+	// Clear removes everything from the DB and re-seeds the genesis block.
 	Clear() error
 	// Close the Loader and finalizes any pending connection.
 	Close(driver string) error
@@ -75,6 +101,18 @@ type Loader interface {
 	Height() (uint64, error)
 	// BlockAt returns the block at a given height.
 	BlockAt(uint64) (block.Block, error)
+	// Iterate calls a function on each block between two heights.
+	Iterate(from, to uint64, fn func(block.Block) error) error
+	// Sync flushes the stored blocks to durable storage.
+	Sync() error
+	// Remove deletes the block at the given height, which must be the tip,
+	// and makes its predecessor the new tip.
+	Remove(height uint64) error
+	// StoreHighestSeen persists the highest block height advertised by the
+	// network.
+	StoreHighestSeen(height uint64) error
+	// LoadHighestSeen returns the highest block height persisted, or zero.
+	LoadHighestSeen() (uint64, error)
 }
 
 // Chain represents the nodes blockchain.
@@ -90,9 +128,12 @@ type Chain struct {
 	// verifier performs verifications on the block.
 	verifier Verifier
 
-	// current blockchain tip of local state.
+	// lock serializes the block processing, and protects the provisioners.
 	lock sync.RWMutex
-	tip  *block.Block
+
+	// state read by the off-loop handlers: tip, finalized and highest seen
+	// heights.
+	chainState chainState
 
 	// Current set of provisioners.
 	p *user.Provisioners
@@ -104,15 +145,35 @@ type Chain struct {
 
 	// Syncing related things.
 	*synchronizer
-	highestSeen uint64
 
 	// rusk client.
 	proxy transactions.Proxy
 
-	ctx context.Context
+	ctx    context.Context
+	cancel context.CancelFunc
+
+	// wg tracks the long-lived goroutines, so that Close can wait for them.
+	wg sync.WaitGroup
 
 	blacklisted dupemap.TmpMap
 	verified    sortedset.SafeSet
+
+	// blocks recently rejected, per peer.
+	rejected *rejectedBlocks
+
+	// txHistory indexes the txs of accepted blocks by address.
+	txHistory *txHistoryIndexer
+
+	// snapshots queues the provisioner snapshots for the API, which are
+	// stored in order by a single worker.
+	snapshots chan provisionersSnapshot
+
+	// metrics receives the block acceptance measurements.
+	metrics MetricsSink
+
+	// pipeline verifies ahead the blocks queued while syncing. Nil unless
+	// Chain.VerifyWorkers is set.
+	pipeline *verifyPipeline
 }
 
 // New returns a new chain object. It accepts the EventBus (for messages coming
@@ -120,6 +181,8 @@ type Chain struct {
 func New(ctx context.Context, db database.DB, eventBus *eventbus.EventBus, rpcBus *rpcbus.RPCBus,
 	loader Loader, verifier Verifier, srv *grpc.Server, proxy transactions.Proxy, loop *loop.Consensus,
 ) (*Chain, error) {
+	ctx, cancel := context.WithCancel(ctx)
+
 	chain := &Chain{
 		eventBus:          eventBus,
 		rpcBus:            rpcBus,
@@ -128,33 +191,149 @@ func New(ctx context.Context, db database.DB, eventBus *eventbus.EventBus, rpcBu
 		verifier:          verifier,
 		proxy:             proxy,
 		ctx:               ctx,
+		cancel:            cancel,
 		loop:              loop,
 		stopConsensusChan: make(chan struct{}),
 		blacklisted:       *dupemap.NewTmpMap(1000, 120),
 		verified:          sortedset.NewSafeSet(),
+		rejected:          newRejectedBlocks(eventBus),
+		txHistory:         newTxHistoryIndexer(db),
+		snapshots:         make(chan provisionersSnapshot, snapshotQueueSize),
+		metrics:           noopMetrics{},
 	}
 
 	chain.synchronizer = newSynchronizer(db, chain)
 
-	provisioners, err := proxy.Executor().GetProvisioners(ctx)
-	if err != nil {
-		log.WithError(err).Error("Error in getting provisioners")
-		return nil, err
+	chain.spawn(func() { chain.txHistory.run(ctx) })
+	chain.spawn(func() { chain.storeSnapshots(ctx) })
+
+	if n := config.Get().Chain.VerifyWorkers; n > 0 {
+		chain.pipeline = newVerifyPipeline(func(prev, blk block.Block) error {
+			return chain.verifier.SanityCheckBlock(prev, blk)
+		}, int(syncBatchSize()))
+
+		for i := 0; i < n; i++ {
+			chain.spawn(func() { chain.pipeline.run(ctx) })
+		}
 	}
 
+	chain.p = chain.loadProvisioners()
+
 	if srv != nil {
 		node.RegisterChainServer(srv, chain)
 	}
 
-	chain.p = &provisioners
+	getCommitteeChan := make(chan rpcbus.Request, 1)
+	if err := rpcBus.Register(topics.GetCommitteeAtRound, getCommitteeChan); err != nil {
+		log.WithError(err).Error("failed to register topics.GetCommitteeAtRound")
+	} else {
+		chain.spawn(func() { chain.serveCommitteeRequests(getCommitteeChan) })
+	}
+
+	submitBlockChan := make(chan rpcbus.Request, 1)
+	if err := rpcBus.Register(topics.SubmitBlock, submitBlockChan); err != nil {
+		log.WithError(err).Error("failed to register topics.SubmitBlock")
+	} else {
+		chain.spawn(func() { chain.serveSubmitBlockRequests(submitBlockChan) })
+	}
+
+	syncStatusChan := make(chan rpcbus.Request, 1)
+	if err := rpcBus.Register(topics.GetSyncProgress, syncStatusChan); err != nil {
+		log.WithError(err).Error("failed to register topics.GetSyncProgress")
+	} else {
+		chain.spawn(func() { chain.serveSyncStatusRequests(syncStatusChan) })
+	}
+
+	getBlockChan := make(chan rpcbus.Request, 1)
+	if err := rpcBus.Register(topics.GetBlockAtHeight, getBlockChan); err != nil {
+		log.WithError(err).Error("failed to register topics.GetBlockAtHeight")
+	} else {
+		chain.spawn(func() { chain.serveBlockRequests(getBlockChan) })
+	}
+
+	consensusStateChan := make(chan rpcbus.Request, 1)
+	if err := rpcBus.Register(topics.GetConsensusState, consensusStateChan); err != nil {
+		log.WithError(err).Error("failed to register topics.GetConsensusState")
+	} else {
+		chain.spawn(func() { chain.serveConsensusStateRequests(consensusStateChan) })
+	}
 
 	if err := chain.syncWithRusk(); err != nil {
+		chain.Close()
 		return nil, err
 	}
 
+	if err := chain.checkGenesisTimestamp(); err != nil {
+		log.WithError(err).Error("genesis block mismatch")
+		chain.Close()
+		return nil, err
+	}
+
+	chain.restoreHighestSeen()
+
 	return chain, nil
 }
 
+// loadProvisioners fetches the current provisioners from Rusk, waiting up to
+// Timeout.TimeoutGetProvisioners. If Rusk does not respond, it returns an empty
+// set, populated on the next accepted block.
+func (c *Chain) loadProvisioners() *user.Provisioners {
+	ctx := c.ctx
+
+	if timeout := config.Get().Timeout.TimeoutGetProvisioners; timeout > 0 {
+		var cancel context.CancelFunc
+
+		ctx, cancel = context.WithTimeout(c.ctx, time.Duration(timeout)*time.Millisecond)
+		defer cancel()
+	}
+
+	provisioners, err := c.proxy.Executor().GetProvisioners(ctx)
+	if err != nil {
+		log.WithError(err).Warn("could not load provisioners, waiting for the next accepted block")
+		return user.NewProvisioners()
+	}
+
+	return &provisioners
+}
+
+// Close stops the consensus loop and all the chain goroutines, and waits for
+// them to return. No more blocks are accepted nor published afterwards.
+func (c *Chain) Close() {
+	c.StopConsensus()
+	c.cancel()
+	c.wg.Wait()
+}
+
+// spawn runs fn in a goroutine Close waits for.
+func (c *Chain) spawn(fn func()) {
+	c.wg.Add(1)
+
+	go func() {
+		defer c.wg.Done()
+		fn()
+	}()
+}
+
+// checkGenesisTimestamp ensures the stored genesis block has the timestamp of
+// the network genesis, as set in Genesis.Timestamp.
+func (c *Chain) checkGenesisTimestamp() error {
+	expected := config.Get().Genesis.Timestamp
+	if expected == 0 {
+		return nil
+	}
+
+	genesis, err := c.loader.BlockAt(0)
+	if err != nil {
+		return err
+	}
+
+	if genesis.Header.Timestamp != expected {
+		return fmt.Errorf("%w: %d, expected %d", ErrGenesisTimestamp, genesis.Header.Timestamp, expected)
+	}
+
+	return nil
+}
+
 func (c *Chain) syncWithRusk() error {
 	var (
 		err           error
@@ -173,6 +352,11 @@ func (c *Chain) syncWithRusk() error {
 		return err
 	}
 
+	// Store the chain hashes missing, before re-accepting any block
+	if err = backfillChainHashes(c.db, prevBlock.Header.Height); err != nil {
+		return err
+	}
+
 	// Detect if both services are on the different state
 	var persitedBlock *block.Block
 
@@ -198,7 +382,7 @@ func (c *Chain) syncWithRusk() error {
 	}
 
 	// Update blockchain tip (in-memory)
-	c.tip = persitedBlock
+	c.setTip(persitedBlock)
 
 	// If both persisted block hash and latest blockchain block hash are the
 	// same then there is no need to execute sync-up.
@@ -232,7 +416,14 @@ func (c *Chain) syncWithRusk() error {
 
 		// Re-accepting all blocks that have not been persisted in Rusk.
 		// This will re-execute accept/finalize accordingly and update chain tip.
-		if err := c.acceptBlock(*blk, false); err != nil {
+		// Blocks up to the trusted height skip the certificate verification.
+		if blk.Header.Height <= config.Get().Database.TrustedHeight {
+			err = c.acceptTrustedBlock(*blk)
+		} else {
+			err = c.acceptBlock(*blk, false)
+		}
+
+		if err != nil {
 			return err
 		}
 	}
@@ -248,8 +439,12 @@ func (c *Chain) syncWithRusk() error {
 func (c *Chain) ProcessBlockFromNetwork(srcPeerID string, m message.Message) ([]bytes.Buffer, error) {
 	blk := m.Payload().(block.Block)
 
-	// Ensure the received block provides a valid hash
-	if err := verifiers.CheckHash(&blk); err != nil {
+	if err := c.preFilterBlock(&blk); err != nil {
+		if err == errStaleBlock {
+			log.WithField("recv_blk_h", blk.Header.Height).Trace("filter out stale block")
+			return nil, nil
+		}
+
 		return nil, err
 	}
 
@@ -257,7 +452,7 @@ func (c *Chain) ProcessBlockFromNetwork(srcPeerID string, m message.Message) ([]
 	defer c.lock.Unlock()
 
 	l := log.WithField("recv_blk_h", blk.Header.Height).
-		WithField("curr_h", c.tip.Header.Height)
+		WithField("curr_h", c.tip().Header.Height)
 
 	if m.Metadata() != nil {
 		l = l.WithField("kad_h", m.Metadata().KadcastHeight)
@@ -272,20 +467,45 @@ func (c *Chain) ProcessBlockFromNetwork(srcPeerID string, m message.Message) ([]
 		return nil, nil
 	}
 
+	// Drop the blocks of a peer which misbehaved too much, until its score
+	// decays.
+	if c.rejected.banned(srcPeerID) {
+		l.WithField("hash", util.StringifyBytes(h)).
+			WithField("r_addr", srcPeerID).
+			WithField("score", c.rejected.score(srcPeerID)).
+			Debug("filter out block from banned peer")
+		return nil, nil
+	}
+
+	// Drop a known-bad block re-delivered by the same peer, without verifying
+	// it again.
+	if c.rejected.has(srcPeerID, h) {
+		l.WithField("hash", util.StringifyBytes(h)).
+			WithField("r_addr", srcPeerID).
+			WithField("score", c.rejected.score(srcPeerID)).
+			Warn("filter out rejected block")
+		return nil, nil
+	}
+
 	switch {
-	case blk.Header.Height == c.tip.Header.Height:
+	case blk.Header.Height == c.tip().Header.Height:
 		{
 			// Check if we already accepted this block
-			if bytes.Equal(blk.Header.Hash, c.tip.Header.Hash) {
+			if bytes.Equal(blk.Header.Hash, c.tip().Header.Hash) {
 				l.WithError(ErrBlockAlreadyAccepted).Debug("discard block")
 				return nil, nil
 			}
 
-			hash := c.tip.Header.Hash
+			hash := c.tip().Header.Hash
 
 			// Try to fallback
 			if err := c.tryFallback(blk); err != nil {
 				l.WithError(err).Error("failed fallback procedure")
+
+				if errors.Is(err, errEquivocation) {
+					c.rejected.penalize(srcPeerID, misbehaviorEquivocation)
+				}
+
 				return nil, nil
 			}
 
@@ -294,9 +514,9 @@ func (c *Chain) ProcessBlockFromNetwork(srcPeerID string, m message.Message) ([]
 			// out if any other node propagates it back when this node is syncing up.
 			c.blacklisted.Add(bytes.NewBuffer(hash))
 
-			return c.synchronizer.processBlock(srcPeerID, c.tip.Header.Height, blk, m.Metadata())
+			return c.synchronizer.processBlock(srcPeerID, c.tip().Header.Height, blk, m.Metadata())
 		}
-	case blk.Header.Height < c.tip.Header.Height:
+	case blk.Header.Height < c.tip().Header.Height:
 		l.Debug("discard block")
 
 		// Due to a network glitch, the fallback procedure may be skipped.
@@ -309,24 +529,94 @@ func (c *Chain) ProcessBlockFromNetwork(srcPeerID string, m message.Message) ([]
 				l.WithField("recv_blk_step", blk.Header.Certificate.Step).
 					WithField("recv_blk_hash", hex.EncodeToString(h)).
 					WithField("event", "fallback").Error("fork detected")
+
+				// Switch to the branch of the block, if it is agreed on an
+				// earlier iteration.
+				if err := c.reorganizeTo(c.ctx, blk); err != nil {
+					l.WithError(err).Warn("could not reorganize the chain")
+				}
 			}
 		}
 
 		return nil, nil
 	}
 
-	if blk.Header.Height > c.highestSeen {
-		c.highestSeen = blk.Header.Height
+	c.observeHeight(blk.Header.Height)
+
+	// Only the peer delivering an invalid block misbehaves. Failures of the
+	// node, or of another block accepted along with this one, are not
+	// accounted to it.
+	res, err := c.synchronizer.processBlock(srcPeerID, c.tip().Header.Height, blk, m.Metadata())
+	if verifiers.IsBlockError(err, h) {
+		c.rejected.add(srcPeerID, h, err)
+	}
+
+	return res, err
+}
+
+// observeHeight records the height of a block received from the network, and
+// persists it if it is the highest seen so far.
+func (c *Chain) observeHeight(height uint64) {
+	if !c.chainState.observeHeight(height) {
+		return
 	}
 
-	return c.synchronizer.processBlock(srcPeerID, c.tip.Header.Height, blk, m.Metadata())
+	if err := c.loader.StoreHighestSeen(c.chainState.snapshot().highestSeen); err != nil {
+		log.WithError(err).Warn("could not persist the highest height seen")
+	}
+}
+
+// restoreHighestSeen restores the highest height seen before the node
+// restarted, so that the sync progress is sensible before any block is
+// received. It is clamped to the tip height.
+func (c *Chain) restoreHighestSeen() {
+	height, err := c.loader.LoadHighestSeen()
+	if err != nil {
+		log.WithError(err).Warn("could not load the highest height seen")
+		return
+	}
+
+	c.chainState.restoreHighestSeen(height)
 }
 
 // TryNextConsecutiveBlockOutSync is the processing path for accepting a block
 // from the network during out-of-sync state.
 func (c *Chain) TryNextConsecutiveBlockOutSync(blk block.Block, metadata *message.Metadata) error {
 	log.WithField("height", blk.Header.Height).Trace("accepting sync block")
-	return c.acceptBlock(blk, true)
+
+	// The sanity check is skipped if the block is already verified against
+	// the chain tip.
+	withSanityCheck := true
+
+	if c.pipeline != nil {
+		checked, err := c.pipeline.take(c.ctx, *c.tip(), blk)
+		if err != nil {
+			log.WithField("height", blk.Header.Height).
+				WithError(err).Error("invalid block error")
+
+			c.countBlock(err)
+			return err
+		}
+
+		withSanityCheck = !checked
+	}
+
+	return c.acceptBlock(blk, withSanityCheck)
+}
+
+// QueueVerification verifies ahead the headers of consecutive blocks about to
+// be accepted while syncing, the first one following the chain tip. It is a
+// no-op unless Chain.VerifyWorkers is set.
+func (c *Chain) QueueVerification(blks []block.Block) {
+	if c.pipeline == nil {
+		return
+	}
+
+	prev := *c.tip()
+	for _, blk := range blks {
+		c.pipeline.submit(prev, blk)
+		prev = blk
+	}
 }
 
 // TryNextConsecutiveBlockInSync is the processing path for accepting a block
@@ -355,20 +645,70 @@ func (c *Chain) TryNextConsecutiveBlockIsValid(blk block.Block) error {
 		"event":    "check_block",
 		"height":   blk.Header.Height,
 		"hash":     util.StringifyBytes(blk.Header.Hash),
-		"curr_h":   c.tip.Header.Height,
+		"curr_h":   c.tip().Header.Height,
 		"prov_num": c.p.Set.Len(),
 	}
 
 	l := log.WithFields(fields)
 
-	return c.isValidHeader(blk, *c.tip, *c.p, l, true)
+	return c.isValidHeader(blk, *c.tip(), *c.p, l, true)
+}
+
+// AcceptGenesis seeds the chain with a genesis block. It is meant for tools
+// that construct a network from scratch. The block goes through the
+// structural checks of a regular block, except the ones binding it to a
+// previous block, and is then stored as the chain tip.
+// Returns error if the block is not at genesis height, or if the chain holds
+// anything but the default genesis block seeded by the loader.
+func (c *Chain) AcceptGenesis(blk block.Block) error {
+	l := log.WithField("event", "accept_genesis").
+		WithField("hash", util.StringifyBytes(blk.Header.Hash))
+
+	if err := verifiers.CheckGenesisBlockHeader(blk); err != nil {
+		l.WithError(err).Error("invalid genesis block")
+		return err
+	}
+
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	height, err := c.loader.Height()
+	if err != nil {
+		return err
+	}
+
+	if height != 0 || !bytes.Equal(c.tip().Header.Hash, genesis.Decode().Header.Hash) {
+		l.WithField("height", height).Error("chain is not empty")
+		return ErrChainNotEmpty
+	}
+
+	if err = c.db.Update(func(t database.Transaction) error {
+		return t.StoreBlock(&blk, true)
+	}); err != nil {
+		l.WithError(err).Error("persisting genesis block failed")
+		return err
+	}
+
+	// The genesis block brings in the initial provisioner set.
+	provisioners, err := c.proxy.Executor().GetProvisioners(c.ctx)
+	if err != nil {
+		l.WithError(err).Error("Error in getting provisioners")
+		return err
+	}
+
+	c.p = &provisioners
+	c.setTip(&blk)
+	c.verified.Reset()
+
+	l.WithField("prov", c.p.Set.Len()).Info("genesis block accepted")
+	return nil
 }
 
 // ProcessSyncTimerExpired called by outsync timer when a peer does not provide GetData response.
 // It implements transition back to inSync state.
 // strPeerAddr is the address of the peer initiated the syncing but failed to deliver.
 func (c *Chain) ProcessSyncTimerExpired(strPeerAddr string) error {
-	log.WithField("curr", c.tip.Header.Height).
+	log.WithField("curr", c.tip().Header.Height).
 		WithField("src_addr", strPeerAddr).Warn("sync timer expired")
 
 	c.lock.Lock()
@@ -381,33 +721,113 @@ func (c *Chain) ProcessSyncTimerExpired(strPeerAddr string) error {
 	log.WithField("state", "inSync").Traceln("change sync state")
 
 	c.state = c.inSync
+	c.setSyncPhase(SyncIdle)
+	return nil
+}
+
+// RequestBlockAtHeight asks a peer for up to count blocks from the given
+// height. As blocks can not be requested by height on the wire, the request
+// is a GetBlocks message located at the block preceding it, which must be
+// known to this node.
+func (c *Chain) RequestBlockAtHeight(peerAddr string, height, count uint64) error {
+	if height == 0 {
+		return errors.New("genesis block can not be requested")
+	}
+
+	var hash []byte
+
+	if err := c.db.View(func(t database.Transaction) error {
+		var err error
+		hash, err = t.FetchBlockHashByHeight(height - 1)
+		return err
+	}); err != nil {
+		return err
+	}
+
+	bufs, err := marshalGetBlocks(createGetBlocksMsg(hash, count))
+	if err != nil {
+		return err
+	}
+
+	log.WithField("height", height).
+		WithField("count", count).
+		WithField("r_addr", peerAddr).
+		Debug("request missing blocks")
+
+	metadata := &message.Metadata{Source: peerAddr}
+	if errList := c.eventBus.Publish(topics.KadcastSendToOne, message.NewWithMetadata(topics.GetBlocks, bufs[0], metadata)); len(errList) > 0 {
+		return errList[0]
+	}
+
 	return nil
 }
 
+// AcceptBlockResult reports which steps of a block acceptance have run.
+type AcceptBlockResult struct {
+	// Stored is true if the block is persisted as the new chain tip.
+	Stored bool
+	// Flushed is true if the block is flushed to disk, with
+	// Chain.DurableAppend set.
+	Flushed bool
+	// Advertised is true if the block is propagated to the network.
+	Advertised bool
+	// Notified is true if all the subsystems are notified of the accepted
+	// block.
+	Notified bool
+}
+
 // acceptSuccessiveBlock will accept a block which directly follows the chain
 // tip, and advertises it to the node's peers.
 func (c *Chain) acceptSuccessiveBlock(blk block.Block, metadata *message.Metadata) error {
+	_, err := c.acceptSuccessiveBlockWithResult(blk, metadata)
+	return err
+}
+
+// acceptSuccessiveBlockWithResult is acceptSuccessiveBlock, reporting which
+// steps of the acceptance have run. A valid block is accepted even if it could
+// not be advertised.
+func (c *Chain) acceptSuccessiveBlockWithResult(blk block.Block, metadata *message.Metadata) (AcceptBlockResult, error) {
 	log.WithField("height", blk.Header.Height).Trace("accepting succeeding block")
 
-	if err := c.isValidHeader(blk, *c.tip, *c.p, log, true); err != nil {
+	if _, err := c.verifyHeader(blk, log, true); err != nil {
 		log.WithError(err).Error("invalid block")
-		return err
+		return AcceptBlockResult{}, err
 	}
 
-	if err := c.kadcastBlock(blk, metadata); err != nil {
-		log.WithError(err).Error("block propagation failed")
-		return err
+	// A durable block is advertised only once it is on disk, so that peers
+	// never fetch a block this node may forget on a crash.
+	durable := config.Get().Chain.DurableAppend
+
+	var advertised bool
+	if !durable {
+		advertised = c.advertiseBlock(blk, metadata)
 	}
 
-	if err := c.acceptBlock(blk, true); err != nil {
-		return err
+	res, err := c.acceptBlockWithResult(blk, true)
+	if durable && res.Flushed {
+		advertised = c.advertiseBlock(blk, metadata)
 	}
 
-	if blk.Header.Height > c.highestSeen {
-		c.highestSeen = blk.Header.Height
+	res.Advertised = advertised
+
+	if err != nil {
+		return res, err
 	}
 
-	return nil
+	c.observeHeight(blk.Header.Height)
+
+	return res, nil
+}
+
+// advertiseBlock propagates a block to the network. It returns false if the
+// block could not be advertised.
+func (c *Chain) advertiseBlock(blk block.Block, metadata *message.Metadata) bool {
+	if err := c.kadcastBlock(blk, metadata); err != nil {
+		log.WithError(err).Error("block propagation failed")
+		return false
+	}
+
+	return true
 }
 
 // runStateTransition performs state transition and returns a block with gasSpent field populated for each tx.
@@ -423,7 +843,7 @@ func (c *Chain) runStateTransition(tipBlk, blk block.Block) (*block.Block, error
 			"height":     blk.Header.Height,
 			"cert_step":  blk.Header.Certificate.Step,
 			"hash":       util.StringifyBytes(blk.Header.Hash),
-			"curr_h":     c.tip.Header.Height,
+			"curr_h":     c.tip().Header.Height,
 			"block_time": blk.Header.Timestamp - tipBlk.Header.Timestamp,
 			"txs_count":  len(blk.Txs),
 		}
@@ -436,7 +856,7 @@ func (c *Chain) runStateTransition(tipBlk, blk block.Block) (*block.Block, error
 	}
 
 	provisionersCount = c.p.Set.Len()
-	eligibleProvisioners := c.p.SubsetSizeAt(c.tip.Header.Height)
+	eligibleProvisioners := c.p.SubsetSizeAt(c.tip().Header.Height)
 
 	l.WithField("prov", provisionersCount).
 		WithField("e_prov", eligibleProvisioners).
@@ -456,6 +876,10 @@ func (c *Chain) runStateTransition(tipBlk, blk block.Block) (*block.Block, error
 			c.p,
 		)
 		if err != nil {
+			if cErr := c.checkCanceled("finalize"); cErr != nil {
+				return block.NewBlock(), cErr
+			}
+
 			l.WithError(err).
 				WithField("grpc", "finalize").
 				Error("Error in executing the state transition")
@@ -490,6 +914,10 @@ func (c *Chain) runStateTransition(tipBlk, blk block.Block) (*block.Block, error
 			blk.Header.Height,
 			blk.Header.GasLimit, blk.Header.GeneratorBlsPubkey, c.p)
 		if err != nil {
+			if cErr := c.checkCanceled("accept"); cErr != nil {
+				return block.NewBlock(), cErr
+			}
+
 			l.WithError(err).
 				WithField("grpc", "accept").
 				Error("Error in executing the state transition")
@@ -504,7 +932,21 @@ func (c *Chain) runStateTransition(tipBlk, blk block.Block) (*block.Block, error
 			WithField("node", util.StringifyBytes(blk.Header.StateHash)).
 			WithError(errInvalidStateHash).Error("inconsistency with state_hash")
 
-		return block.NewBlock(), errInvalidStateHash
+		return block.NewBlock(), verifiers.NewBlockError(blk, errInvalidStateHash)
+	}
+
+	// The gas spent is not part of the block received from the network, hence
+	// the one reported by Rusk is checked.
+	if err = verifiers.CheckBlockGas(blk.Header.GasLimit, txs); err != nil {
+		l.WithError(err).Error("invalid block gas")
+		return block.NewBlock(), verifiers.NewBlockError(blk, err)
+	}
+
+	// The stakes admitted by the block must not push any provisioner over
+	// the limit.
+	if err = verifiers.CheckProvisionerStakes(provisionersUpdated); err != nil {
+		l.WithError(err).Error("invalid provisioners")
+		return block.NewBlock(), verifiers.NewBlockError(blk, err)
 	}
 
 	// Tamper block transactions with ones return by Rusk service in order to persist GasSpent per transaction.
@@ -526,7 +968,7 @@ func (c *Chain) runStateTransition(tipBlk, blk block.Block) (*block.Block, error
 	// Update the provisioners.
 	// blk.Txs may bring new provisioners to the current state
 	c.p = &provisionersUpdated
-	eligibleProvisioners = c.p.SubsetSizeAt(c.tip.Header.Height + 1)
+	eligibleProvisioners = c.p.SubsetSizeAt(c.tip().Header.Height + 1)
 
 	l.WithField("prov", c.p.Set.Len()).
 		WithField("added", c.p.Set.Len()-provisionersCount).
@@ -544,7 +986,7 @@ func (c *Chain) runStateTransition(tipBlk, blk block.Block) (*block.Block, error
 
 // sanityCheckStateHash ensures most recent local statehash and rusk statehash are the same.
 func (c *Chain) sanityCheckStateHash() error {
-	if c.tip.Header.Height == 0 {
+	if c.tip().Header.Height == 0 {
 		return nil
 	}
 
@@ -556,7 +998,7 @@ func (c *Chain) sanityCheckStateHash() error {
 		return err
 	}
 
-	nodeStateHash := c.tip.Header.StateHash
+	nodeStateHash := c.tip().Header.StateHash
 
 	if !bytes.Equal(nodeStateHash, ruskStateHash) || len(nodeStateHash) == 0 {
 		log.WithField("rusk", hex.EncodeToString(ruskStateHash)).
@@ -574,7 +1016,11 @@ func (c *Chain) isValidHeader(newBlock, prevBlock block.Block, provisioners user
 	l.Debug("verifying block header")
 	// Check that stateless and stateful checks pass
 	if withSanityCheck {
-		if err := c.verifier.SanityCheckBlock(prevBlock, newBlock); err != nil {
+		start := time.Now()
+		err := c.verifier.SanityCheckBlock(prevBlock, newBlock)
+		c.observe(PhaseSanityCheck, start)
+
+		if err != nil {
 			l.WithError(err).Error("block header verification failed")
 			return err
 		}
@@ -589,7 +1035,7 @@ func (c *Chain) isValidHeader(newBlock, prevBlock block.Block, provisioners user
 	var err error
 	if err = agreement.CheckBlockCertificate(provisioners, newBlock, prevBlock.Header.Seed); err != nil {
 		l.WithError(err).Error("certificate verification failed")
-		return err
+		return fmt.Errorf("%w: %s", errInvalidCertificate, err)
 	}
 
 	return nil
@@ -600,47 +1046,181 @@ func (c *Chain) isValidHeader(newBlock, prevBlock block.Block, provisioners user
 // 2. All stateless and stateful checks are true
 // Returns nil, if checks passed and block was successfully saved.
 func (c *Chain) acceptBlock(blk block.Block, withSanityCheck bool) error {
+	_, err := c.acceptBlockWithResult(blk, withSanityCheck)
+	return err
+}
+
+// acceptBlockWithResult is acceptBlock, reporting which steps of the
+// acceptance have run.
+func (c *Chain) acceptBlockWithResult(blk block.Block, withSanityCheck bool) (res AcceptBlockResult, err error) {
+	defer func() { c.countBlock(err) }()
+
 	fields := logger.Fields{
 		"event":     "accept_block",
 		"height":    blk.Header.Height,
 		"cert_step": blk.Header.Certificate.Step,
 		"hash":      util.StringifyBytes(blk.Header.Hash),
-		"curr_h":    c.tip.Header.Height,
+		"curr_h":    c.tip().Header.Height,
 		"prov_num":  c.p.Set.Len(),
 	}
 
 	l := log.WithFields(fields)
-	var err error
 
-	// 1. Ensure block fields and certificate are valid
-	if err = c.isValidHeader(blk, *c.tip, *c.p, l, withSanityCheck); err != nil {
+	if err := c.checkCanceled("header verification"); err != nil {
+		return AcceptBlockResult{}, err
+	}
+
+	// 1. Ensure block fields and certificate are valid. The state transition
+	// updates the provisioners, hence keep the ones the certificate is
+	// verified with.
+	provisioners, err := c.verifyHeader(blk, l, withSanityCheck)
+	if err != nil {
 		l.WithError(err).Error("invalid block error")
+		return AcceptBlockResult{}, err
+	}
+
+	return c.commitBlock(blk, provisioners, l)
+}
+
+// verifyHeader checks the header and the certificate of a block following the
+// chain tip. It returns the provisioners the certificate is verified with.
+func (c *Chain) verifyHeader(blk block.Block, l *logrus.Entry, withSanityCheck bool) (user.Provisioners, error) {
+	provisioners := *c.p
+
+	err := c.isValidHeader(blk, *c.tip(), provisioners, l, withSanityCheck)
+	if err == nil || !errors.Is(err, errInvalidCertificate) {
+		return provisioners, err
+	}
+
+	// The certificate may fail only because the provisioners are stale, e.g.
+	// on the first block accepted after a restart.
+	fresh, retryErr := c.retryWithFreshProvisioners(blk, provisioners, l)
+	if cErr := c.checkCanceled("provisioners refresh"); cErr != nil {
+		return provisioners, cErr
+	}
+
+	// The certificate is deemed invalid only if it fails against the
+	// provisioners known by Rusk as well.
+	if errors.Is(retryErr, errInvalidCertificate) {
+		return provisioners, verifiers.NewBlockError(blk, err)
+	}
+
+	if retryErr != nil {
+		return provisioners, err
+	}
+
+	c.p = &fresh
+	return fresh, nil
+}
+
+// retryWithFreshProvisioners checks the certificate of blk once more, with the
+// provisioners currently known by Rusk. It returns them if the certificate is
+// valid against them.
+func (c *Chain) retryWithFreshProvisioners(blk block.Block, stale user.Provisioners, l *logrus.Entry) (user.Provisioners, error) {
+	start := time.Now()
+	fresh, err := c.proxy.Executor().GetProvisioners(c.ctx)
+	c.observe(PhaseGetProvisioners, start)
+
+	if err != nil {
+		return user.Provisioners{}, err
+	}
+
+	if err := agreement.CheckBlockCertificate(fresh, blk, c.tip().Header.Seed); err != nil {
+		return user.Provisioners{}, fmt.Errorf("%w: %s", errInvalidCertificate, err)
+	}
+
+	l.WithField("prov", stale.Set.Len()).
+		WithField("fresh_prov", fresh.Set.Len()).
+		Warn("certificate verified with refreshed provisioners")
+
+	return fresh, nil
+}
+
+// acceptTrustedBlock accepts a block stored below Database.TrustedHeight. Only
+// its linkage to the chain tip is verified.
+func (c *Chain) acceptTrustedBlock(blk block.Block) error {
+	l := log.WithField("event", "accept_trusted_block").
+		WithField("height", blk.Header.Height).
+		WithField("hash", util.StringifyBytes(blk.Header.Hash))
+
+	if err := verifiers.CheckBlockLinkage(*c.tip(), blk); err != nil {
+		l.WithError(err).Error("invalid block linkage")
 		return err
 	}
 
+	_, err := c.commitBlock(blk, *c.p, l)
+	c.countBlock(err)
+
+	return err
+}
+
+// commitBlock runs the state transition of an already verified block and
+// makes it the new chain tip. The provisioners are the ones the block
+// certificate is verified with.
+func (c *Chain) commitBlock(blk block.Block, provisioners user.Provisioners, l *logrus.Entry) (AcceptBlockResult, error) {
+	var res AcceptBlockResult
+
+	if err := c.checkCanceled("state transition"); err != nil {
+		return res, err
+	}
+
 	// 2. Perform State Transition to update Contract Storage with Tentative or Finalized state.
-	var b *block.Block
+	start := time.Now()
+	b, err := c.runStateTransition(*c.tip(), blk)
+	c.observe(PhaseStateTransition, start)
 
-	if b, err = c.runStateTransition(*c.tip, blk); err != nil {
+	if err != nil {
 		l.WithError(err).Error("execute state transition failed")
-		return err
+		return res, err
 	}
 
-	// 3. Persist the approved block and update in-memory chain tip
+	// 3. Persist the approved block and update in-memory chain tip. Rusk
+	// has committed the state transition, hence the block is persisted even
+	// if the chain is closed meanwhile.
 	l.Debug("persisting block")
 
-	if err := c.persist(b); err != nil {
+	start = time.Now()
+	err = c.persist(b)
+	c.observe(PhasePersist, start)
+
+	if err != nil {
 		l.WithError(err).Error("persisting block failed")
-		return err
+		return res, err
+	}
+
+	// The block is committed in both Rusk and the DB at this point, hence
+	// a failure to flush it does not abort the acceptance.
+	if config.Get().Chain.DurableAppend {
+		if err := c.loader.Sync(); err != nil {
+			l.WithError(err).Error("flushing block failed")
+		} else {
+			res.Flushed = true
+		}
 	}
 
-	c.tip = b
+	res.Stored = true
+
+	// 4. Keep track of the committee which finalized the block
+	c.storeCommittee(*b, c.tip().Header.Seed, provisioners)
+
+	c.setTip(b)
 	c.verified.Reset()
 
 	// 5. Perform all post-events on accepting a block
-	c.postAcceptBlock(*b, l)
+	res.Notified = c.postAcceptBlock(*b, l)
 
-	return nil
+	return res, nil
+}
+
+// checkCanceled returns an error wrapping context.Canceled if the chain is
+// closed, so that a block acceptance is aborted before the given step.
+func (c *Chain) checkCanceled(step string) error {
+	select {
+	case <-c.ctx.Done():
+		return fmt.Errorf("%w: block acceptance aborted before %s", context.Canceled, step)
+	default:
+		return nil
+	}
 }
 
 // Persist persists a block in both Contract Storage state and dusk-blockchain db in atomic manner.
@@ -650,13 +1230,18 @@ func (c *Chain) persist(b *block.Block) error {
 			"event":  "accept_block",
 			"height": b.Header.Height,
 			"hash":   util.StringifyBytes(b.Header.Hash),
-			"curr_h": c.tip.Header.Height,
+			"curr_h": c.tip().Header.Height,
 		})
 
 		err error
 		pe  = config.Get().State.PersistEvery
 	)
 
+	metadata, err := newBlockMetadata(b)
+	if err != nil {
+		return err
+	}
+
 	//  Atomic persist
 	err = c.db.Update(func(t database.Transaction) error {
 		var p bool
@@ -673,9 +1258,21 @@ func (c *Chain) persist(b *block.Block) error {
 			return err
 		}
 
-		// Persist Rusk state
+		// Store the block metadata for the indexers
+		if err = t.StoreBlockMetadata(b.Header.Hash, metadata); err != nil {
+			return err
+		}
+
+		// Extend the cumulative chain hash. The missing ones are backfilled
+		// at startup, hence a failure is not fatal.
+		if err := storeChainHash(t, b); err != nil {
+			clog.WithError(err).Warn("could not store chain hash")
+		}
+
+		// Persist Rusk state. The chain context is not used, as the block
+		// is committed in Rusk already.
 		if p {
-			if err = c.proxy.Executor().Persist(c.ctx, b.Header.StateHash); err != nil {
+			if err = c.proxy.Executor().Persist(context.Background(), b.Header.StateHash); err != nil {
 				clog.WithError(err).Error("persisting contract state failed")
 				return err
 			}
@@ -689,8 +1286,9 @@ func (c *Chain) persist(b *block.Block) error {
 	return err
 }
 
-// postAcceptBlock performs all post-events on accepting a block.
-func (c *Chain) postAcceptBlock(blk block.Block, l *logrus.Entry) {
+// postAcceptBlock performs all post-events on accepting a block. It returns
+// false if any subsystem could not be notified of the accepted block.
+func (c *Chain) postAcceptBlock(blk block.Block, l *logrus.Entry) bool {
 	// 1. Notify other subsystems for the accepted block
 	// Subsystems listening for this topic:
 	// mempool.Mempool
@@ -707,8 +1305,22 @@ func (c *Chain) postAcceptBlock(blk block.Block, l *logrus.Entry) {
 		l.WithError(err).Warn("candidate deletion failed")
 	}
 
+	// 3. Snapshot the provisioners for the API, every few blocks
+	if cfg := config.Get().API; cfg.Enabled && isSnapshotHeight(blk.Header.Height, cfg.ProvisionersSnapshotInterval) {
+		c.pushSnapshot(provisionersSnapshot{
+			height:     blk.Header.Height,
+			p:          c.p.Copy(),
+			maxMembers: cfg.MaxSnapshotProvisioners,
+		})
+	}
+
+	// 4. Index the block txs by address
+	c.txHistory.push()
+
 	diagnostics.LogPublishErrors("chain/chain.go, topics.AcceptedBlock", errList)
 	l.Debug("procedure ended")
+
+	return len(errList) == 0
 }
 
 // VerifyCandidateBlock can be used as a callback for the consensus in order to
@@ -721,7 +1333,7 @@ func (c *Chain) VerifyCandidateBlock(ctx context.Context, candidate block.Block)
 	)
 
 	c.lock.Lock()
-	chainTip = c.tip.Copy().(block.Block)
+	chainTip = c.tip().Copy().(block.Block)
 	c.lock.Unlock()
 
 	// A edge case where the next valid block is received from the network while
@@ -765,8 +1377,25 @@ func (c *Chain) VerifyCandidateBlock(ctx context.Context, candidate block.Block)
 }
 
 // ExecuteStateTransition calls Rusk ExecuteStateTransitiongrpc method.
+// The call is canceled after Timeout.TimeoutExecuteStateTransition, so that
+// a hung Rusk does not block the caller forever.
 func (c *Chain) ExecuteStateTransition(ctx context.Context, txs []transactions.ContractCall, blockHeight uint64, blockGasLimit uint64, generator []byte) ([]transactions.ContractCall, []byte, error) {
-	return c.proxy.Executor().ExecuteStateTransition(c.ctx, txs, blockGasLimit, blockHeight, generator)
+	estCtx := c.ctx
+
+	timeout := time.Duration(config.Get().Timeout.TimeoutExecuteStateTransition) * time.Millisecond
+	if timeout > 0 {
+		var cancel context.CancelFunc
+
+		estCtx, cancel = context.WithTimeout(c.ctx, timeout)
+		defer cancel()
+	}
+
+	calls, stateHash, err := c.proxy.Executor().ExecuteStateTransition(estCtx, txs, blockGasLimit, blockHeight, generator)
+	if err != nil && errors.Is(estCtx.Err(), context.DeadlineExceeded) {
+		return nil, nil, fmt.Errorf("%w after %v at height %d: %v", ErrStateTransitionTimeout, timeout, blockHeight, err)
+	}
+
+	return calls, stateHash, err
 }
 
 func (c *Chain) kadcastBlock(blk block.Block, metadata *message.Metadata) error {
@@ -781,45 +1410,172 @@ func (c *Chain) kadcastBlock(blk block.Block, metadata *message.Metadata) error
 		return err
 	}
 
-	c.eventBus.Publish(topics.Kadcast, message.NewWithMetadata(topics.Block, *buf, metadata))
+	if errList := c.eventBus.Publish(topics.Kadcast, message.NewWithMetadata(topics.Block, *buf, metadata)); len(errList) > 0 {
+		return errList[0]
+	}
+
 	return nil
 }
 
 // getRoundUpdate constructs RoundUpdate and returns a deep copy.
 func (c *Chain) getRoundUpdate() consensus.RoundUpdate {
 	r := consensus.RoundUpdate{
-		Round:           c.tip.Header.Height + 1,
+		Round:           c.tip().Header.Height + 1,
 		P:               *c.p,
-		Seed:            c.tip.Header.Seed,
-		Hash:            c.tip.Header.Hash,
-		LastCertificate: c.tip.Header.Certificate,
-		Timestamp:       c.tip.Header.Timestamp,
+		Seed:            c.tip().Header.Seed,
+		Hash:            c.tip().Header.Hash,
+		LastCertificate: c.tip().Header.Certificate,
+		Timestamp:       c.tip().Header.Timestamp,
 	}
 
 	return r.Copy().(consensus.RoundUpdate)
 }
 
-// GetSyncProgress returns how close the node is to being synced to the tip,
-// as a percentage value.
-func (c *Chain) GetSyncProgress(_ context.Context, e *node.EmptyRequest) (*node.SyncProgressResponse, error) {
-	return &node.SyncProgressResponse{Progress: float32(c.CalculateSyncProgress())}, nil
+// setTip updates the in-memory chain tip. An accepted block is final, hence
+// the finalized height follows the tip.
+func (c *Chain) setTip(b *block.Block) {
+	c.chainState.setTip(b)
 }
 
-// CalculateSyncProgress of the node.
-func (c *Chain) CalculateSyncProgress() float64 {
+// tip returns the in-memory chain tip. It must not be mutated.
+func (c *Chain) tip() *block.Block {
+	return c.chainState.snapshot().tip
+}
+
+// FinalizedHeight returns the height of the highest finalized block.
+func (c *Chain) FinalizedHeight() uint64 {
+	return c.chainState.snapshot().finalizedHeight
+}
+
+// LastCertificate returns a copy of the certificate of the chain tip, or an
+// empty certificate if the tip has none. It is safe to call concurrently with
+// the block acceptance.
+func (c *Chain) LastCertificate() *block.Certificate {
+	return c.chainState.snapshot().lastCertificate()
+}
+
+// GetCurrentCommittee returns the voting committee drawn by sortition for a
+// step of the current round. The sortition runs on a copy of the provisioner
+// set, hence it does not affect the consensus.
+func (c *Chain) GetCurrentCommittee(round uint64, step uint8) ([]CommitteeMember, error) {
 	c.lock.RLock()
 	defer c.lock.RUnlock()
 
-	if c.highestSeen == 0 {
-		return 0.0
+	if round != c.tip().Header.Height+1 {
+		return nil, ErrNotCurrentRound
+	}
+
+	maxSize := config.ConsensusMaxCommitteeSize
+	if step%3 == 1 {
+		// Selection step
+		maxSize = config.ConsensusSelectionMaxCommitteeSize
+	}
+
+	size := c.p.SubsetSizeAt(round)
+	if size > maxSize {
+		size = maxSize
+	}
+
+	committee := c.p.CreateVotingCommittee(c.tip().Header.Seed, round, step, size)
+	return committeeMembers(committee.Cluster), nil
+}
+
+// GetRecentBlocks returns up to count blocks ending at the chain tip, newest
+// first. The count is capped at Database.MaxRecentBlocks.
+func (c *Chain) GetRecentBlocks(count uint64) ([]block.Block, error) {
+	max := config.Get().Database.MaxRecentBlocks
+	if max == 0 {
+		max = config.DefaultMaxRecentBlocks
+	}
+
+	if count > max {
+		count = max
+	}
+
+	tipHeight := c.tip().Header.Height
+
+	if count > tipHeight+1 {
+		count = tipHeight + 1
+	}
+
+	blocks := make([]block.Block, 0, count)
+	if count == 0 {
+		return blocks, nil
+	}
+
+	err := c.loader.Iterate(tipHeight, tipHeight+1-count, func(blk block.Block) error {
+		blocks = append(blocks, blk)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return blocks, nil
+}
+
+// GetBlockAtHeight returns the accepted block at the given height. It returns
+// database.ErrBlockNotFound above the chain tip.
+func (c *Chain) GetBlockAtHeight(height uint64) (block.Block, error) {
+	if height > c.tip().Header.Height {
+		return block.Block{}, database.ErrBlockNotFound
+	}
+
+	return c.loader.BlockAt(height)
+}
+
+// serveBlockRequests answers topics.GetBlockAtHeight requests until the chain
+// context is canceled. The request params hold the height as a uint64 LE, and
+// the response holds the block encoded with message.MarshalBlock.
+func (c *Chain) serveBlockRequests(reqChan <-chan rpcbus.Request) {
+	for {
+		select {
+		case r := <-reqChan:
+			buf, err := c.processGetBlockRequest(r)
+
+			timeout := time.Duration(config.Get().Timeout.TimeoutSendResponse) * time.Millisecond
+			if err := r.Respond(rpcbus.NewResponse(buf, err), timeout); err != nil {
+				log.WithError(err).Warn("failed to send block response")
+			}
+		case <-c.ctx.Done():
+			return
+		}
+	}
+}
+
+func (c *Chain) processGetBlockRequest(r rpcbus.Request) (bytes.Buffer, error) {
+	params, ok := r.Params.(bytes.Buffer)
+	if !ok {
+		return bytes.Buffer{}, errors.New("invalid height")
 	}
 
-	progressPercentage := (float64(c.tip.Header.Height) / float64(c.highestSeen)) * 100
-	if progressPercentage > 100 {
-		progressPercentage = 100
+	var height uint64
+	if err := encoding.ReadUint64LE(&params, &height); err != nil {
+		return bytes.Buffer{}, err
+	}
+
+	blk, err := c.GetBlockAtHeight(height)
+	if err != nil {
+		return bytes.Buffer{}, err
 	}
 
-	return progressPercentage
+	buf := new(bytes.Buffer)
+	if err := message.MarshalBlock(buf, &blk); err != nil {
+		return bytes.Buffer{}, err
+	}
+
+	return *buf, nil
+}
+
+// GetSyncProgress returns how close the node is to being synced to the tip,
+// as a percentage value.
+func (c *Chain) GetSyncProgress(_ context.Context, e *node.EmptyRequest) (*node.SyncProgressResponse, error) {
+	return &node.SyncProgressResponse{Progress: float32(c.CalculateSyncProgress())}, nil
+}
+
+// CalculateSyncProgress of the node.
+func (c *Chain) CalculateSyncProgress() float64 {
+	return c.chainState.syncProgress()
 }
 
 // RebuildChain will delete all blocks except for the genesis block,
@@ -830,13 +1586,67 @@ func (c *Chain) RebuildChain(_ context.Context, e *node.EmptyRequest) (*node.Gen
 	return &node.GenericResponse{Response: "Unimplemented"}, nil
 }
 
-//nolint
-func (c *Chain) storeStakesInStormDB(blkHeight uint64) {
-	store := capi.GetStormDBInstance()
-	members := make([]*capi.Member, len(c.p.Members))
-	i := 0
+// isSnapshotHeight tells if the provisioners should be snapshot at the given
+// height. As long as blocks are accepted, the latest snapshot lags behind the
+// provisioner set by less than interval blocks.
+func isSnapshotHeight(height, interval uint64) bool {
+	return interval <= 1 || height%interval == 0
+}
+
+// snapshotQueueSize is the number of provisioner snapshots which can wait to
+// be stored before new ones are dropped.
+const snapshotQueueSize = 16
+
+// provisionersSnapshot is a copy of the provisioner set, waiting to be stored
+// into the API database.
+type provisionersSnapshot struct {
+	height     uint64
+	p          user.Provisioners
+	maxMembers int
+}
+
+// pushSnapshot queues a snapshot for the snapshot worker. It never blocks the
+// block acceptance: if the worker falls behind, the snapshot is dropped.
+func (c *Chain) pushSnapshot(s provisionersSnapshot) {
+	select {
+	case c.snapshots <- s:
+	default:
+		log.WithField("height", s.height).Warn("provisioners snapshot queue full, snapshot dropped")
+	}
+}
+
+// storeSnapshots stores the queued snapshots, one at a time and in the order
+// of the blocks, until the context is canceled.
+func (c *Chain) storeSnapshots(ctx context.Context) {
+	for {
+		select {
+		case s := <-c.snapshots:
+			storeStakesInStormDB(s.height, s.p, s.maxMembers)
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// storeStakesInStormDB saves the provisioner set at a given height into the
+// API database. At most maxMembers provisioners are saved, in the order of
+// the provisioner set, unless maxMembers is zero. The size of the whole set is
+// recorded, so that truncated snapshots can be told apart.
+func storeStakesInStormDB(blkHeight uint64, p user.Provisioners, maxMembers int) {
+	n := len(p.Set)
+	if maxMembers > 0 && n > maxMembers {
+		n = maxMembers
+	}
+
+	members := make([]*capi.Member, 0, n)
+
+	for i := 0; i < n; i++ {
+		v, err := p.MemberAt(i)
+		if err != nil {
+			log.WithError(err).Warn("Could not find provisioner")
+			return
+		}
 
-	for _, v := range c.p.Members {
 		var stakes []capi.Stake
 
 		for _, s := range v.Stakes {
@@ -855,16 +1665,18 @@ func (c *Chain) storeStakesInStormDB(blkHeight uint64) {
 			Stakes:       stakes,
 		}
 
-		members[i] = &member
-		i++
+		members = append(members, &member)
 	}
 
 	provisioner := capi.ProvisionerJSON{
 		ID:      blkHeight,
-		Set:     c.p.Set,
+		Set:     p.Set[:n],
 		Members: members,
+		Total:   len(p.Set),
 	}
 
+	store := capi.GetStormDBInstance()
+
 	err := store.Save(&provisioner)
 	if err != nil {
 		log.Warn("Could not store provisioners on memoryDB")
@@ -9,26 +9,38 @@ package chain
 import (
 	"bytes"
 	"context"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"sync/atomic"
 	"testing"
 	"time"
 
+	"github.com/dusk-network/dusk-blockchain/pkg/config"
 	"github.com/dusk-network/dusk-blockchain/pkg/config/genesis"
 	"github.com/dusk-network/dusk-blockchain/pkg/util/diagnostics"
 	"github.com/dusk-network/dusk-protobuf/autogen/go/node"
 
 	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus/capi"
 	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus/key"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus/user"
 	"github.com/dusk-network/dusk-blockchain/pkg/core/data/block"
 	"github.com/dusk-network/dusk-blockchain/pkg/core/data/ipc/transactions"
 	"github.com/dusk-network/dusk-blockchain/pkg/core/database"
 	"github.com/dusk-network/dusk-blockchain/pkg/core/database/heavy"
-	_ "github.com/dusk-network/dusk-blockchain/pkg/core/database/lite"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/database/lite"
 	"github.com/dusk-network/dusk-blockchain/pkg/core/loop"
 	"github.com/dusk-network/dusk-blockchain/pkg/core/tests/helper"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/verifiers"
+	"github.com/dusk-network/dusk-blockchain/pkg/p2p/wire/encoding"
 	"github.com/dusk-network/dusk-blockchain/pkg/p2p/wire/message"
 	"github.com/dusk-network/dusk-blockchain/pkg/p2p/wire/topics"
 	"github.com/dusk-network/dusk-blockchain/pkg/util/nativeutils/eventbus"
 	"github.com/dusk-network/dusk-blockchain/pkg/util/nativeutils/rpcbus"
+	"github.com/dusk-network/dusk-blockchain/pkg/util/nativeutils/sortedset"
 	assert "github.com/stretchr/testify/require"
 )
 
@@ -114,16 +126,705 @@ func TestAcceptBlock(t *testing.T) {
 	assert.NoError(c.acceptBlock(*blk, true), true)
 
 	// Should have `blk` as blockchain head now
-	assert.True(bytes.Equal(blk.Header.Hash, c.tip.Header.Hash))
+	assert.True(bytes.Equal(blk.Header.Hash, c.tip().Header.Hash))
 
 	// lastCertificate should be `cert`
-	assert.True(cert.Equals(c.tip.Header.Certificate))
+	assert.True(cert.Equals(c.tip().Header.Certificate))
 
 	// Should have gotten `blk` over topics.AcceptBlock
 	blkMsg := <-acceptedBlockChan
 	decodedBlk := blkMsg.Payload().(block.Block)
 
-	assert.True(decodedBlk.Equals(c.tip))
+	assert.True(decodedBlk.Equals(c.tip()))
+}
+
+func TestAcceptBlockNotAdvertised(t *testing.T) {
+	assert := assert.New(t)
+	startingHeight := uint64(1)
+
+	eb, c := setupChainTest(t, startingHeight)
+
+	// Block propagation fails, as no message can be forwarded to kadcast
+	eb.Subscribe(topics.Kadcast, eventbus.NewChanListener(make(chan message.Message)))
+
+	blk := helper.RandomBlock(startingHeight, 1)
+
+	res, err := c.acceptSuccessiveBlockWithResult(*blk, nil)
+	assert.NoError(err)
+	assert.Equal(AcceptBlockResult{Stored: true, Advertised: false, Notified: true}, res)
+
+	// The block is accepted anyway
+	assert.True(bytes.Equal(blk.Header.Hash, c.tip().Header.Hash))
+}
+
+// syncRecorder records, on each flush of the wrapped loader, the number of
+// blocks advertised so far.
+type syncRecorder struct {
+	Loader
+	kadcast    chan message.Message
+	advertised *[]int
+}
+
+func (l syncRecorder) Sync() error {
+	*l.advertised = append(*l.advertised, len(l.kadcast))
+	return l.Loader.Sync()
+}
+
+func TestDurableAppend(t *testing.T) {
+	assert := assert.New(t)
+	startingHeight := uint64(1)
+
+	eb, c := setupChainTest(t, startingHeight)
+
+	orig := config.Get()
+	defer config.Mock(&orig)
+
+	r := config.Get()
+	r.Chain.DurableAppend = true
+	config.Mock(&r)
+
+	var advertised []int
+
+	kadcast := make(chan message.Message, 1)
+	eb.Subscribe(topics.Kadcast, eventbus.NewChanListener(kadcast))
+
+	c.loader = syncRecorder{Loader: c.loader, kadcast: kadcast, advertised: &advertised}
+
+	blk := helper.RandomBlock(startingHeight, 1)
+
+	res, err := c.acceptSuccessiveBlockWithResult(*blk, nil)
+	assert.NoError(err)
+	assert.True(res.Advertised)
+
+	// The block is flushed to disk before being advertised
+	assert.Equal([]int{0}, advertised)
+	assert.Len(kadcast, 1)
+
+	// A block which could not be flushed is still accepted, but is not
+	// advertised
+	eb, c = setupChainTest(t, startingHeight)
+	c.loader = failingSync{c.loader}
+
+	kadcast = make(chan message.Message, 1)
+	eb.Subscribe(topics.Kadcast, eventbus.NewChanListener(kadcast))
+
+	res, err = c.acceptSuccessiveBlockWithResult(*blk, nil)
+	assert.NoError(err)
+	assert.True(res.Stored)
+	assert.False(res.Flushed)
+	assert.False(res.Advertised)
+	assert.Equal(blk.Header.Hash, c.tip().Header.Hash)
+	assert.Empty(kadcast)
+}
+
+// failingSync fails to flush the blocks to disk.
+type failingSync struct {
+	Loader
+}
+
+func (l failingSync) Sync() error {
+	return errors.New("sync failed")
+}
+
+func TestBlockMetadata(t *testing.T) {
+	assert := assert.New(t)
+	startingHeight := uint64(1)
+
+	_, c := setupChainTest(t, startingHeight)
+
+	blk := helper.RandomBlock(startingHeight, 2)
+	for _, tx := range blk.Txs {
+		tx.(*transactions.Transaction).GasSpentValue = 1000
+	}
+
+	blk.Header.GasLimit = 2000
+
+	assert.NoError(c.acceptSuccessiveBlock(*blk, nil))
+
+	var m database.BlockMetadata
+
+	assert.NoError(c.db.View(func(t database.Transaction) error {
+		var err error
+		m, err = t.FetchBlockMetadata(blk.Header.Hash)
+		return err
+	}))
+
+	var fees uint64
+
+	for _, tx := range blk.Txs {
+		decoded, err := tx.Decode()
+		assert.NoError(err)
+
+		fees += tx.GasSpent() * decoded.Fee.GasPrice
+	}
+
+	buf := new(bytes.Buffer)
+	assert.NoError(message.MarshalBlock(buf, blk))
+
+	assert.NotZero(fees)
+	assert.Equal(uint32(len(blk.Txs)), m.TxCount)
+	assert.Equal(fees, m.TotalFees)
+	assert.Equal(block.Emission(startingHeight)+fees, m.CoinbaseAmount)
+	assert.Equal(uint64(buf.Len()), m.Size)
+}
+
+// stubExecutor overrides some of the calls of a PermissiveExecutor. The calls
+// whose hook is not set are served by the PermissiveExecutor.
+type stubExecutor struct {
+	*transactions.PermissiveExecutor
+
+	// onStateTransition runs on Accept and Finalize, before Rusk would
+	// commit the state transition.
+	onStateTransition func()
+	// executed returns the txs executed by Accept and Finalize.
+	executed        func(cc []transactions.ContractCall) []transactions.ContractCall
+	execute         func(ctx context.Context) ([]transactions.ContractCall, []byte, error)
+	getProvisioners func(ctx context.Context) (user.Provisioners, error)
+	revert          func(ctx context.Context) ([]byte, error)
+}
+
+func (e stubExecutor) ExecuteStateTransition(ctx context.Context, cc []transactions.ContractCall, blockGasLimit uint64, blockHeight uint64, generator []byte) ([]transactions.ContractCall, []byte, error) {
+	if e.execute != nil {
+		return e.execute(ctx)
+	}
+
+	return e.PermissiveExecutor.ExecuteStateTransition(ctx, cc, blockGasLimit, blockHeight, generator)
+}
+
+func (e stubExecutor) Accept(ctx context.Context, cc []transactions.ContractCall, stateRoot []byte, height, gasLimit uint64, generator []byte, p *user.Provisioners) ([]transactions.ContractCall, user.Provisioners, []byte, error) {
+	if e.onStateTransition != nil {
+		e.onStateTransition()
+	}
+
+	txs, provisioners, stateHash, err := e.PermissiveExecutor.Accept(ctx, cc, stateRoot, height, gasLimit, generator, p)
+	if e.executed != nil {
+		txs = e.executed(cc)
+	}
+
+	return txs, provisioners, stateHash, err
+}
+
+func (e stubExecutor) Finalize(ctx context.Context, cc []transactions.ContractCall, stateRoot []byte, height, gasLimit uint64, generator []byte, p *user.Provisioners) ([]transactions.ContractCall, user.Provisioners, []byte, error) {
+	if e.onStateTransition != nil {
+		e.onStateTransition()
+	}
+
+	txs, provisioners, stateHash, err := e.PermissiveExecutor.Finalize(ctx, cc, stateRoot, height, gasLimit, generator, p)
+	if e.executed != nil {
+		txs = e.executed(cc)
+	}
+
+	return txs, provisioners, stateHash, err
+}
+
+func (e stubExecutor) GetProvisioners(ctx context.Context) (user.Provisioners, error) {
+	if e.getProvisioners != nil {
+		return e.getProvisioners(ctx)
+	}
+
+	return e.PermissiveExecutor.GetProvisioners(ctx)
+}
+
+func (e stubExecutor) Revert(ctx context.Context) ([]byte, error) {
+	if e.revert != nil {
+		return e.revert(ctx)
+	}
+
+	return e.PermissiveExecutor.Revert(ctx)
+}
+
+func TestAcceptBlockGasExceeded(t *testing.T) {
+	assert := assert.New(t)
+	startingHeight := uint64(1)
+
+	_, c := setupChainTest(t, startingHeight)
+
+	// Rusk reports the gas spent by each tx
+	spending := func(c *Chain) {
+		proxy := c.proxy.(*transactions.MockProxy)
+		proxy.E = stubExecutor{
+			PermissiveExecutor: proxy.E.(*transactions.PermissiveExecutor),
+			executed: func(cc []transactions.ContractCall) []transactions.ContractCall {
+				executed := make([]transactions.ContractCall, len(cc))
+				for i, tx := range cc {
+					tx := tx.(*transactions.Transaction).Copy().(*transactions.Transaction)
+					tx.GasSpentValue = 1000
+					executed[i] = tx
+				}
+
+				return executed
+			},
+		}
+	}
+
+	spending(c)
+
+	blk := helper.RandomBlock(startingHeight, 2)
+	blk.Header.GasLimit = 1999
+	blk.Header.Hash, _ = blk.CalculateHash()
+
+	// The block is received from the network, without the gas spent
+	buf := new(bytes.Buffer)
+	assert.NoError(message.MarshalBlock(buf, blk))
+
+	received := block.NewBlock()
+	assert.NoError(message.UnmarshalBlock(buf, received))
+
+	for _, tx := range received.Txs {
+		assert.Zero(tx.GasSpent())
+	}
+
+	tip := c.tip()
+
+	err := c.acceptSuccessiveBlock(*received, nil)
+	assert.ErrorIs(err, verifiers.ErrBlockGasExceeded)
+	assert.True(verifiers.IsBlockError(err, blk.Header.Hash))
+	assert.Equal(tip.Header.Hash, c.tip().Header.Hash)
+
+	// Within the limit, the block is accepted
+	_, c = setupChainTest(t, startingHeight)
+	spending(c)
+
+	received.Header.GasLimit = 2000
+	received.Header.Hash, _ = received.CalculateHash()
+
+	assert.NoError(c.acceptSuccessiveBlock(*received, nil))
+	assert.Equal(received.Header.Hash, c.tip().Header.Hash)
+}
+
+func TestAcceptBlockTooManyStakes(t *testing.T) {
+	assert := assert.New(t)
+	startingHeight := uint64(1)
+
+	_, c := setupChainTest(t, startingHeight)
+
+	// The state transition pushes a provisioner over the stakes limit
+	p := c.proxy.(*transactions.MockProxy).E.(*transactions.PermissiveExecutor).P
+	for _, m := range p.Members {
+		for len(m.Stakes) <= config.MaxStakesPerProvisioner {
+			m.AddStake(user.Stake{Value: 1, Reward: 1, Counter: 1})
+		}
+	}
+
+	tip := c.tip()
+	blk := helper.RandomBlock(startingHeight, 1)
+
+	err := c.acceptSuccessiveBlock(*blk, nil)
+	assert.ErrorIs(err, verifiers.ErrTooManyStakes)
+	assert.True(verifiers.IsBlockError(err, blk.Header.Hash))
+	assert.Equal(tip.Header.Hash, c.tip().Header.Hash)
+}
+
+func TestLastCertificateConcurrentAccept(t *testing.T) {
+	assert := assert.New(t)
+	_, c := setupChainTest(t, 0)
+
+	done := make(chan struct{})
+	readerDone := make(chan struct{})
+
+	go func() {
+		defer close(readerDone)
+
+		for {
+			select {
+			case <-done:
+				return
+			default:
+				assert.NotNil(c.LastCertificate())
+			}
+		}
+	}()
+
+	p, keys := consensus.MockProvisioners(10)
+	c.proxy.(*transactions.MockProxy).E.(*transactions.PermissiveExecutor).P = p
+	c.p = p
+
+	// Blocks are accepted under the chain lock, as on the network and the
+	// consensus paths
+	var cert *block.Certificate
+
+	for height := uint64(1); height <= 5; height++ {
+		blk := helper.RandomBlock(height, 1)
+		blk.Header.PrevBlockHash = c.tip().Header.Hash
+		blk.Header.Seed = []byte{0, 0, 0, 0}
+		blk.Header.StateHash = make([]byte, 32)
+		blk.Header.Hash, _ = blk.CalculateHash()
+		blk.Header.Certificate = message.MockAgreement(blk.Header.Hash, height, 3, keys, p).GenerateCertificate()
+		cert = blk.Header.Certificate
+
+		c.lock.Lock()
+		err := c.acceptBlock(*blk, true)
+		c.lock.Unlock()
+
+		assert.NoError(err)
+	}
+
+	close(done)
+	<-readerDone
+
+	assert.True(cert.Equals(c.LastCertificate()))
+
+	// The tip certificate is not affected by changes to the returned copy
+	c.LastCertificate().Step = 0
+	assert.True(cert.Equals(c.LastCertificate()))
+}
+
+func TestReplayVerify(t *testing.T) {
+	assert := assert.New(t)
+	_, c := setupChainTest(t, 0)
+
+	p, keys := consensus.MockProvisioners(10)
+	c.proxy.(*transactions.MockProxy).E.(*transactions.PermissiveExecutor).P = p
+	c.p = p
+
+	for height := uint64(1); height <= 5; height++ {
+		blk := helper.RandomBlock(height, 1)
+		blk.Header.PrevBlockHash = c.tip().Header.Hash
+		blk.Header.Seed = []byte{0, 0, 0, 0}
+		blk.Header.StateHash = make([]byte, 32)
+		blk.Header.Hash, _ = blk.CalculateHash()
+		blk.Header.Certificate = message.MockAgreement(blk.Header.Hash, height, 3, keys, p).GenerateCertificate()
+
+		assert.NoError(c.acceptBlock(*blk, true))
+	}
+
+	tip := c.tip()
+
+	assert.NoError(c.ReplayVerify(context.Background(), 1, 5))
+
+	// Inject a block whose certificate does not match its hash at height 4
+	blk3, err := c.loader.BlockAt(3)
+	assert.NoError(err)
+
+	blk4, err := c.loader.BlockAt(4)
+	assert.NoError(err)
+
+	blk4.Header.Certificate = blk3.Header.Certificate
+	assert.NoError(c.db.Update(func(t database.Transaction) error {
+		return t.StoreBlock(&blk4, false)
+	}))
+
+	var replayErr *ReplayError
+
+	err = c.ReplayVerify(context.Background(), 1, 5)
+	assert.True(errors.As(err, &replayErr))
+	assert.Equal(uint64(4), replayErr.Height)
+	assert.ErrorIs(err, errInvalidCertificate)
+
+	// The range below the bad block still verifies
+	assert.NoError(c.ReplayVerify(context.Background(), 1, 3))
+
+	// The chain tip is left untouched
+	assert.Equal(tip, c.tip())
+}
+
+func TestMaxBlockAge(t *testing.T) {
+	assert := assert.New(t)
+	_, c := setupChainTest(t, 0)
+
+	orig := config.Get()
+	defer config.Mock(&orig)
+
+	r := config.Get()
+	r.Chain.MaxBlockAge = 10
+	config.Mock(&r)
+
+	c.setTip(helper.RandomBlock(1000, 1))
+
+	// An old block is dropped without any further check
+	blk := helper.RandomBlock(989, 1)
+	assert.Equal(errStaleBlock, c.preFilterBlock(blk))
+
+	var calls int32
+
+	proxy := c.proxy.(*transactions.MockProxy)
+	executor := proxy.E.(*transactions.PermissiveExecutor)
+	proxy.E = stubExecutor{
+		PermissiveExecutor: executor,
+		getProvisioners: func(ctx context.Context) (user.Provisioners, error) {
+			atomic.AddInt32(&calls, 1)
+			return executor.GetProvisioners(ctx)
+		},
+	}
+
+	res, err := c.ProcessBlockFromNetwork("peer", message.New(topics.Block, *blk))
+	assert.NoError(err)
+	assert.Nil(res)
+	assert.Equal(int32(0), atomic.LoadInt32(&calls))
+
+	// A recent one still passes the filter
+	assert.NoError(c.preFilterBlock(helper.RandomBlock(990, 1)))
+}
+
+func TestFinalizedHeight(t *testing.T) {
+	assert := assert.New(t)
+	startingHeight := uint64(1)
+
+	_, c := setupChainTest(t, startingHeight)
+	assert.Equal(startingHeight-1, c.FinalizedHeight())
+
+	blk := helper.RandomBlock(startingHeight, 1)
+	assert.NoError(c.acceptBlock(*blk, true))
+
+	assert.Equal(startingHeight, c.FinalizedHeight())
+}
+
+func TestGetCurrentCommittee(t *testing.T) {
+	assert := assert.New(t)
+
+	_, c := setupChainTest(t, 0)
+	round := c.tip().Header.Height + 1
+
+	// Add more provisioners, to draw a committee of several members
+	for i := 0; i < 5; i++ {
+		assert.NoError(c.p.Add(key.NewRandKeys().BLSPubKey, 1000*user.DUSK, 0, 0, 0))
+	}
+
+	for _, step := range []uint8{1, 2, 3} {
+		size := config.ConsensusMaxCommitteeSize
+		if step == 1 {
+			size = config.ConsensusSelectionMaxCommitteeSize
+		}
+
+		if n := c.p.SubsetSizeAt(round); n < size {
+			size = n
+		}
+
+		expected := c.p.CreateVotingCommittee(c.tip().Header.Seed, round, step, size)
+
+		members, err := c.GetCurrentCommittee(round, step)
+		assert.NoError(err)
+		assert.Equal(len(expected.Set), len(members))
+
+		var votes int
+
+		for _, m := range members {
+			assert.Equal(expected.OccurrencesOf(m.PubKeyBLS), m.Votes)
+			votes += m.Votes
+		}
+
+		assert.Equal(expected.Size(), votes)
+		assertCanonicalOrder(assert, members)
+	}
+
+	_, err := c.GetCurrentCommittee(round+1, 1)
+	assert.Equal(ErrNotCurrentRound, err)
+}
+
+func TestStartConsensusWaitsForPeers(t *testing.T) {
+	assert := assert.New(t)
+	_, c := setupChainTest(t, 0)
+
+	orig := config.Get()
+	defer config.Mock(&orig)
+
+	cfg := config.Get()
+	cfg.Consensus.MinPeersToStart = 3
+	cfg.Consensus.MinPeersTimeout = 60
+	config.Mock(&cfg)
+
+	defer func(d time.Duration) { peerCountPollInterval = d }(peerCountPollInterval)
+	peerCountPollInterval = 10 * time.Millisecond
+
+	peers := int32(1)
+	reqChan := make(chan rpcbus.Request, 1)
+	assert.NoError(c.rpcBus.Register(topics.GetPeerCount, reqChan))
+
+	go func() {
+		for r := range reqChan {
+			r.RespChan <- rpcbus.NewResponse(int(atomic.LoadInt32(&peers)), nil)
+		}
+	}()
+
+	loopID := atomic.LoadUint64(&c.loopID)
+
+	started := make(chan error, 1)
+	go func() {
+		started <- c.StartConsensus()
+	}()
+
+	// Consensus start is deferred while peers are missing
+	select {
+	case <-started:
+		t.Fatal("consensus started without enough peers")
+	case <-time.After(200 * time.Millisecond):
+	}
+
+	assert.Equal(loopID, atomic.LoadUint64(&c.loopID))
+
+	atomic.StoreInt32(&peers, 3)
+
+	select {
+	case err := <-started:
+		assert.NoError(err)
+	case <-time.After(2 * time.Second):
+		t.Fatal("consensus did not start")
+	}
+
+	assert.Equal(loopID+1, atomic.LoadUint64(&c.loopID))
+}
+
+func TestStartConsensusWaitsForMempool(t *testing.T) {
+	assert := assert.New(t)
+	_, c := setupChainTest(t, 0)
+
+	orig := config.Get()
+	defer config.Mock(&orig)
+
+	cfg := config.Get()
+	cfg.Consensus.MinPeersToStart = 0
+	cfg.Consensus.MempoolReadyTimeout = 60
+	config.Mock(&cfg)
+
+	defer func(d time.Duration) { peerCountPollInterval = d }(peerCountPollInterval)
+	peerCountPollInterval = 10 * time.Millisecond
+
+	ready := int32(0)
+	reqChan := make(chan rpcbus.Request, 1)
+	assert.NoError(c.rpcBus.Register(topics.GetMempoolReady, reqChan))
+
+	go func() {
+		for r := range reqChan {
+			r.RespChan <- rpcbus.NewResponse(atomic.LoadInt32(&ready) == 1, nil)
+		}
+	}()
+
+	loopID := atomic.LoadUint64(&c.loopID)
+
+	started := make(chan error, 1)
+	go func() {
+		started <- c.StartConsensus()
+	}()
+
+	// The first round is deferred while the mempool is not ready
+	select {
+	case <-started:
+		t.Fatal("consensus started before the mempool is ready")
+	case <-time.After(200 * time.Millisecond):
+	}
+
+	assert.Equal(loopID, atomic.LoadUint64(&c.loopID))
+
+	atomic.StoreInt32(&ready, 1)
+
+	select {
+	case err := <-started:
+		assert.NoError(err)
+	case <-time.After(2 * time.Second):
+		t.Fatal("consensus did not start")
+	}
+
+	assert.Equal(loopID+1, atomic.LoadUint64(&c.loopID))
+}
+
+func TestObserverMode(t *testing.T) {
+	assert := assert.New(t)
+	startingHeight := uint64(1)
+
+	orig := config.Get()
+	defer config.Mock(&orig)
+
+	r := config.Get()
+	r.Node.ObserverMode = true
+	config.Mock(&r)
+
+	eb, c := setupChainTest(t, startingHeight)
+
+	kadcast := make(chan message.Message, 1)
+	eb.Subscribe(topics.Kadcast, eventbus.NewChanListener(kadcast))
+
+	// The consensus loop is never started
+	assert.NoError(c.StartConsensus())
+	assert.NoError(c.RestartConsensus())
+	assert.Equal(uint64(0), atomic.LoadUint64(&c.loopID))
+
+	// Blocks are still accepted and advertised
+	blk := helper.RandomBlock(startingHeight, 1)
+
+	res, err := c.acceptSuccessiveBlockWithResult(*blk, nil)
+	assert.NoError(err)
+	assert.True(res.Advertised)
+	assert.True(bytes.Equal(blk.Header.Hash, c.tip().Header.Hash))
+
+	select {
+	case <-kadcast:
+	case <-time.After(time.Second):
+		t.Fatal("block not advertised")
+	}
+
+	assert.Equal(uint64(0), atomic.LoadUint64(&c.loopID))
+}
+
+func TestExecuteStateTransitionTimeout(t *testing.T) {
+	assert := assert.New(t)
+	_, c := setupChainTest(t, 0)
+
+	orig := config.Get()
+	defer config.Mock(&orig)
+
+	r := config.Get()
+	r.Timeout.TimeoutExecuteStateTransition = 100
+	config.Mock(&r)
+
+	// The state transition hangs until the call is canceled
+	proxy := c.proxy.(*transactions.MockProxy)
+	proxy.E = stubExecutor{
+		PermissiveExecutor: proxy.E.(*transactions.PermissiveExecutor),
+		execute: func(ctx context.Context) ([]transactions.ContractCall, []byte, error) {
+			<-ctx.Done()
+			return nil, nil, ctx.Err()
+		},
+	}
+
+	done := make(chan error, 1)
+
+	go func() {
+		_, _, err := c.ExecuteStateTransition(context.Background(), nil, 1, 0, nil)
+		done <- err
+	}()
+
+	select {
+	case err := <-done:
+		assert.True(errors.Is(err, ErrStateTransitionTimeout))
+	case <-time.After(2 * time.Second):
+		t.Fatal("state transition did not time out")
+	}
+}
+
+func TestLoadProvisionersAtStartup(t *testing.T) {
+	assert := assert.New(t)
+
+	// The first round update carries the provisioners known by Rusk
+	_, c := setupChainTest(t, 0)
+	p := c.proxy.(*transactions.MockProxy).E.(*transactions.PermissiveExecutor).P
+
+	assert.Equal(1, c.p.Set.Len())
+	assert.Equal(p.Members, c.getRoundUpdate().P.Members)
+
+	// Without Rusk, the chain starts with no provisioners
+	orig := config.Get()
+	defer config.Mock(&orig)
+
+	r := config.Get()
+	r.Timeout.TimeoutGetProvisioners = 50
+	config.Mock(&r)
+
+	_, db := heavy.CreateDBConnection()
+	proxy := &transactions.MockProxy{
+		E: stubExecutor{
+			PermissiveExecutor: transactions.MockExecutor(0),
+			getProvisioners: func(ctx context.Context) (user.Provisioners, error) {
+				<-ctx.Done()
+				return user.Provisioners{}, ctx.Err()
+			},
+		},
+	}
+
+	c, err := New(context.Background(), db, eventbus.New(), rpcbus.New(), createLoader(db), &MockVerifier{}, nil, proxy, nil)
+	assert.NoError(err)
+	defer c.Close()
+
+	assert.Equal(0, c.p.Set.Len())
 }
 
 func createLoader(db database.DB) *DBLoader {
@@ -131,105 +832,997 @@ func createLoader(db database.DB) *DBLoader {
 	return NewDBLoader(db, genesis.Decode())
 }
 
-func TestFetchTip(t *testing.T) {
+func TestGetCommitteeAtRound(t *testing.T) {
+	assert := assert.New(t)
+	_, c := setupChainTest(t, 0)
+
+	orig := config.Get()
+	defer config.Mock(&orig)
+
+	r := config.Registry{}
+	r.Database.CommitteeRetention = 3
+	config.Mock(&r)
+
+	p, keys := consensus.MockProvisioners(10)
+	seed := []byte{0, 0, 0, 0}
+
+	// Finalize blocks at rounds 1 to 5
+	blocks := make([]*block.Block, 6)
+
+	for round := uint64(1); round <= 5; round++ {
+		blk := helper.RandomBlock(round, 1)
+		ev := message.MockAgreement(blk.Header.Hash, round, 3, keys, p)
+		blk.Header.Certificate = ev.GenerateCertificate()
+
+		c.storeCommittee(*blk, seed, *p)
+		blocks[round] = blk
+	}
+
+	// Committees out of the retention window are dropped
+	for _, round := range []uint64{1, 2} {
+		_, err := c.GetCommitteeAtRound(round)
+		assert.Equal(database.ErrCommitteeNotFound, err)
+	}
+
+	// An earlier committee is the one which signed the certificate
+	blk := blocks[3]
+	cert := blk.Header.Certificate
+
+	committee, err := c.GetCommitteeAtRound(3)
+	assert.NoError(err)
+	assert.Equal(uint64(3), committee.Round)
+	assert.Equal(cert.Step, committee.Step)
+	assert.Equal(stepVoters(*p, seed, 3, cert.Step-1, cert.StepOneCommittee), committee.StepOne)
+	assert.Equal(stepVoters(*p, seed, 3, cert.Step, cert.StepTwoCommittee), committee.StepTwo)
+	assert.NotEmpty(committee.StepOne)
+	assert.NotEmpty(committee.StepTwo)
+	assertCanonicalOrder(assert, committee.StepOne)
+	assertCanonicalOrder(assert, committee.StepTwo)
+
+	// The same committee is served over the RPCBus
+	params := new(bytes.Buffer)
+	assert.NoError(encoding.WriteUint64LE(params, 3))
+
+	resp, err := c.rpcBus.Call(topics.GetCommitteeAtRound, rpcbus.NewRequest(*params), time.Second)
+	assert.NoError(err)
+	assert.Equal(committee, resp.(FinalizingCommittee))
+}
+
+func TestCompactCommittees(t *testing.T) {
+	assert := assert.New(t)
+	_, c := setupChainTest(t, 0)
+
+	orig := config.Get()
+	defer config.Mock(&orig)
+
+	r := config.Registry{}
+	r.Database.CommitteeRetention = 10
+	config.Mock(&r)
+
+	p, keys := consensus.MockProvisioners(10)
+	seed := []byte{1, 2, 3, 4}
+
+	blk := helper.RandomBlock(1, 1)
+	blk.Header.Certificate = message.MockAgreement(blk.Header.Hash, 1, 3, keys, p).GenerateCertificate()
+
+	// Store a committee with its members, then a compact one
+	c.storeCommittee(*blk, seed, *p)
+
+	r.Database.CompactCommittees = true
+	config.Mock(&r)
+
+	compactBlk := helper.RandomBlock(2, 1)
+	compactBlk.Header.Certificate = message.MockAgreement(compactBlk.Header.Hash, 2, 3, keys, p).GenerateCertificate()
+	c.storeCommittee(*compactBlk, seed, *p)
+
+	var full, compact []byte
+
+	assert.NoError(c.db.View(func(t database.Transaction) error {
+		var err error
+		if full, err = t.FetchCommittee(1); err != nil {
+			return err
+		}
+
+		compact, err = t.FetchCommittee(2)
+		return err
+	}))
+	assert.Less(len(compact), len(full))
+
+	// The derived committee is the one stored with its members
+	committee, err := c.GetCommitteeAtRound(2)
+	assert.NoError(err)
+	assert.Equal(newFinalizingCommittee(*compactBlk, seed, *p), committee)
+	assert.NotEmpty(committee.StepOne)
+	assert.NotEmpty(committee.StepTwo)
+
+	// Committees stored with their members are still served
+	committee, err = c.GetCommitteeAtRound(1)
+	assert.NoError(err)
+	assert.Equal(newFinalizingCommittee(*blk, seed, *p), committee)
+}
+
+// assertCanonicalOrder checks that committee members are sorted by BLS key.
+func assertCanonicalOrder(assert *assert.Assertions, members []CommitteeMember) {
+	assert.True(sort.SliceIsSorted(members, func(i, j int) bool {
+		return bytes.Compare(members[i].PubKeyBLS, members[j].PubKeyBLS) < 0
+	}))
+}
+
+func TestCommitteeMembersOrder(t *testing.T) {
+	assert := assert.New(t)
+
+	// Keys of different lengths, which the set orders by int value instead of
+	// by bytes
+	keys := [][]byte{{0xff, 0x01}, {0x02}, {0x01, 0x00, 0x00}}
+
+	c := sortedset.NewCluster()
+	for _, k := range keys {
+		c.Insert(k)
+	}
+
+	c.Insert(keys[1])
+
+	members := committeeMembers(c)
+	assert.Len(members, 3)
+	assertCanonicalOrder(assert, members)
+
+	for _, m := range members {
+		assert.Equal(c.OccurrencesOf(m.PubKeyBLS), m.Votes)
+	}
+
+	// The same committee is always presented the same way
+	assert.Equal(members, committeeMembers(c))
+}
+
+func TestFetchTip(t *testing.T) {
+	assert := assert.New(t)
+	_, chain := setupChainTest(t, 0)
+
+	// on a modern chain, state(tip) must point at genesis
+	var s *database.Registry
+
+	err := chain.db.View(func(t database.Transaction) error {
+		var err error
+		s, err = t.FetchRegistry()
+		return err
+	})
+	assert.NoError(err)
+
+	assert.Equal(chain.tip().Header.Hash, s.TipHash)
+}
+
+func TestSyncProgress(t *testing.T) {
+	assert := assert.New(t)
+	_, c := setupChainTest(t, 0)
+
+	// SyncProgress should be 0% right now
+	resp, err := c.GetSyncProgress(context.Background(), &node.EmptyRequest{})
+	assert.NoError(err)
+
+	assert.Equal(resp.Progress, float32(0.0))
+
+	// Change tipHeight and then give the chain a block from far in the future
+	tip := c.tip().Copy().(block.Block)
+	tip.Header.Height = 50
+	c.setTip(&tip)
+
+	blk := helper.RandomBlock(100, 1)
+	c.ProcessBlockFromNetwork("", message.New(topics.Block, *blk))
+
+	// SyncProgress should be 50%
+	resp, err = c.GetSyncProgress(context.Background(), &node.EmptyRequest{})
+	assert.NoError(err)
+
+	assert.Equal(resp.Progress, float32(50.0))
+}
+
+func TestPreFilterBlock(t *testing.T) {
+	assert := assert.New(t)
+	_, c := setupChainTest(t, 0)
+
+	// Hold the chain lock for the whole test, so that any block reaching the
+	// accept path would block
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	tip := helper.RandomBlock(1000, 1)
+	c.setTip(tip)
+
+	done := make(chan struct{})
+
+	go func() {
+		defer close(done)
+
+		for i := 0; i < 100; i++ {
+			// Genesis height
+			blk := helper.RandomBlock(0, 1)
+			_, err := c.ProcessBlockFromNetwork("peer", message.New(topics.Block, *blk))
+			assert.Equal(errGenesisFromNetwork, err)
+
+			// Stale height
+			blk = helper.RandomBlock(uint64(i+1), 1)
+			res, err := c.ProcessBlockFromNetwork("peer", message.New(topics.Block, *blk))
+			assert.NoError(err)
+			assert.Nil(res)
+
+			// Invalid header hash
+			blk = helper.RandomBlock(1001, 1)
+			blk.Header.Hash = make([]byte, 32)
+			_, err = c.ProcessBlockFromNetwork("peer", message.New(topics.Block, *blk))
+			assert.Equal(verifiers.ErrInvalidBlockHash, err)
+		}
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("invalid blocks reached the accept path")
+	}
+
+	assert.Equal(tip, c.tip())
+}
+
+func TestFallbackProcedure(t *testing.T) {
+	t.Skip()
+
+	// Set up a chain instance with mocking verifiers
+	_, chain := setupChainTest(t, 1)
+
+	// Accept block at height 1 with Certificate.Step = 6 (second iteration)
+	blk1 := helper.RandomBlock(1, 3)
+
+	cert1 := block.EmptyCertificate()
+	cert1.Step = 6
+	blk1.Header.Certificate = cert1
+
+	chain.ProcessBlockFromNetwork("", message.New(topics.Block, *blk1))
+
+	b, err := chain.loader.BlockAt(1)
+	assert.NoError(t, err)
+
+	// assert block was accepted successfully
+	assert.True(t, bytes.Equal(b.Header.Hash, blk1.Header.Hash))
+
+	// Enforce fallback procedure at block height 1
+	blk2 := helper.RandomBlock(1, 3)
+
+	cert2 := block.EmptyCertificate()
+	cert2.Step = 3 // (first iteration certificate)
+	blk2.Header.Certificate = cert2
+
+	chain.ProcessBlockFromNetwork("", message.New(topics.Block, *blk2))
+
+	b, err = chain.loader.BlockAt(1)
+	assert.NoError(t, err)
+
+	// assert block at height 1 has been rewritten from fallback procedure
+	assert.True(t, bytes.Equal(b.Header.Hash, blk2.Header.Hash))
+
+	// Ensure fallback will be canceled if blk is from higher iteration
+	chain.ProcessBlockFromNetwork("", message.New(topics.Block, *blk1))
+
+	b, err = chain.loader.BlockAt(1)
+	assert.NoError(t, err)
+
+	assert.True(t, bytes.Equal(b.Header.Hash, blk2.Header.Hash))
+}
+
+// failingVerifier rejects all blocks, counting the verifications.
+type failingVerifier struct {
+	MockVerifier
+	calls int
+
+	// err is the verification error, if set
+	err error
+
+	// transient makes the verification fail on a node error, rather than on
+	// the block
+	transient bool
+}
+
+func (v *failingVerifier) SanityCheckBlock(prevBlock block.Block, blk block.Block) error {
+	v.calls++
+
+	if v.transient {
+		return errors.New("db unavailable")
+	}
+
+	if v.err != nil {
+		return verifiers.NewBlockError(blk, v.err)
+	}
+
+	return verifiers.NewBlockError(blk, errors.New("invalid block"))
+}
+
+func TestDropRejectedBlock(t *testing.T) {
+	assert := assert.New(t)
+	_, c := setupChainTest(t, 0)
+
+	orig := config.Get()
+	defer config.Mock(&orig)
+
+	r := config.Get()
+	r.Network.Misbehavior.InvalidBlock = 1
+	config.Mock(&r)
+
+	v := &failingVerifier{}
+	c.verifier = v
+
+	blk := helper.RandomBlock(1, 1)
+
+	_, err := c.ProcessBlockFromNetwork("peer", message.New(topics.Block, *blk))
+	assert.Error(err)
+	assert.Equal(1, v.calls)
+
+	// Re-delivery from the same peer is dropped without verification
+	_, err = c.ProcessBlockFromNetwork("peer", message.New(topics.Block, *blk))
+	assert.NoError(err)
+	assert.Equal(1, v.calls)
+	assert.Equal(uint32(2), c.rejected.score("peer"))
+
+	// Another peer delivering the same block is not affected
+	_, err = c.ProcessBlockFromNetwork("other_peer", message.New(topics.Block, *blk))
+	assert.Error(err)
+	assert.Equal(2, v.calls)
+
+	// A block failing on a node error is neither cached nor accounted to
+	// the peer
+	v.transient = true
+	blk = helper.RandomBlock(1, 1)
+
+	for i := 0; i < 2; i++ {
+		_, err = c.ProcessBlockFromNetwork("third_peer", message.New(topics.Block, *blk))
+		assert.Error(err)
+	}
+
+	assert.Equal(4, v.calls)
+	assert.Zero(c.rejected.score("third_peer"))
+}
+
+func TestRejectedBlockNotCached(t *testing.T) {
+	assert := assert.New(t)
+	_, c := setupChainTest(t, 0)
+
+	v := &failingVerifier{err: errInvalidStateHash}
+	c.verifier = v
+
+	blk := helper.RandomBlock(1, 1)
+
+	// A block failing against the node view of the contract state is
+	// verified again on re-delivery
+	for i := 0; i < 2; i++ {
+		_, err := c.ProcessBlockFromNetwork("peer", message.New(topics.Block, *blk))
+		assert.ErrorIs(err, errInvalidStateHash)
+	}
+
+	assert.Equal(2, v.calls)
+	assert.False(c.rejected.has("peer", blk.Header.Hash))
+}
+
+func TestBanMisbehavingPeer(t *testing.T) {
+	assert := assert.New(t)
+	eb, c := setupChainTest(t, 0)
+
+	orig := config.Get()
+	defer config.Mock(&orig)
+
+	r := config.Get()
+	r.Network.Misbehavior.BanScore = 10
+	r.Network.Misbehavior.InvalidBlock = 0
+	r.Network.Misbehavior.FutureTimestamp = 3
+	config.Mock(&r)
+
+	bans := make(chan message.Message, 2)
+	eb.Subscribe(topics.PeerBanned, eventbus.NewChanListener(bans))
+
+	v := &failingVerifier{}
+	c.verifier = v
+
+	deliver := func(peerID string, err error) {
+		v.err = err
+		_, _ = c.ProcessBlockFromNetwork(peerID, message.New(topics.Block, *helper.RandomBlock(1, 1)))
+	}
+
+	// Weighted misbehavior below the ban score
+	for i := 0; i < 3; i++ {
+		deliver("peer", verifiers.ErrFutureTimestamp)
+	}
+
+	assert.Equal(uint32(9), c.rejected.score("peer"))
+	assert.False(c.rejected.banned("peer"))
+	assert.Equal(3, v.calls)
+
+	// A misbehavior weighted zero is not scored
+	deliver("peer", nil)
+	assert.Equal(uint32(9), c.rejected.score("peer"))
+	assert.False(c.rejected.banned("peer"))
+	assert.Equal(4, v.calls)
+	assert.Empty(bans)
+
+	// Crossing the ban score notifies the p2p layer
+	deliver("peer", verifiers.ErrFutureTimestamp)
+	assert.True(c.rejected.banned("peer"))
+	assert.Equal(5, v.calls)
+
+	select {
+	case m := <-bans:
+		ban := m.Payload().(message.PeerBanned)
+		assert.Equal("peer", ban.Address)
+		assert.Equal(misbehaviorFutureTimestamp.String(), ban.Reason)
+		assert.Equal(uint32(12), ban.Score)
+		assert.Equal(rejectedHalfLife, ban.Duration.Round(time.Second))
+	case <-time.After(time.Second):
+		t.Fatal("ban not published")
+	}
+
+	// The blocks of a banned peer are dropped without verification
+	deliver("peer", nil)
+	assert.Equal(5, v.calls)
+
+	// Other peers are not affected
+	deliver("other_peer", nil)
+	assert.Equal(6, v.calls)
+	assert.False(c.rejected.banned("other_peer"))
+
+	// A ban is notified once
+	assert.Empty(bans)
+}
+
+func TestMisbehaviorScoreDecay(t *testing.T) {
+	assert := assert.New(t)
+
+	orig := config.Get()
+	defer config.Mock(&orig)
+
+	r := config.Get()
+	r.Network.Misbehavior.BanScore = 10
+	r.Network.Misbehavior.Equivocation = 20
+	config.Mock(&r)
+
+	now := time.Now()
+	rb := newRejectedBlocks(eventbus.New())
+	rb.now = func() time.Time { return now }
+
+	rb.penalize("peer", misbehaviorEquivocation)
+	assert.True(rb.banned("peer"))
+
+	// The score is halved once per half-life, rather than reset
+	now = now.Add(rejectedHalfLife - time.Second)
+	assert.Equal(uint32(20), rb.score("peer"))
+
+	now = now.Add(time.Second)
+	assert.Equal(uint32(10), rb.score("peer"))
+	assert.True(rb.banned("peer"))
+
+	now = now.Add(rejectedHalfLife)
+	assert.Equal(uint32(5), rb.score("peer"))
+	assert.False(rb.banned("peer"))
+
+	// Decayed scores are forgotten
+	now = now.Add(10 * rejectedHalfLife)
+	assert.Zero(rb.score("peer"))
+	assert.NotContains(rb.scores, "peer")
+}
+
+func TestClassifyMisbehavior(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.Equal(misbehaviorInvalidCertificate, classifyMisbehavior(fmt.Errorf("%w: bad signature", errInvalidCertificate)))
+	assert.Equal(misbehaviorFutureTimestamp, classifyMisbehavior(verifiers.ErrFutureTimestamp))
+	assert.Equal(misbehaviorEquivocation, classifyMisbehavior(errEquivocation))
+	assert.Equal(misbehaviorInvalidBlock, classifyMisbehavior(errors.New("invalid block")))
+}
+
+// mock a block which can be accepted by the chain.
+// note that this is only valid for height 1, as the certificate
+// is not checked on height 1 (for network bootstrapping)
+//nolint
+func mockAcceptableBlock(prevBlock block.Block) *block.Block {
+	// Create block 1
+	blk := helper.RandomBlock(1, 1)
+	// Add cert and prev hash
+	blk.Header.Certificate = block.EmptyCertificate()
+	blk.Header.PrevBlockHash = prevBlock.Header.Hash
+	blk.Header.StateHash = make([]byte, 32)
+	return blk
+}
+
+func setupChainTest(t testing.TB, startAtHeight uint64) (*eventbus.EventBus, *Chain) {
+	eb := eventbus.New()
+	rpc := rpcbus.New()
+
+	_, db := heavy.CreateDBConnection()
+	loader := createLoader(db)
+
+	proxy := &transactions.MockProxy{
+		E: transactions.MockExecutor(startAtHeight),
+	}
+
+	BLSKeys := key.NewRandKeys()
+
+	e := &consensus.Emitter{
+		EventBus:    eb,
+		RPCBus:      rpc,
+		Keys:        BLSKeys,
+		TimerLength: 5 * time.Second,
+	}
+
+	l := loop.New(e)
+
+	c, err := New(context.Background(), db, eb, rpc, loader, &MockVerifier{}, nil, proxy, l)
+	assert.NoError(t, err)
+
+	c.RestartConsensus()
+	return eb, c
+}
+
+func TestAcceptGenesis(t *testing.T) {
+	assert := assert.New(t)
+
+	// A chain started on an empty DB holds the default genesis block only
+	emptyChain := func() *Chain {
+		_, db := lite.CreateDBConnection()
+		proxy := &transactions.MockProxy{E: transactions.MockExecutor(0)}
+
+		c, err := New(context.Background(), db, eventbus.New(), rpcbus.New(), createLoader(db), &MockVerifier{}, nil, proxy, nil)
+		assert.NoError(err)
+
+		return c
+	}
+
+	c := emptyChain()
+	defer c.Close()
+
+	// A non-genesis height should be rejected
+	blk := helper.RandomBlock(1, 1)
+	assert.Equal(verifiers.ErrNotGenesisBlock, c.AcceptGenesis(*blk))
+
+	genesisBlk := helper.RandomBlock(0, 1)
+	assert.NoError(c.AcceptGenesis(*genesisBlk))
+
+	// Genesis should be the blockchain head now
+	assert.True(bytes.Equal(genesisBlk.Header.Hash, c.tip().Header.Hash))
+	assert.Equal(1, c.p.Set.Len())
+
+	b, err := c.loader.BlockAt(0)
+	assert.NoError(err)
+	assert.True(bytes.Equal(genesisBlk.Header.Hash, b.Header.Hash))
+
+	// The accepted genesis block is not overwritten
+	assert.ErrorIs(c.AcceptGenesis(*helper.RandomBlock(0, 1)), ErrChainNotEmpty)
+	assert.True(bytes.Equal(genesisBlk.Header.Hash, c.tip().Header.Hash))
+
+	// Neither is the genesis block of a chain holding blocks
+	c = emptyChain()
+	defer c.Close()
+
+	assert.NoError(c.acceptBlock(*mockAcceptableBlock(*c.tip()), true))
+
+	tip := c.tip()
+	assert.ErrorIs(c.AcceptGenesis(*helper.RandomBlock(0, 1)), ErrChainNotEmpty)
+	assert.Equal(tip, c.tip())
+}
+
+func TestSubmitBlock(t *testing.T) {
 	assert := assert.New(t)
-	_, chain := setupChainTest(t, 0)
+	_, c := setupChainTest(t, 0)
 
-	// on a modern chain, state(tip) must point at genesis
-	var s *database.Registry
+	// Run the full header checks
+	c.verifier = createLoader(c.db)
 
-	err := chain.db.View(func(t database.Transaction) error {
-		var err error
-		s, err = t.FetchRegistry()
+	submit := func(blk *block.Block) error {
+		buf := new(bytes.Buffer)
+		assert.NoError(message.MarshalBlock(buf, blk))
+
+		_, err := c.rpcBus.Call(topics.SubmitBlock, rpcbus.NewRequest(*buf), 5*time.Second)
 		return err
-	})
+	}
+
+	genesisHash := c.tip().Header.Hash
+
+	// A block not linked to the tip is rejected
+	invalid := mockAcceptableBlock(*c.tip())
+	invalid.Header.PrevBlockHash = make([]byte, 32)
+	invalid.Header.Hash, _ = invalid.CalculateHash()
+
+	assert.Equal(verifiers.ErrPrevBlockHash, submit(invalid))
+	assert.Equal(genesisHash, c.tip().Header.Hash)
+
+	// A valid block becomes the new tip
+	valid := mockAcceptableBlock(*c.tip())
+	valid.Header.Hash, _ = valid.CalculateHash()
+
+	assert.NoError(submit(valid))
+	assert.Equal(valid.Header.Hash, c.tip().Header.Hash)
+}
+
+func TestProvisionersSnapshotInterval(t *testing.T) {
+	assert := assert.New(t)
+	_, c := setupChainTest(t, 0)
+
+	orig := config.Get()
+	defer config.Mock(&orig)
+
+	r := config.Registry{}
+	r.API.Enabled = true
+	r.API.ProvisionersSnapshotInterval = 5
+	config.Mock(&r)
+
+	store, err := capi.NewStormDBInstance(filepath.Join(t.TempDir(), "api.db"))
 	assert.NoError(err)
 
-	assert.Equal(chain.tip.Header.Hash, s.TipHash)
+	defer store.Close()
+
+	capi.SetStormDBInstance(store)
+
+	for height := uint64(1); height <= 10; height++ {
+		c.postAcceptBlock(*helper.RandomBlock(height, 1), log)
+	}
+
+	var snapshots []capi.ProvisionerJSON
+
+	assert.Eventually(func() bool {
+		snapshots = nil
+		return store.DB.All(&snapshots) == nil && len(snapshots) == 2
+	}, 5*time.Second, 10*time.Millisecond)
+
+	assert.Equal(uint64(5), snapshots[0].ID)
+	assert.Equal(uint64(10), snapshots[1].ID)
+	assert.Len(snapshots[1].Members, c.p.Set.Len())
 }
 
-func TestSyncProgress(t *testing.T) {
+func TestProvisionersSnapshotsInOrder(t *testing.T) {
 	assert := assert.New(t)
 	_, c := setupChainTest(t, 0)
 
-	// SyncProgress should be 0% right now
-	resp, err := c.GetSyncProgress(context.Background(), &node.EmptyRequest{})
+	store, err := capi.NewStormDBInstance(filepath.Join(t.TempDir(), "api.db"))
 	assert.NoError(err)
 
-	assert.Equal(resp.Progress, float32(0.0))
+	defer store.Close()
 
-	// Change tipHeight and then give the chain a block from far in the future
-	c.tip.Header.Height = 50
-	blk := helper.RandomBlock(100, 1)
-	c.ProcessBlockFromNetwork("", message.New(topics.Block, *blk))
+	capi.SetStormDBInstance(store)
 
-	// SyncProgress should be 50%
-	resp, err = c.GetSyncProgress(context.Background(), &node.EmptyRequest{})
+	// Successive snapshots of the same height overwrite each other, so that
+	// the last one queued must be the one stored
+	for n := 1; n <= snapshotQueueSize; n++ {
+		p, _ := consensus.MockProvisioners(n)
+		c.pushSnapshot(provisionersSnapshot{height: 5, p: *p})
+	}
+
+	assert.Eventually(func() bool {
+		var snapshot capi.ProvisionerJSON
+		return store.Find("ID", uint64(5), &snapshot) == nil && snapshot.Total == snapshotQueueSize
+	}, 5*time.Second, 10*time.Millisecond)
+
+	// Close waits for the snapshot worker
+	c.Close()
+
+	p, _ := consensus.MockProvisioners(1)
+	c.pushSnapshot(provisionersSnapshot{height: 5, p: *p})
+
+	var snapshot capi.ProvisionerJSON
+
+	assert.NoError(store.Find("ID", uint64(5), &snapshot))
+	assert.Equal(snapshotQueueSize, snapshot.Total)
+}
+
+func TestAcceptBlockStaleProvisioners(t *testing.T) {
+	assert := assert.New(t)
+	_, c := setupChainTest(t, 0)
+
+	// The chain tip follows the genesis, so that the certificate of the next
+	// block is checked
+	tip := mockAcceptableBlock(*c.tip())
+	tip.Header.Seed = []byte{0, 0, 0, 0}
+	tip.Header.Hash, _ = tip.CalculateHash()
+	c.setTip(tip)
+
+	// The provisioners known to Rusk differ from the ones cached by the chain
+	p, keys := consensus.MockProvisioners(10)
+	c.proxy.(*transactions.MockProxy).E.(*transactions.PermissiveExecutor).P = p
+
+	blk := helper.RandomBlock(2, 1)
+	blk.Header.PrevBlockHash = tip.Header.Hash
+	blk.Header.StateHash = make([]byte, 32)
+	blk.Header.Hash, _ = blk.CalculateHash()
+	blk.Header.Certificate = message.MockAgreement(blk.Header.Hash, 2, 3, keys, p).GenerateCertificate()
+
+	assert.NoError(c.acceptBlock(*blk, true))
+	assert.Equal(blk.Header.Hash, c.tip().Header.Hash)
+	assert.Equal(p.Set.Len(), c.p.Set.Len())
+}
+
+func TestBlockFromForkHistoricalProvisioners(t *testing.T) {
+	assert := assert.New(t)
+	_, c := setupChainTest(t, 0)
+
+	orig := config.Get()
+	defer config.Mock(&orig)
+
+	r := config.Get()
+	r.Database.CommitteeRetention = 10
+	config.Mock(&r)
+
+	// Blocks 1 to 3 are accepted, block 2 being finalized by a set of
+	// provisioners which has changed since
+	p, keys := consensus.MockProvisioners(10)
+	blocks := make([]*block.Block, 4)
+
+	for height := uint64(1); height <= 3; height++ {
+		blk := helper.RandomBlock(height, 1)
+		blk.Header.Seed = []byte{0, 0, 0, 0}
+		blocks[height] = blk
+
+		assert.NoError(c.db.Update(func(t database.Transaction) error {
+			return t.StoreBlock(blk, false)
+		}))
+	}
+
+	c.storeCommittee(*blocks[2], blocks[1].Header.Seed, *p)
+	c.setTip(blocks[3])
+	c.p, _ = consensus.MockProvisioners(10)
+
+	// A block of a fork at height 2
+	fork := helper.RandomBlock(2, 1)
+	fork.Header.Certificate = message.MockAgreement(fork.Header.Hash, 2, 3, keys, p).GenerateCertificate()
+
+	assert.Error(c.isValidHeader(*fork, *blocks[1], *c.p, log, true))
+
+	// It is verified with the provisioners of that round
+	res, err := c.isBlockFromFork(*fork)
 	assert.NoError(err)
+	assert.True(res)
+}
 
-	assert.Equal(resp.Progress, float32(50.0))
+func TestEquivocation(t *testing.T) {
+	assert := assert.New(t)
+	eb, c := setupChainTest(t, 0)
+
+	orig := config.Get()
+	defer config.Mock(&orig)
+
+	r := config.Get()
+	r.Chain.PublishEquivocation = true
+	config.Mock(&r)
+
+	equivocationChan := make(chan message.Message, 1)
+	eb.Subscribe(topics.EquivocationDetected, eventbus.NewChanListener(equivocationChan))
+
+	tip := mockAcceptableBlock(*c.tip())
+	tip.Header.Seed = []byte{0, 0, 0, 0}
+	tip.Header.Hash, _ = tip.CalculateHash()
+	assert.NoError(c.acceptBlock(*tip, false))
+
+	p, keys := consensus.MockProvisioners(10)
+	c.proxy.(*transactions.MockProxy).E.(*transactions.PermissiveExecutor).P = p
+	c.p = p
+
+	// Two blocks are certified at height 2, in the same iteration
+	certified := func() *block.Block {
+		blk := helper.RandomBlock(2, 1)
+		blk.Header.PrevBlockHash = tip.Header.Hash
+		blk.Header.StateHash = make([]byte, 32)
+		blk.Header.Hash, _ = blk.CalculateHash()
+		blk.Header.Certificate = message.MockAgreement(blk.Header.Hash, 2, 3, keys, p).GenerateCertificate()
+		return blk
+	}
+
+	first, second := certified(), certified()
+
+	_, err := c.ProcessBlockFromNetwork("", message.New(topics.Block, *first))
+	assert.NoError(err)
+	assert.Equal(first.Header.Hash, c.tip().Header.Hash)
+
+	_, err = c.ProcessBlockFromNetwork("", message.New(topics.Block, *second))
+	assert.NoError(err)
+
+	// The first one is kept
+	assert.Equal(first.Header.Hash, c.tip().Header.Hash)
+
+	select {
+	case m := <-equivocationChan:
+		e := m.Payload().(message.Equivocation)
+		assert.Equal(first.Header.Hash, e.Kept.Hash)
+		assert.Equal(second.Header.Hash, e.Rejected.Hash)
+	case <-time.After(time.Second):
+		assert.FailNow("no equivocation notified")
+	}
 }
 
-func TestFallbackProcedure(t *testing.T) {
-	t.Skip()
+func TestGenesisTimestampMismatch(t *testing.T) {
+	assert := assert.New(t)
 
-	// Set up a chain instance with mocking verifiers
-	_, chain := setupChainTest(t, 1)
+	_, db := heavy.CreateDBConnection()
+	loader := createLoader(db)
 
-	// Accept block at height 1 with Certificate.Step = 6 (second iteration)
-	blk1 := helper.RandomBlock(1, 3)
+	orig := config.Get()
+	defer config.Mock(&orig)
 
-	cert1 := block.EmptyCertificate()
-	cert1.Step = 6
-	blk1.Header.Certificate = cert1
+	r := config.Registry{}
+	r.Genesis.Timestamp = loader.genesis.Header.Timestamp + 1
+	config.Mock(&r)
 
-	chain.ProcessBlockFromNetwork("", message.New(topics.Block, *blk1))
+	proxy := &transactions.MockProxy{
+		E: transactions.MockExecutor(0),
+	}
 
-	b, err := chain.loader.BlockAt(1)
-	assert.NoError(t, err)
+	e := &consensus.Emitter{
+		EventBus:    eventbus.New(),
+		RPCBus:      rpcbus.New(),
+		Keys:        key.NewRandKeys(),
+		TimerLength: 5 * time.Second,
+	}
 
-	// assert block was accepted successfully
-	assert.True(t, bytes.Equal(b.Header.Hash, blk1.Header.Hash))
+	_, err := New(context.Background(), db, e.EventBus, e.RPCBus, loader, &MockVerifier{}, nil, proxy, loop.New(e))
+	assert.ErrorIs(err, ErrGenesisTimestamp)
+}
 
-	// Enforce fallback procedure at block height 1
-	blk2 := helper.RandomBlock(1, 3)
+func TestTxHistory(t *testing.T) {
+	assert := assert.New(t)
+	_, c := setupChainTest(t, 0)
 
-	cert2 := block.EmptyCertificate()
-	cert2.Step = 3 // (first iteration certificate)
-	blk2.Header.Certificate = cert2
+	addrA := bytes.Repeat([]byte{0xa}, 64)
+	addrB := bytes.Repeat([]byte{0xb}, 64)
 
-	chain.ProcessBlockFromNetwork("", message.New(topics.Block, *blk2))
+	txA1 := transactions.MockTxWithAddresses(addrA)
+	txB1 := transactions.MockTxWithAddresses(addrB)
+	txAB := transactions.MockTxWithAddresses(addrA, addrB)
+	txA3 := transactions.MockTxWithAddresses(addrA)
 
-	b, err = chain.loader.BlockAt(1)
-	assert.NoError(t, err)
+	for height, txs := range [][]transactions.ContractCall{{txA1, txB1}, {txAB}, {txA3}} {
+		blk := helper.RandomBlock(uint64(height+1), 0)
+		blk.Txs = txs
 
-	// assert block at height 1 has been rewritten from fallback procedure
-	assert.True(t, bytes.Equal(b.Header.Hash, blk2.Header.Hash))
+		assert.NoError(c.persist(blk))
+		c.postAcceptBlock(*blk, log)
+	}
 
-	// Ensure fallback will be canceled if blk is from higher iteration
-	chain.ProcessBlockFromNetwork("", message.New(topics.Block, *blk1))
+	history := func(addr []byte, offset, limit uint64) []database.TxHistoryEntry {
+		var entries []database.TxHistoryEntry
 
-	b, err = chain.loader.BlockAt(1)
-	assert.NoError(t, err)
+		assert.NoError(c.db.View(func(t database.Transaction) error {
+			var err error
+			entries, err = t.FetchTxHistory(addr, offset, limit)
+			return err
+		}))
 
-	assert.True(t, bytes.Equal(b.Header.Hash, blk2.Header.Hash))
+		return entries
+	}
+
+	// Wait for the last block to be indexed
+	assert.Eventually(func() bool {
+		return len(history(addrA, 0, 10)) == 3
+	}, 5*time.Second, 10*time.Millisecond)
+
+	txID := func(tx *transactions.Transaction) []byte {
+		h, _ := tx.CalculateHash()
+		return h
+	}
+
+	// Newest first
+	entries := history(addrA, 0, 10)
+	assert.Equal(txID(txA3), entries[0].TxID)
+	assert.Equal(uint64(3), entries[0].Height)
+	assert.Equal(txID(txAB), entries[1].TxID)
+	assert.Equal(uint64(2), entries[1].Height)
+	assert.Equal(txID(txA1), entries[2].TxID)
+	assert.Equal(uint64(1), entries[2].Height)
+
+	// The history of the other address holds only its txs
+	entries = history(addrB, 0, 10)
+	assert.Len(entries, 2)
+	assert.Equal(txID(txAB), entries[0].TxID)
+	assert.Equal(txID(txB1), entries[1].TxID)
+	assert.Equal(uint32(1), entries[1].TxIndex)
+
+	// Pagination
+	entries = history(addrA, 1, 1)
+	assert.Len(entries, 1)
+	assert.Equal(txID(txAB), entries[0].TxID)
+
+	assert.Empty(history(addrA, 3, 10))
+
+	// The height of the last block indexed is stored along with the index
+	assert.NoError(c.db.View(func(t database.Transaction) error {
+		height, err := t.FetchTxHistoryHeight()
+		assert.Equal(uint64(3), height)
+		return err
+	}))
 }
 
-// mock a block which can be accepted by the chain.
-// note that this is only valid for height 1, as the certificate
-// is not checked on height 1 (for network bootstrapping)
-//nolint
-func mockAcceptableBlock(prevBlock block.Block) *block.Block {
-	// Create block 1
-	blk := helper.RandomBlock(1, 1)
-	// Add cert and prev hash
-	blk.Header.Certificate = block.EmptyCertificate()
-	blk.Header.PrevBlockHash = prevBlock.Header.Hash
-	blk.Header.StateHash = make([]byte, 32)
-	return blk
+func TestTxHistoryCatchUp(t *testing.T) {
+	assert := assert.New(t)
+
+	_, db := heavy.CreateDBConnection()
+	genesis, _, err := createLoader(db).LoadTip()
+	assert.NoError(err)
+
+	addr := bytes.Repeat([]byte{0xa}, 64)
+
+	store := func(prev *block.Block) *block.Block {
+		blk := helper.RandomBlock(prev.Header.Height+1, 0)
+		blk.Header.PrevBlockHash = prev.Header.Hash
+		blk.Txs = []transactions.ContractCall{transactions.MockTxWithAddresses(addr)}
+
+		assert.NoError(db.Update(func(t database.Transaction) error {
+			return t.StoreBlock(blk, true)
+		}))
+
+		return blk
+	}
+
+	heights := func() []uint64 {
+		var entries []database.TxHistoryEntry
+
+		assert.NoError(db.View(func(t database.Transaction) error {
+			var err error
+			entries, err = t.FetchTxHistory(addr, 0, 10)
+			return err
+		}))
+
+		h := make([]uint64, len(entries))
+		for i, e := range entries {
+			h[i] = e.Height
+		}
+
+		return h
+	}
+
+	// The blocks stored while the indexer was not running are indexed from
+	// the last one indexed
+	blk1 := store(genesis)
+	i := newTxHistoryIndexer(db)
+	assert.NoError(i.catchUp(context.Background()))
+
+	blk2 := store(blk1)
+	store(blk2)
+	assert.NoError(i.catchUp(context.Background()))
+	assert.Equal([]uint64{3, 2, 1}, heights())
+
+	// The blocks replacing reverted ones are indexed
+	assert.NoError(db.Update(func(t database.Transaction) error {
+		if err := t.DeleteBlock(blk2); err != nil {
+			return err
+		}
+
+		if err := t.StoreBlock(blk1, true); err != nil {
+			return err
+		}
+
+		return i.rewind(t, 1)
+	}))
+
+	blk2 = store(blk1)
+	assert.NoError(i.catchUp(context.Background()))
+
+	assert.NoError(db.View(func(t database.Transaction) error {
+		height, err := t.FetchTxHistoryHeight()
+		assert.Equal(uint64(2), height)
+		return err
+	}))
+
+	var txID []byte
+
+	assert.NoError(db.View(func(t database.Transaction) error {
+		entries, err := t.FetchTxHistory(addr, 0, 1)
+		txID = entries[0].TxID
+		return err
+	}))
+
+	expected, _ := blk2.Txs[0].CalculateHash()
+	assert.Equal(expected, txID)
 }
 
-func setupChainTest(t *testing.T, startAtHeight uint64) (*eventbus.EventBus, *Chain) {
+func TestCloseChain(t *testing.T) {
+	assert := assert.New(t)
+
+	orig := config.Get()
+	defer config.Mock(&orig)
+
+	r := config.Get()
+	r.Consensus.ConsensusTimeOut = 1
+	config.Mock(&r)
+
 	eb := eventbus.New()
 	rpc := rpcbus.New()
 
@@ -237,23 +1830,203 @@ func setupChainTest(t *testing.T, startAtHeight uint64) (*eventbus.EventBus, *Ch
 	loader := createLoader(db)
 
 	proxy := &transactions.MockProxy{
-		E: transactions.MockExecutor(startAtHeight),
+		E: transactions.MockExecutor(0),
 	}
 
-	BLSKeys := key.NewRandKeys()
-
 	e := &consensus.Emitter{
 		EventBus:    eb,
 		RPCBus:      rpc,
-		Keys:        BLSKeys,
-		TimerLength: 5 * time.Second,
+		Keys:        key.NewRandKeys(),
+		TimerLength: 100 * time.Millisecond,
 	}
 
 	l := loop.New(e)
 
+	baseline := runtime.NumGoroutine()
+
 	c, err := New(context.Background(), db, eb, rpc, loader, &MockVerifier{}, nil, proxy, l)
-	assert.NoError(t, err)
+	assert.NoError(err)
+	assert.NoError(c.StartConsensus())
 
-	c.RestartConsensus()
-	return eb, c
+	c.Close()
+
+	// All the chain and consensus goroutines are gone, once the pending
+	// step timers have fired. Polled in place, as assert.Eventually runs
+	// goroutines itself.
+	deadline := time.Now().Add(5 * time.Second)
+	for runtime.NumGoroutine() > baseline && time.Now().Before(deadline) {
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	assert.LessOrEqual(runtime.NumGoroutine(), baseline)
+
+	// Closing the buses afterwards does not make any late publisher panic
+	rpc.Close()
+	eb.Close()
+}
+
+func TestRequestBlockAtHeight(t *testing.T) {
+	assert := assert.New(t)
+	eb, c := setupChainTest(t, 0)
+
+	sent := make(chan message.Message, 1)
+	eb.Subscribe(topics.KadcastSendToOne, eventbus.NewChanListener(sent))
+
+	// The blocks following the tip are requested from the tip onwards
+	assert.NoError(c.RequestBlockAtHeight("127.0.0.1:9000", c.tip().Header.Height+1, 2))
+
+	m := <-sent
+	assert.Equal("127.0.0.1:9000", m.Metadata().Source)
+
+	buf := m.Payload().(message.SafeBuffer)
+	msg, err := message.Unmarshal(&buf.Buffer, nil)
+	assert.NoError(err)
+	assert.Equal(topics.GetBlocks, msg.Category())
+	assert.Equal([][]byte{c.tip().Header.Hash}, msg.Payload().(message.GetBlocks).Locators)
+	assert.Equal(uint64(2), msg.Payload().(message.GetBlocks).Count)
+
+	// A block can not be requested past the tip successor
+	assert.Error(c.RequestBlockAtHeight("127.0.0.1:9000", c.tip().Header.Height+2, 1))
+	assert.Empty(sent)
+}
+
+func TestAcceptBlockCanceled(t *testing.T) {
+	assert := assert.New(t)
+	startingHeight := uint64(1)
+
+	// A block is not accepted once the chain is closed
+	_, c := setupChainTest(t, startingHeight)
+	tip, p := c.tip(), c.p
+
+	c.cancel()
+
+	err := c.acceptBlock(*helper.RandomBlock(startingHeight, 1), true)
+	assert.ErrorIs(err, context.Canceled)
+	assert.Equal(tip, c.tip())
+	assert.Equal(p, c.p)
+
+	// Once Rusk has committed the state transition, the block is accepted
+	// even if the chain is closed meanwhile
+	_, c = setupChainTest(t, startingHeight)
+
+	proxy := c.proxy.(*transactions.MockProxy)
+	proxy.E = stubExecutor{
+		PermissiveExecutor: proxy.E.(*transactions.PermissiveExecutor),
+		onStateTransition:  c.cancel,
+	}
+
+	blk := helper.RandomBlock(startingHeight, 1)
+	assert.NoError(c.acceptBlock(*blk, true))
+	assert.Equal(blk.Header.Hash, c.tip().Header.Hash)
+
+	stored, err := c.loader.BlockAt(startingHeight)
+	assert.NoError(err)
+	assert.Equal(blk.Header.Hash, stored.Header.Hash)
+}
+
+// recordingMetrics records the block acceptance measurements.
+type recordingMetrics struct {
+	phases   []string
+	accepted int
+	rejected int
+}
+
+func (m *recordingMetrics) ObserveDuration(phase string, _ time.Duration) {
+	m.phases = append(m.phases, phase)
+}
+
+func (m *recordingMetrics) CountBlock(accepted bool) {
+	if accepted {
+		m.accepted++
+	} else {
+		m.rejected++
+	}
+}
+
+func TestAcceptBlockMetrics(t *testing.T) {
+	assert := assert.New(t)
+	startingHeight := uint64(1)
+
+	_, c := setupChainTest(t, startingHeight)
+
+	m := new(recordingMetrics)
+	c.SetMetrics(m)
+
+	assert.NoError(c.acceptBlock(*helper.RandomBlock(startingHeight, 1), true))
+	assert.Equal([]string{PhaseSanityCheck, PhaseStateTransition, PhasePersist}, m.phases)
+	assert.Equal(1, m.accepted)
+
+	// A block failing the sanity check is rejected
+	c.verifier = &failingVerifier{}
+	m.phases = nil
+
+	assert.Error(c.acceptBlock(*helper.RandomBlock(startingHeight+1, 1), true))
+	assert.Equal([]string{PhaseSanityCheck}, m.phases)
+	assert.Equal(1, m.accepted)
+	assert.Equal(1, m.rejected)
+
+	// Measurements can be disabled
+	c.SetMetrics(nil)
+	assert.Error(c.acceptBlock(*helper.RandomBlock(startingHeight+1, 1), true))
+	assert.Equal(1, m.rejected)
+}
+
+func TestRestoreHighestSeen(t *testing.T) {
+	assert := assert.New(t)
+	c, keys, p := setupPipelineTest(t, 0)
+
+	blks := linkedBlocks(c.tip(), 4, keys, p)
+	for _, blk := range blks {
+		assert.NoError(c.acceptBlock(*blk, true))
+	}
+
+	c.observeHeight(8)
+	assert.Equal(float64(50), c.CalculateSyncProgress())
+
+	restart := func() *Chain {
+		c.Close()
+
+		restarted, err := New(context.Background(), c.db, eventbus.New(), rpcbus.New(), c.loader, c.verifier, nil, c.proxy, c.loop)
+		assert.NoError(err)
+
+		return restarted
+	}
+
+	// The sync progress is restored along with the highest height seen
+	c = restart()
+	assert.Equal(uint64(4), c.tip().Header.Height)
+	assert.Equal(float64(50), c.CalculateSyncProgress())
+
+	// A highest height seen below the tip is clamped to it
+	assert.NoError(c.loader.StoreHighestSeen(2))
+
+	c = restart()
+	assert.Equal(uint64(4), c.chainState.snapshot().highestSeen)
+	assert.Equal(float64(100), c.CalculateSyncProgress())
+}
+
+func TestGetBlockAtHeight(t *testing.T) {
+	assert := assert.New(t)
+	_, c := setupChainTest(t, 1)
+
+	assert.NoError(c.acceptBlock(*helper.RandomBlock(1, 2), true))
+
+	call := func(height uint64) (interface{}, error) {
+		params := new(bytes.Buffer)
+		assert.NoError(encoding.WriteUint64LE(params, height))
+
+		return c.rpcBus.Call(topics.GetBlockAtHeight, rpcbus.NewRequest(*params), time.Second)
+	}
+
+	resp, err := call(1)
+	assert.NoError(err)
+
+	buf := resp.(bytes.Buffer)
+	blk := block.NewBlock()
+	assert.NoError(message.UnmarshalBlock(&buf, blk))
+	assert.True(blk.Equals(c.tip()))
+
+	// Blocks above the tip are not found
+	_, err = call(2)
+	assert.ErrorIs(err, database.ErrBlockNotFound)
 }
@@ -0,0 +1,144 @@
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT License was not distributed with this
+// file, you can obtain one at https://opensource.org/licenses/MIT.
+//
+// Copyright (c) DUSK NETWORK. All rights reserved.
+
+package chain
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/dusk-network/dusk-blockchain/pkg/core/data/block"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/database"
+	"github.com/dusk-network/dusk-crypto/hash"
+)
+
+// chainHashBatch is the number of chain hashes backfilled per transaction.
+const chainHashBatch = 1000
+
+// GetChainHash returns the cumulative hash of the chain up to the block at
+// height. Two nodes with the same chain hash at a height have the same
+// blocks up to it.
+func (c *Chain) GetChainHash(height uint64) ([]byte, error) {
+	if tip := c.tip().Header.Height; height > tip {
+		return nil, fmt.Errorf("height %d above the tip %d: %w", height, tip, database.ErrBlockNotFound)
+	}
+
+	var h []byte
+
+	err := c.db.View(func(t database.Transaction) error {
+		var err error
+		h, err = t.FetchChainHash(height)
+		return err
+	})
+
+	return h, err
+}
+
+// nextChainHash returns the chain hash following prev, after appending the
+// block with the given hash. The chain hash of the genesis block is the hash
+// of its hash.
+func nextChainHash(prev, blockHash []byte) ([]byte, error) {
+	buf := make([]byte, 0, len(prev)+len(blockHash))
+	buf = append(buf, prev...)
+	buf = append(buf, blockHash...)
+
+	return hash.Sha3256(buf)
+}
+
+// backfillChainHashes stores the chain hashes missing up to tip, e.g. for the
+// blocks stored before chain hashes were introduced. It runs at startup,
+// before any block is accepted, so that accepting a block only extends the
+// chain hash of its predecessor.
+func backfillChainHashes(db database.DB, tip uint64) error {
+	var (
+		from uint64
+		prev []byte
+	)
+
+	// The chain hashes are stored from the genesis block up, hence look for
+	// the highest one stored.
+	err := db.View(func(t database.Transaction) error {
+		for i := tip; ; i-- {
+			h, err := t.FetchChainHash(i)
+			if err == nil {
+				from, prev = i+1, h
+				return nil
+			}
+
+			if !errors.Is(err, database.ErrChainHashNotFound) {
+				return err
+			}
+
+			if i == 0 {
+				return nil
+			}
+		}
+	})
+	if err != nil {
+		return err
+	}
+
+	if from <= tip {
+		log.WithField("from", from).WithField("to", tip).Info("backfilling chain hashes")
+	}
+
+	for from <= tip {
+		to := from + chainHashBatch - 1
+		if to > tip {
+			to = tip
+		}
+
+		err := db.Update(func(t database.Transaction) error {
+			h := prev
+
+			for i := from; i <= to; i++ {
+				blockHash, err := t.FetchBlockHashByHeight(i)
+				if err != nil {
+					return err
+				}
+
+				if h, err = nextChainHash(h, blockHash); err != nil {
+					return err
+				}
+
+				if err := t.StoreChainHash(i, h); err != nil {
+					return err
+				}
+			}
+
+			prev = h
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+
+		from = to + 1
+	}
+
+	return nil
+}
+
+// storeChainHash stores the chain hash at the height of b, as b is being
+// stored in the same transaction. It extends the chain hash stored for the
+// previous block.
+func storeChainHash(t database.Transaction, b *block.Block) error {
+	var prev []byte
+
+	if b.Header.Height > 0 {
+		var err error
+		if prev, err = t.FetchChainHash(b.Header.Height - 1); err != nil {
+			return err
+		}
+	}
+
+	h, err := nextChainHash(prev, b.Header.Hash)
+	if err != nil {
+		return err
+	}
+
+	return t.StoreChainHash(b.Header.Height, h)
+}
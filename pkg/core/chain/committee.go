@@ -0,0 +1,353 @@
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT License was not distributed with this
+// file, you can obtain one at https://opensource.org/licenses/MIT.
+//
+// Copyright (c) DUSK NETWORK. All rights reserved.
+
+package chain
+
+import (
+	"bytes"
+	"errors"
+	"sort"
+	"time"
+
+	"github.com/dusk-network/dusk-blockchain/pkg/config"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus/user"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/data/block"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/database"
+	"github.com/dusk-network/dusk-blockchain/pkg/p2p/wire/encoding"
+	"github.com/dusk-network/dusk-blockchain/pkg/util/nativeutils/rpcbus"
+	"github.com/dusk-network/dusk-blockchain/pkg/util/nativeutils/sortedset"
+)
+
+// FinalizingCommittee is the set of provisioners whose votes are aggregated
+// in the certificate of a block, for both reduction steps.
+type FinalizingCommittee struct {
+	Round   uint64
+	Step    uint8
+	StepOne []CommitteeMember
+	StepTwo []CommitteeMember
+}
+
+// compactCommitteeTag starts a committee stored in its compact form. It can not
+// be mistaken for the step starting a committee stored with its members.
+const compactCommitteeTag = uint8(0xff)
+
+// newFinalizingCommittee reconstructs the committee which voted for the
+// certificate of blk, out of the provisioners and the seed it was verified
+// with.
+func newFinalizingCommittee(blk block.Block, seed []byte, p user.Provisioners) FinalizingCommittee {
+	return deriveCommittee(blk.Header.Height, blk.Header.Certificate, seed, p)
+}
+
+// deriveCommittee reconstructs the committee which voted for cert at the given
+// round.
+func deriveCommittee(round uint64, cert *block.Certificate, seed []byte, p user.Provisioners) FinalizingCommittee {
+	return FinalizingCommittee{
+		Round:   round,
+		Step:    cert.Step,
+		StepOne: stepVoters(p, seed, round, cert.Step-1, cert.StepOneCommittee),
+		StepTwo: stepVoters(p, seed, round, cert.Step, cert.StepTwoCommittee),
+	}
+}
+
+// stepVoters returns the members of the committee of a reduction step who are
+// part of the certificate bitset.
+func stepVoters(p user.Provisioners, seed []byte, round uint64, step uint8, bitSet uint64) []CommitteeMember {
+	size := p.SubsetSizeAt(round)
+	if size > config.ConsensusMaxCommitteeSize {
+		size = config.ConsensusMaxCommitteeSize
+	}
+
+	committee := p.CreateVotingCommittee(seed, round, step, size)
+	return committeeMembers(committee.IntersectCluster(bitSet))
+}
+
+// committeeMembers lists the members of a committee, sorted by BLS public key
+// bytes. The order is canonical, so that all nodes present the same committee
+// the same way, regardless of how the set is ordered internally.
+func committeeMembers(c sortedset.Cluster) []CommitteeMember {
+	members := make([]CommitteeMember, 0, len(c.Set))
+	for _, k := range c.Set {
+		pk := k.Bytes()
+		members = append(members, CommitteeMember{
+			PubKeyBLS: pk,
+			Votes:     c.OccurrencesOf(pk),
+		})
+	}
+
+	sort.Slice(members, func(i, j int) bool {
+		return bytes.Compare(members[i].PubKeyBLS, members[j].PubKeyBLS) < 0
+	})
+
+	return members
+}
+
+// storeCommittee stores the committee which finalized blk, along with the
+// provisioners its certificate is verified with, and drops the ones which went
+// out of the retention window. It is a no-op if Database.CommitteeRetention is
+// not set.
+func (c *Chain) storeCommittee(blk block.Block, seed []byte, p user.Provisioners) {
+	retention := config.Get().Database.CommitteeRetention
+	if retention == 0 {
+		return
+	}
+
+	round := blk.Header.Height
+	l := log.WithField("round", round)
+
+	var (
+		buf = new(bytes.Buffer)
+		err error
+	)
+
+	if config.Get().Database.CompactCommittees {
+		err = marshalCompactCommittee(buf, blk.Header.Certificate, seed)
+	} else {
+		err = marshalFinalizingCommittee(buf, newFinalizingCommittee(blk, seed, p))
+	}
+
+	if err != nil {
+		l.WithError(err).Warn("could not marshal committee")
+		return
+	}
+
+	pBuf := new(bytes.Buffer)
+	if err := user.MarshalProvisioners(pBuf, &p); err != nil {
+		l.WithError(err).Warn("could not marshal provisioners")
+		return
+	}
+
+	err = c.db.Update(func(t database.Transaction) error {
+		if err := t.StoreCommittee(round, buf.Bytes()); err != nil {
+			return err
+		}
+
+		if err := t.StoreProvisioners(round, pBuf.Bytes()); err != nil {
+			return err
+		}
+
+		if round < retention {
+			return nil
+		}
+
+		if err := t.DeleteCommittee(round - retention); err != nil {
+			return err
+		}
+
+		return t.DeleteProvisioners(round - retention)
+	})
+	if err != nil {
+		l.WithError(err).Warn("could not store committee")
+	}
+}
+
+// GetCommitteeAtRound returns the committee which finalized the block at the
+// given round. Only the last Database.CommitteeRetention rounds are kept.
+func (c *Chain) GetCommitteeAtRound(round uint64) (FinalizingCommittee, error) {
+	var data []byte
+
+	err := c.db.View(func(t database.Transaction) error {
+		var err error
+		data, err = t.FetchCommittee(round)
+		return err
+	})
+	if err != nil {
+		return FinalizingCommittee{}, err
+	}
+
+	if len(data) > 0 && data[0] == compactCommitteeTag {
+		return c.expandCommittee(round, bytes.NewBuffer(data[1:]))
+	}
+
+	committee := FinalizingCommittee{Round: round}
+	if err := unmarshalFinalizingCommittee(bytes.NewBuffer(data), &committee); err != nil {
+		return FinalizingCommittee{}, err
+	}
+
+	return committee, nil
+}
+
+// expandCommittee derives a committee stored in its compact form, out of the
+// provisioners stored for the same round.
+func (c *Chain) expandCommittee(round uint64, r *bytes.Buffer) (FinalizingCommittee, error) {
+	cert, seed, err := unmarshalCompactCommittee(r)
+	if err != nil {
+		return FinalizingCommittee{}, err
+	}
+
+	p, err := c.provisionersAt(round)
+	if err != nil {
+		return FinalizingCommittee{}, err
+	}
+
+	return deriveCommittee(round, cert, seed, p), nil
+}
+
+// provisionersAt returns the provisioners the certificate of the block at the
+// given round is verified with. Only the last Database.CommitteeRetention
+// rounds are kept.
+func (c *Chain) provisionersAt(round uint64) (user.Provisioners, error) {
+	var data []byte
+
+	err := c.db.View(func(t database.Transaction) error {
+		var err error
+		data, err = t.FetchProvisioners(round)
+		return err
+	})
+	if err != nil {
+		return user.Provisioners{}, err
+	}
+
+	return user.UnmarshalProvisioners(bytes.NewBuffer(data))
+}
+
+// serveCommitteeRequests answers topics.GetCommitteeAtRound requests until the
+// chain context is canceled. The request params hold the round as a uint64 LE.
+func (c *Chain) serveCommitteeRequests(reqChan <-chan rpcbus.Request) {
+	for {
+		select {
+		case r := <-reqChan:
+			committee, err := c.processGetCommitteeRequest(r)
+
+			timeout := time.Duration(config.Get().Timeout.TimeoutSendResponse) * time.Millisecond
+			if err := r.Respond(rpcbus.NewResponse(committee, err), timeout); err != nil {
+				log.WithError(err).Warn("failed to send committee response")
+			}
+		case <-c.ctx.Done():
+			return
+		}
+	}
+}
+
+func (c *Chain) processGetCommitteeRequest(r rpcbus.Request) (FinalizingCommittee, error) {
+	params, ok := r.Params.(bytes.Buffer)
+	if !ok {
+		return FinalizingCommittee{}, errors.New("invalid round")
+	}
+
+	var round uint64
+	if err := encoding.ReadUint64LE(&params, &round); err != nil {
+		return FinalizingCommittee{}, err
+	}
+
+	return c.GetCommitteeAtRound(round)
+}
+
+func marshalFinalizingCommittee(r *bytes.Buffer, c FinalizingCommittee) error {
+	if err := encoding.WriteUint8(r, c.Step); err != nil {
+		return err
+	}
+
+	if err := marshalCommitteeMembers(r, c.StepOne); err != nil {
+		return err
+	}
+
+	return marshalCommitteeMembers(r, c.StepTwo)
+}
+
+// marshalCompactCommittee writes the seed and the bitsets of cert, from which
+// the committee is derived.
+func marshalCompactCommittee(r *bytes.Buffer, cert *block.Certificate, seed []byte) error {
+	if err := encoding.WriteUint8(r, compactCommitteeTag); err != nil {
+		return err
+	}
+
+	if err := encoding.WriteUint8(r, cert.Step); err != nil {
+		return err
+	}
+
+	if err := encoding.WriteUint64LE(r, cert.StepOneCommittee); err != nil {
+		return err
+	}
+
+	if err := encoding.WriteUint64LE(r, cert.StepTwoCommittee); err != nil {
+		return err
+	}
+
+	return encoding.WriteVarBytes(r, seed)
+}
+
+// unmarshalCompactCommittee reads the compact form of a committee, following
+// its tag. The returned certificate holds only the step and the bitsets.
+func unmarshalCompactCommittee(r *bytes.Buffer) (*block.Certificate, []byte, error) {
+	cert := new(block.Certificate)
+	if err := encoding.ReadUint8(r, &cert.Step); err != nil {
+		return nil, nil, err
+	}
+
+	if err := encoding.ReadUint64LE(r, &cert.StepOneCommittee); err != nil {
+		return nil, nil, err
+	}
+
+	if err := encoding.ReadUint64LE(r, &cert.StepTwoCommittee); err != nil {
+		return nil, nil, err
+	}
+
+	var seed []byte
+	if err := encoding.ReadVarBytes(r, &seed); err != nil {
+		return nil, nil, err
+	}
+
+	return cert, seed, nil
+}
+
+func unmarshalFinalizingCommittee(r *bytes.Buffer, c *FinalizingCommittee) error {
+	if err := encoding.ReadUint8(r, &c.Step); err != nil {
+		return err
+	}
+
+	var err error
+	if c.StepOne, err = unmarshalCommitteeMembers(r); err != nil {
+		return err
+	}
+
+	c.StepTwo, err = unmarshalCommitteeMembers(r)
+	return err
+}
+
+func marshalCommitteeMembers(r *bytes.Buffer, members []CommitteeMember) error {
+	if err := encoding.WriteVarInt(r, uint64(len(members))); err != nil {
+		return err
+	}
+
+	for _, m := range members {
+		if err := encoding.WriteVarBytes(r, m.PubKeyBLS); err != nil {
+			return err
+		}
+
+		if err := encoding.WriteUint32LE(r, uint32(m.Votes)); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func unmarshalCommitteeMembers(r *bytes.Buffer) ([]CommitteeMember, error) {
+	n, err := encoding.ReadVarInt(r)
+	if err != nil {
+		return nil, err
+	}
+
+	if n > uint64(config.ConsensusMaxCommitteeSize) {
+		return nil, errors.New("committee too large")
+	}
+
+	members := make([]CommitteeMember, n)
+	for i := range members {
+		if err := encoding.ReadVarBytes(r, &members[i].PubKeyBLS); err != nil {
+			return nil, err
+		}
+
+		var votes uint32
+		if err := encoding.ReadUint32LE(r, &votes); err != nil {
+			return nil, err
+		}
+
+		members[i].Votes = int(votes)
+	}
+
+	return members, nil
+}
@@ -7,6 +7,7 @@
 package chain
 
 import (
+	"bytes"
 	"context"
 	"errors"
 	"sync/atomic"
@@ -14,8 +15,129 @@ import (
 
 	"github.com/dusk-network/dusk-blockchain/pkg/config"
 	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus"
+	"github.com/dusk-network/dusk-blockchain/pkg/p2p/wire/encoding"
+	"github.com/dusk-network/dusk-blockchain/pkg/p2p/wire/topics"
+	"github.com/dusk-network/dusk-blockchain/pkg/util/nativeutils/rpcbus"
 )
 
+var errNoConsensusLoop = errors.New("no consensus loop present")
+
+// peerCountPollInterval is the interval between two peer count or mempool
+// readiness queries, while waiting to start the consensus.
+var peerCountPollInterval = time.Second
+
+// StartConsensus starts the consensus loop on node startup. The first round
+// is deferred until the node is connected to Consensus.MinPeersToStart peers,
+// or Consensus.MinPeersTimeout elapses. It is then deferred until the mempool
+// is ready, for at most Consensus.MempoolReadyTimeout.
+func (c *Chain) StartConsensus() error {
+	cfg := config.Get().Consensus
+
+	timeout := cfg.MinPeersTimeout
+	if timeout == 0 {
+		timeout = config.DefaultMinPeersTimeout
+	}
+
+	c.waitForPeers(cfg.MinPeersToStart, time.Duration(timeout)*time.Second)
+
+	if cfg.MempoolReadyTimeout > 0 {
+		c.waitForMempool(time.Duration(cfg.MempoolReadyTimeout) * time.Second)
+	}
+
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	return c.RestartConsensus()
+}
+
+// waitForPeers blocks until the peer count, as provided by
+// topics.GetPeerCount, reaches minPeers or the timeout elapses.
+func (c *Chain) waitForPeers(minPeers uint32, timeout time.Duration) {
+	if minPeers == 0 {
+		return
+	}
+
+	deadline := time.NewTimer(timeout)
+	defer deadline.Stop()
+
+	ticker := time.NewTicker(peerCountPollInterval)
+	defer ticker.Stop()
+
+	l := log.WithField("min_peers", minPeers)
+
+	for {
+		buf := new(bytes.Buffer)
+		if err := encoding.WriteUint32LE(buf, minPeers); err != nil {
+			l.WithError(err).Error("could not encode peer count request")
+			return
+		}
+
+		resp, err := c.rpcBus.Call(topics.GetPeerCount, rpcbus.NewRequest(*buf), peerCountPollInterval)
+
+		var notExists *rpcbus.ErrMethodNotExists
+
+		switch {
+		case errors.As(err, &notExists):
+			l.Warn("peer count not available, starting consensus")
+			return
+		case err != nil:
+			l.WithError(err).Debug("could not get peer count")
+		case uint32(resp.(int)) >= minPeers:
+			l.WithField("peers", resp.(int)).Info("enough peers to start consensus")
+			return
+		default:
+			l.WithField("peers", resp.(int)).Info("waiting for peers to start consensus")
+		}
+
+		select {
+		case <-ticker.C:
+		case <-deadline.C:
+			l.Warn("timeout waiting for peers, starting consensus")
+			return
+		case <-c.ctx.Done():
+			return
+		}
+	}
+}
+
+// waitForMempool blocks until the mempool reports it is ready through
+// topics.GetMempoolReady, or the timeout elapses.
+func (c *Chain) waitForMempool(timeout time.Duration) {
+	deadline := time.NewTimer(timeout)
+	defer deadline.Stop()
+
+	ticker := time.NewTicker(peerCountPollInterval)
+	defer ticker.Stop()
+
+	for {
+		resp, err := c.rpcBus.Call(topics.GetMempoolReady, rpcbus.EmptyRequest(), peerCountPollInterval)
+
+		var notExists *rpcbus.ErrMethodNotExists
+
+		switch {
+		case errors.As(err, &notExists):
+			log.Warn("mempool readiness not available, starting consensus")
+			return
+		case err != nil:
+			log.WithError(err).Debug("could not get mempool readiness")
+		case resp.(bool):
+			log.Info("mempool ready, starting consensus")
+			return
+		default:
+			log.Info("waiting for mempool to start consensus")
+		}
+
+		select {
+		case <-ticker.C:
+		case <-deadline.C:
+			log.Warn("timeout waiting for mempool, starting consensus")
+			return
+		case <-c.ctx.Done():
+			return
+		}
+	}
+}
+
 // RestartConsensus implements Stop and Start Consensus.
 // This is a safer approach to ensure we do not duplicate Consensus loop.
 func (c *Chain) RestartConsensus() error {
@@ -27,6 +149,11 @@ func (c *Chain) RestartConsensus() error {
 // sending a signal through the `stopConsensus` channel (`StopConsensus`
 // as exposed by the `Ledger` interface).
 func (c *Chain) startConsensus() error {
+	if config.Get().Node.ObserverMode {
+		log.Debug("observer mode, consensus loop not started")
+		return nil
+	}
+
 	ctx, cancel := context.WithCancel(c.ctx)
 
 	id := c.beginTrace()
@@ -40,12 +167,12 @@ func (c *Chain) startConsensus() error {
 		return err
 	}
 
-	go func(ctx context.Context, cancel context.CancelFunc, winnerChan chan consensus.Results) {
+	c.spawn(func() {
 		defer cancel()
 		defer log.WithField("id", id).Info("consensus_loop terminated")
 
 		c.acceptConsensusResults(ctx, winnerChan)
-	}(ctx, cancel, winnerChan)
+	})
 
 	return nil
 }
@@ -64,7 +191,7 @@ func (c *Chain) acceptConsensusResults(ctx context.Context, winnerChan chan cons
 
 			c.lock.Lock()
 
-			if block.IsEmpty() || block.Header.Height != c.tip.Header.Height+1 {
+			if block.IsEmpty() || block.Header.Height != c.tip().Header.Height+1 {
 				log.WithField("height", block.Header.Height).Debugln("discarding consensus result")
 				c.lock.Unlock()
 				return
@@ -106,14 +233,14 @@ func (c *Chain) asyncSpin(ctx context.Context, winnerChan chan consensus.Results
 			return err
 		}
 
-		go func() {
+		c.spawn(func() {
 			winnerChan <- c.loop.Spin(ctx, scr, agr, ru)
-		}()
+		})
 
 		return nil
 	}
 
-	return errors.New("no consensus loop present")
+	return errNoConsensusLoop
 }
 
 // StopConsensus will send a non-blocking signal to `stopConsensusChan` to
@@ -138,3 +265,24 @@ func (c *Chain) beginTrace() uint64 {
 
 	return correlateID
 }
+
+// serveConsensusStateRequests answers topics.GetConsensusState requests with
+// the loop.State of the consensus, until the chain context is canceled.
+func (c *Chain) serveConsensusStateRequests(reqChan <-chan rpcbus.Request) {
+	for {
+		select {
+		case r := <-reqChan:
+			resp := rpcbus.NewResponse(nil, errNoConsensusLoop)
+			if c.loop != nil {
+				resp = rpcbus.NewResponse(c.loop.State(), nil)
+			}
+
+			timeout := time.Duration(config.Get().Timeout.TimeoutSendResponse) * time.Millisecond
+			if err := r.Respond(resp, timeout); err != nil {
+				log.WithError(err).Warn("failed to send consensus state response")
+			}
+		case <-c.ctx.Done():
+			return
+		}
+	}
+}
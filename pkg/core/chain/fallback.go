@@ -9,26 +9,31 @@ package chain
 import (
 	"encoding/hex"
 	"errors"
-	"time"
 
+	"github.com/dusk-network/dusk-blockchain/pkg/config"
 	"github.com/dusk-network/dusk-blockchain/pkg/core/data/block"
 	"github.com/dusk-network/dusk-blockchain/pkg/core/data/ipc/transactions"
 	"github.com/dusk-network/dusk-blockchain/pkg/core/database"
+	"github.com/dusk-network/dusk-blockchain/pkg/p2p/wire/message"
 	"github.com/dusk-network/dusk-blockchain/pkg/p2p/wire/topics"
-	"github.com/dusk-network/dusk-blockchain/pkg/util/nativeutils/rpcbus"
+	"github.com/dusk-network/dusk-blockchain/pkg/util/diagnostics"
 	"github.com/sirupsen/logrus"
 )
 
+// errEquivocation is returned on a block certified at the same height and
+// iteration as the chain tip.
+var errEquivocation = errors.New("more the one winning block for the same iteration")
+
 // allowFallback performs major verification to allow or disallow a fallback procedure.
 func (c *Chain) allowFallback(b block.Block, l *logrus.Entry) error {
 	// Prioritize the lowest iteration
-	if b.Header.Certificate.Step > c.tip.Header.Certificate.Step {
+	if b.Header.Certificate.Step > c.tip().Header.Certificate.Step {
 		// We already know a winning block from lower consensus iteration.
 		return errors.New("lower cetificate step")
 	}
 
 	// Fetch Previous block
-	prevBlk, err := c.loader.BlockAt(c.tip.Header.Height - 1)
+	prevBlk, err := c.loader.BlockAt(c.tip().Header.Height - 1)
 	if err != nil {
 		return err
 	}
@@ -39,8 +44,8 @@ func (c *Chain) allowFallback(b block.Block, l *logrus.Entry) error {
 		return err
 	}
 
-	if b.Header.Certificate.Step == c.tip.Header.Certificate.Step {
-		return errors.New("more the one winning block for the same iteration")
+	if b.Header.Certificate.Step == c.tip().Header.Certificate.Step {
+		return errEquivocation
 	}
 
 	return nil
@@ -48,12 +53,12 @@ func (c *Chain) allowFallback(b block.Block, l *logrus.Entry) error {
 
 func (c *Chain) tryFallback(b block.Block) error {
 	var (
-		th        = c.tip.Header.Height
+		th        = c.tip().Header.Height
 		stateHash []byte
 		err       error
 
 		llog = log.WithField("curr_h", th).
-			WithField("curr_step", c.tip.Header.Certificate.Step).
+			WithField("curr_step", c.tip().Header.Certificate.Step).
 			WithField("recv_blk_step", b.Header.Certificate.Step).
 			WithField("event", "fallback")
 	)
@@ -61,6 +66,10 @@ func (c *Chain) tryFallback(b block.Block) error {
 	llog.Info("initialize procedure")
 
 	if err = c.allowFallback(b, llog); err != nil {
+		if errors.Is(err, errEquivocation) {
+			c.reportEquivocation(b, llog)
+		}
+
 		return err
 	}
 
@@ -97,21 +106,55 @@ func (c *Chain) tryFallback(b block.Block) error {
 	}
 
 	// revert blockchain from current tip to finalized block
-	err = c.revertBlockchain(c.tip, finalized, llog)
+	evicted, err := c.revertBlockchain(c.tip(), finalized, llog)
 	if err != nil {
 		return err
 	}
 
+	// Notify other subsystems (mempool.Mempool) about the txs evicted in
+	// favor of the new branch.
+	msg := message.New(topics.ChainReorg, message.ChainReorg{Evicted: evicted, Accepted: b.Txs})
+	errList := c.eventBus.Publish(topics.ChainReorg, msg)
+	diagnostics.LogPublishErrors("chain/fallback.go, topics.ChainReorg", errList)
+
 	llog.Info("completed")
 
 	return nil
 }
 
-func (c *Chain) revertBlockchain(from, to *block.Block, llog *logrus.Entry) error {
+// reportEquivocation handles a block certified at the same height and
+// iteration as the chain tip. The tip, seen first, is kept. The conflict is
+// logged, and notified over topics.EquivocationDetected if so configured.
+func (c *Chain) reportEquivocation(b block.Block, llog *logrus.Entry) {
+	llog.WithField("tip_hash", hex.EncodeToString(c.tip().Header.Hash)).
+		WithField("recv_blk_hash", hex.EncodeToString(b.Header.Hash)).
+		Warn("equivocation detected, keep first seen block")
+
+	if !config.Get().Chain.PublishEquivocation {
+		return
+	}
+
+	e := message.Equivocation{
+		Kept:     c.tip().Header.Copy(),
+		Rejected: b.Header.Copy(),
+	}
+
+	errList := c.eventBus.Publish(topics.EquivocationDetected, message.New(topics.EquivocationDetected, e))
+	diagnostics.LogPublishErrors("chain/fallback.go, topics.EquivocationDetected", errList)
+}
+
+// revertBlockchain deletes all blocks from the current tip down to the given
+// block. It returns the transactions of the deleted blocks.
+func (c *Chain) revertBlockchain(from, to *block.Block, llog *logrus.Entry) ([]transactions.ContractCall, error) {
 	llog.WithField("from", from.Header.Height).
 		WithField("to", to.Header.Height).
 		Info("revert blockchain")
 
+	var evicted []transactions.ContractCall
+
+	c.txHistory.mu.Lock()
+	defer c.txHistory.mu.Unlock()
+
 	err := c.db.Update(func(t database.Transaction) error {
 		// Delete all non-finalized blocks
 		for h := from.Header.Height; h >= to.Header.Height; h-- {
@@ -135,8 +178,7 @@ func (c *Chain) revertBlockchain(from, to *block.Block, llog *logrus.Entry) erro
 				Txs:    txs,
 			}
 
-			// resubmit txs back to mempool
-			go c.resubmitTxs(txs)
+			evicted = append(evicted, txs...)
 
 			if err := t.DeleteBlock(&b); err != nil {
 				return err
@@ -149,11 +191,16 @@ func (c *Chain) revertBlockchain(from, to *block.Block, llog *logrus.Entry) erro
 			panic(err)
 		}
 
-		c.tip = to
+		// Index the txs of the blocks replacing the deleted ones
+		if err := c.txHistory.rewind(t, to.Header.Height); err != nil {
+			return err
+		}
+
+		c.setTip(to)
 		return nil
 	})
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	// Restore provisioners set
@@ -165,17 +212,7 @@ func (c *Chain) revertBlockchain(from, to *block.Block, llog *logrus.Entry) erro
 
 	c.p = &provisioners
 
-	return nil
-}
-
-func (c *Chain) resubmitTxs(txs []transactions.ContractCall) {
-	// Find diff txs between consensus-split block and new block
-	for _, tx := range txs {
-		// transaction has not been accepted by new block then it should be resubmitted to mempool.
-		if _, err := c.rpcBus.Call(topics.SendMempoolTx, rpcbus.NewRequest(tx), 5*time.Second); err != nil {
-			log.WithError(err).Warn("could not resubmit txs")
-		}
-	}
+	return evicted, nil
 }
 
 // isBlockFromFork returns true if a valid block from a fork is detected by
@@ -186,7 +223,7 @@ func (c *Chain) resubmitTxs(txs []transactions.ContractCall) {
 // - block is valid to its predecessor fetched from local blockchain state.
 func (c *Chain) isBlockFromFork(b block.Block) (bool, error) {
 	var (
-		th = c.tip.Header.Height
+		th = c.tip().Header.Height
 		rh = b.Header.Height
 		pb *block.Block
 	)
@@ -228,10 +265,15 @@ func (c *Chain) isBlockFromFork(b block.Block) (bool, error) {
 		return false, err
 	}
 
-	// A weak assumption is made here that provisioners state has not changed
-	// since recvBlk.Header.Height
+	// Verify the certificate with the provisioners of that round. If they are
+	// out of the retention window, a weak assumption is made here that
+	// provisioners state has not changed since recvBlk.Header.Height
+	p, err := c.provisionersAt(rh)
+	if err != nil {
+		p = *c.p
+	}
 
-	err = c.isValidHeader(b, *pb, *c.p, log, true)
+	err = c.isValidHeader(b, *pb, p, log, true)
 	if err != nil {
 		return false, err
 	}
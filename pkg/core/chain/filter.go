@@ -0,0 +1,45 @@
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT License was not distributed with this
+// file, you can obtain one at https://opensource.org/licenses/MIT.
+//
+// Copyright (c) DUSK NETWORK. All rights reserved.
+
+package chain
+
+import (
+	"errors"
+
+	"github.com/dusk-network/dusk-blockchain/pkg/config"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/data/block"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/verifiers"
+)
+
+var (
+	errGenesisFromNetwork = errors.New("genesis block received from network")
+	errStaleBlock         = errors.New("block too far behind the tip")
+)
+
+// preFilterBlock performs cheap checks on a block received from the network,
+// before it contends for the chain lock. It drops blocks with an obviously
+// wrong height or an invalid header hash. It relies on the tip height being
+// read atomically, hence it must not be called with the chain lock held.
+func (c *Chain) preFilterBlock(blk *block.Block) error {
+	// The genesis block is never propagated over the network
+	if blk.Header.Height == 0 {
+		return errGenesisFromNetwork
+	}
+
+	// Blocks way below the tip can neither extend the chain nor trigger a
+	// fallback
+	maxAge := config.Get().Chain.MaxBlockAge
+	if maxAge == 0 {
+		maxAge = config.MaxInvBlocks
+	}
+
+	if blk.Header.Height+maxAge < c.tip().Header.Height {
+		return errStaleBlock
+	}
+
+	// Ensure the received block provides a valid hash
+	return verifiers.CheckHash(blk)
+}
@@ -17,6 +17,10 @@ type Ledger interface {
 	TryNextConsecutiveBlockOutSync(blk block.Block, metadata *message.Metadata) error
 	TryNextConsecutiveBlockIsValid(blk block.Block) error
 
+	// QueueVerification verifies ahead consecutive blocks about to be
+	// accepted while syncing.
+	QueueVerification(blks []block.Block)
+
 	// RestartConsensus Stop and Start Consensus.
 	// This is a safer approach to ensure we do not duplicate Consensus loop ever.
 	// It starts the consensus loop that deals with start-and-stop
@@ -29,4 +33,8 @@ type Ledger interface {
 	StopConsensus()
 
 	ProcessSyncTimerExpired(strPeerAddr string) error
+
+	// RequestBlockAtHeight asks a peer for up to count blocks from the given
+	// height.
+	RequestBlockAtHeight(peerAddr string, height, count uint64) error
 }
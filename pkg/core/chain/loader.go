@@ -10,7 +10,10 @@ import (
 	"bytes"
 	"errors"
 	"fmt"
+	"runtime"
+	"sync"
 
+	"github.com/dusk-network/dusk-blockchain/pkg/config"
 	"github.com/dusk-network/dusk-blockchain/pkg/core/data/block"
 	"github.com/dusk-network/dusk-blockchain/pkg/core/database"
 	"github.com/dusk-network/dusk-blockchain/pkg/core/verifiers"
@@ -22,6 +25,9 @@ const (
 	SanityCheckHeight uint64 = 10
 )
 
+// ErrNotTip is returned on the removal of a block which is not the chain tip.
+var ErrNotTip = errors.New("not the chain tip")
+
 // DBLoader performs database prefetching and sanityChecks at node startup.
 type DBLoader struct {
 	db database.DB
@@ -49,10 +55,15 @@ func (l *DBLoader) SanityCheckBlock(prevBlock block.Block, blk block.Block) erro
 		return err
 	}
 
-	if err := verifiers.CheckBlockHeader(prevBlock, blk); err != nil {
+	// A block not linked to prevBlock may still be valid on another branch
+	if err := verifiers.CheckBlockLinkage(prevBlock, blk); err != nil {
 		return err
 	}
 
+	if err := verifiers.CheckBlockHeader(prevBlock, blk); err != nil {
+		return verifiers.NewBlockError(blk, err)
+	}
+
 	return nil
 }
 
@@ -74,6 +85,32 @@ func (l *DBLoader) Height() (uint64, error) {
 	return height, err
 }
 
+// Sync flushes the stored blocks to durable storage.
+func (l *DBLoader) Sync() error {
+	return l.db.Sync()
+}
+
+// StoreHighestSeen persists the highest block height advertised by the
+// network.
+func (l *DBLoader) StoreHighestSeen(height uint64) error {
+	return l.db.Update(func(t database.Transaction) error {
+		return t.StoreHighestSeen(height)
+	})
+}
+
+// LoadHighestSeen returns the highest block height persisted, or zero.
+func (l *DBLoader) LoadHighestSeen() (uint64, error) {
+	var height uint64
+
+	err := l.db.View(func(t database.Transaction) error {
+		var err error
+		height, err = t.FetchHighestSeen()
+		return err
+	})
+
+	return height, err
+}
+
 // BlockAt returns the block stored at a given height.
 func (l *DBLoader) BlockAt(searchingHeight uint64) (block.Block, error) {
 	var blk *block.Block
@@ -94,10 +131,88 @@ func (l *DBLoader) BlockAt(searchingHeight uint64) (block.Block, error) {
 	return *blk, err
 }
 
-// Clear the underlying DB.
+// Iterate calls fn on each block between the heights from and to (both
+// included), within a single DB transaction. Blocks are visited in descending
+// order if from is greater than to. Iteration stops at the first error.
+func (l *DBLoader) Iterate(from, to uint64, fn func(block.Block) error) error {
+	return l.db.View(func(t database.Transaction) error {
+		height := from
+
+		for {
+			hash, err := t.FetchBlockHashByHeight(height)
+			if err != nil {
+				return err
+			}
+
+			blk, err := t.FetchBlock(hash)
+			if err != nil {
+				return err
+			}
+
+			if err := fn(*blk); err != nil {
+				return err
+			}
+
+			switch {
+			case height == to:
+				return nil
+			case from > to:
+				height--
+			default:
+				height++
+			}
+		}
+	})
+}
+
+// Remove deletes the block at the given height, which must be the tip of the
+// stored blockchain, and makes its predecessor the new tip. The genesis block
+// can not be removed.
+func (l *DBLoader) Remove(height uint64) error {
+	return l.db.Update(func(t database.Transaction) error {
+		tip, err := t.FetchCurrentHeight()
+		if err != nil {
+			return err
+		}
+
+		if height == 0 || height != tip {
+			return ErrNotTip
+		}
+
+		hash, err := t.FetchBlockHashByHeight(height)
+		if err != nil {
+			return err
+		}
+
+		blk, err := t.FetchBlock(hash)
+		if err != nil {
+			return err
+		}
+
+		prev, err := t.FetchBlock(blk.Header.PrevBlockHash)
+		if err != nil {
+			return err
+		}
+
+		if err := t.DeleteBlock(blk); err != nil {
+			return err
+		}
+
+		return t.StoreBlock(prev, false)
+	})
+}
+
+// Clear the underlying DB and re-seed it with the genesis block.
+// Both operations run within a single DB transaction, so a failure leaves
+// the DB untouched on drivers supporting rollback. Clear is also safe to
+// retry: a repeated call completes a previously failed one.
 func (l *DBLoader) Clear() error {
 	return l.db.Update(func(t database.Transaction) error {
-		return t.ClearDatabase()
+		if err := t.ClearDatabase(); err != nil {
+			return err
+		}
+
+		return t.StoreBlock(l.genesis, true)
 	})
 }
 
@@ -115,12 +230,102 @@ func (l *DBLoader) Close(driver string) error {
 
 // SanityCheckBlockchain checks the head and the tail of the blockchain to avoid
 // inconsistencies and a faulty bootstrap.
+//
+// Blocks up to Database.TrustedHeight are only checked for their linkage to
+// the previous block, as the local storage is trusted below that height.
+//
+// The range of blocks is split across a bounded pool of workers. Each worker
+// still checks every block against its actual predecessor, hence the chunks
+// overlap by one block.
 func (l *DBLoader) SanityCheckBlockchain(startAt, firstBlocksAmount uint64) error {
-	var height uint64
+	var tip uint64
 
-	// Verify first N blocks
 	err := l.db.View(func(t database.Transaction) error {
-		h, err := t.FetchBlockHashByHeight(startAt)
+		var err error
+		tip, err = t.FetchCurrentHeight()
+		return err
+	})
+	if err != nil {
+		return err
+	}
+
+	// Verify first N blocks, stopping at the tip
+	last := firstBlocksAmount
+	if last > tip {
+		last = tip
+	}
+
+	if last < startAt {
+		last = startAt
+	}
+
+	if err := l.checkRangeParallel(startAt, last, sanityCheckWorkers()); err != nil {
+		return err
+	}
+
+	// TODO: Verify last blocks
+	return nil
+}
+
+func sanityCheckWorkers() int {
+	if n := config.Get().Database.SanityCheckWorkers; n > 0 {
+		return n
+	}
+
+	return runtime.NumCPU()
+}
+
+// checkRangeParallel checks the blocks in (from, to] using up to `workers`
+// goroutines. It returns the first error encountered.
+func (l *DBLoader) checkRangeParallel(from, to uint64, workers int) error {
+	chunk := (to - from) / uint64(workers)
+	if (to-from)%uint64(workers) != 0 {
+		chunk++
+	}
+
+	if chunk == 0 {
+		return l.checkRange(from, to, nil)
+	}
+
+	var (
+		wg       sync.WaitGroup
+		once     sync.Once
+		firstErr error
+		quit     = make(chan struct{})
+	)
+
+	for lo := from; lo < to; lo += chunk {
+		hi := lo + chunk
+		if hi > to {
+			hi = to
+		}
+
+		wg.Add(1)
+
+		go func(lo, hi uint64) {
+			defer wg.Done()
+
+			if err := l.checkRange(lo, hi, quit); err != nil {
+				once.Do(func() {
+					firstErr = err
+					close(quit)
+				})
+			}
+		}(lo, hi)
+	}
+
+	wg.Wait()
+	return firstErr
+}
+
+// checkRange verifies that each block in (from, to] links to its predecessor.
+// Blocks above the trusted height also undergo the header checks.
+// It returns early, with no error, if quit is closed.
+func (l *DBLoader) checkRange(from, to uint64, quit <-chan struct{}) error {
+	trustedHeight := config.Get().Database.TrustedHeight
+
+	return l.db.View(func(t database.Transaction) error {
+		h, err := t.FetchBlockHashByHeight(from)
 		if err != nil {
 			return err
 		}
@@ -130,14 +335,14 @@ func (l *DBLoader) SanityCheckBlockchain(startAt, firstBlocksAmount uint64) erro
 			return err
 		}
 
-		for height = startAt + 1; height <= firstBlocksAmount; height++ {
-			hash, err := t.FetchBlockHashByHeight(height)
-
-			if err == database.ErrBlockNotFound {
-				// we reach the tip
+		for height := from + 1; height <= to; height++ {
+			select {
+			case <-quit:
 				return nil
+			default:
 			}
 
+			hash, err := t.FetchBlockHashByHeight(height)
 			if err != nil {
 				return err
 			}
@@ -151,17 +356,21 @@ func (l *DBLoader) SanityCheckBlockchain(startAt, firstBlocksAmount uint64) erro
 				return fmt.Errorf("invalid block hash at height %d", height)
 			}
 
+			if header.Height != prevHeader.Height+1 {
+				return fmt.Errorf("invalid block height at height %d", height)
+			}
+
+			if height > trustedHeight {
+				if err := verifiers.CheckBlockHeader(block.Block{Header: prevHeader}, block.Block{Header: header}); err != nil {
+					return fmt.Errorf("invalid block at height %d: %w", height, err)
+				}
+			}
+
 			prevHeader = header
 		}
 
 		return nil
 	})
-	if err != nil {
-		return err
-	}
-
-	// TODO: Verify last blocks
-	return nil
 }
 
 // LoadTip returns the tip of the chain.
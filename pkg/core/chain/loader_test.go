@@ -0,0 +1,346 @@
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT License was not distributed with this
+// file, you can obtain one at https://opensource.org/licenses/MIT.
+//
+// Copyright (c) DUSK NETWORK. All rights reserved.
+
+package chain
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/dusk-network/dusk-blockchain/pkg/config"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/data/block"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/database"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/database/heavy"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/database/lite"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/tests/helper"
+	assert "github.com/stretchr/testify/require"
+)
+
+var errInjected = errors.New("injected failure")
+
+// failingDB fails the next Update right after the DB has been cleared.
+type failingDB struct {
+	database.DB
+	fail bool
+}
+
+func (f *failingDB) Update(fn func(database.Transaction) error) error {
+	return f.DB.Update(func(t database.Transaction) error {
+		if !f.fail {
+			return fn(t)
+		}
+
+		f.fail = false
+		return fn(&failingTx{Transaction: t})
+	})
+}
+
+// failingTx fails on storing a block.
+type failingTx struct {
+	database.Transaction
+}
+
+func (f *failingTx) StoreBlock(*block.Block, bool) error {
+	return errInjected
+}
+
+func TestClearRecoversFromFailure(t *testing.T) {
+	assert := assert.New(t)
+
+	_, heavyDB := heavy.CreateDBConnection()
+	db := &failingDB{DB: heavyDB}
+	loader := createLoader(db)
+
+	// Build up a chain on top of genesis
+	genesisBlk, _, err := loader.LoadTip()
+	assert.NoError(err)
+
+	blk := helper.RandomBlock(1, 1)
+	assert.NoError(db.Update(func(t database.Transaction) error {
+		return t.StoreBlock(blk, false)
+	}))
+
+	// Fail in the middle of clearing
+	db.fail = true
+	assert.Equal(errInjected, loader.Clear())
+
+	// A subsequent Clear should complete the operation
+	assert.NoError(loader.Clear())
+
+	height, err := loader.Height()
+	assert.NoError(err)
+	assert.Equal(uint64(0), height)
+
+	tip, persistedHash, err := loader.LoadTip()
+	assert.NoError(err)
+	assert.True(bytes.Equal(genesisBlk.Header.Hash, tip.Header.Hash))
+	assert.True(bytes.Equal(genesisBlk.Header.Hash, persistedHash))
+
+	_, err = loader.BlockAt(1)
+	assert.Error(err)
+}
+
+// storeLinkedChain stores `n` blocks on top of the genesis block, linked via
+// their headers.
+func storeLinkedChain(t testing.TB, db database.DB, loader *DBLoader, n uint64) []*block.Block {
+	prev, _, err := loader.LoadTip()
+	assert.NoError(t, err)
+
+	blocks := []*block.Block{prev}
+
+	for height := uint64(1); height <= n; height++ {
+		blk := helper.RandomBlock(height, 0)
+		blk.Header.PrevBlockHash = prev.Header.Hash
+
+		blk.Header.Hash, err = blk.CalculateHash()
+		assert.NoError(t, err)
+
+		assert.NoError(t, db.Update(func(t database.Transaction) error {
+			return t.StoreBlock(blk, true)
+		}))
+
+		blocks = append(blocks, blk)
+		prev = blk
+	}
+
+	return blocks
+}
+
+func TestSanityCheckBlockchain(t *testing.T) {
+	assert := assert.New(t)
+
+	_, db := lite.CreateDBConnection()
+	loader := createLoader(db)
+	blocks := storeLinkedChain(t, db, loader, 50)
+
+	assert.NoError(loader.SanityCheckBlockchain(0, 100))
+
+	for _, workers := range []int{1, 3, 8, 64} {
+		assert.NoError(loader.checkRangeParallel(0, 50, workers))
+	}
+
+	// A corrupt block is caught wherever it lies in the checked range,
+	// regardless of the chunk boundaries
+	for _, height := range []uint64{1, 17, 25, 26, 50} {
+		blk := blocks[height]
+		prevHash := blk.Header.PrevBlockHash
+		blk.Header.PrevBlockHash = make([]byte, 32)
+
+		assert.NoError(db.Update(func(t database.Transaction) error {
+			return t.StoreBlock(blk, true)
+		}))
+
+		for _, workers := range []int{1, 2, 3, 8, 64} {
+			err := loader.checkRangeParallel(0, 50, workers)
+			assert.EqualError(err, fmt.Sprintf("invalid block hash at height %d", height))
+		}
+
+		blk.Header.PrevBlockHash = prevHash
+
+		assert.NoError(db.Update(func(t database.Transaction) error {
+			return t.StoreBlock(blk, true)
+		}))
+	}
+}
+
+func TestSanityCheckTrustedHeight(t *testing.T) {
+	assert := assert.New(t)
+
+	orig := config.Get()
+	defer config.Mock(&orig)
+
+	_, db := lite.CreateDBConnection()
+	loader := createLoader(db)
+	blocks := storeLinkedChain(t, db, loader, 20)
+
+	// Corrupt a block without breaking the linkage
+	blk := blocks[5]
+	blk.Header.Version = 1
+
+	assert.NoError(db.Update(func(t database.Transaction) error {
+		return t.StoreBlock(blk, true)
+	}))
+
+	r := config.Registry{}
+	config.Mock(&r)
+
+	assert.EqualError(loader.SanityCheckBlockchain(0, 20), "invalid block at height 5: unsupported block version")
+
+	// Full verification is skipped below the trusted height
+	r.Database.TrustedHeight = 10
+	config.Mock(&r)
+
+	assert.NoError(loader.SanityCheckBlockchain(0, 20))
+
+	// The linkage is still checked
+	blk = blocks[3]
+	blk.Header.PrevBlockHash = make([]byte, 32)
+
+	assert.NoError(db.Update(func(t database.Transaction) error {
+		return t.StoreBlock(blk, true)
+	}))
+
+	assert.EqualError(loader.SanityCheckBlockchain(0, 20), "invalid block hash at height 3")
+}
+
+func BenchmarkSanityCheckBlockchain(b *testing.B) {
+	_, db := lite.CreateDBConnection()
+	loader := createLoader(db)
+	storeLinkedChain(b, db, loader, 1000)
+
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		if err := loader.SanityCheckBlockchain(0, 1000); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func TestGetRecentBlocks(t *testing.T) {
+	assert := assert.New(t)
+
+	_, db := lite.CreateDBConnection()
+	loader := createLoader(db)
+	blocks := storeLinkedChain(t, db, loader, 20)
+
+	c := &Chain{loader: loader, chainState: chainState{tip: blocks[20]}}
+
+	orig := config.Get()
+	defer config.Mock(&orig)
+
+	r := config.Registry{}
+	r.Database.MaxRecentBlocks = 8
+	config.Mock(&r)
+
+	// Blocks are returned newest first, ending at the tip
+	recent, err := c.GetRecentBlocks(5)
+	assert.NoError(err)
+	assert.Len(recent, 5)
+
+	for i, blk := range recent {
+		assert.Equal(blocks[20-i].Header.Hash, blk.Header.Hash)
+	}
+
+	// The count is capped at the configured max
+	recent, err = c.GetRecentBlocks(15)
+	assert.NoError(err)
+	assert.Len(recent, 8)
+	assert.Equal(blocks[13].Header.Hash, recent[7].Header.Hash)
+
+	// The count is capped at the chain length, genesis included
+	r.Database.MaxRecentBlocks = 100
+	recent, err = c.GetRecentBlocks(50)
+	assert.NoError(err)
+	assert.Len(recent, 21)
+	assert.Equal(blocks[0].Header.Hash, recent[20].Header.Hash)
+
+	recent, err = c.GetRecentBlocks(0)
+	assert.NoError(err)
+	assert.Empty(recent)
+}
+
+func TestChainHash(t *testing.T) {
+	assert := assert.New(t)
+
+	_, liteDB := lite.CreateDBConnection()
+	_, heavyDB := heavy.CreateDBConnection()
+	_, otherDB := lite.CreateDBConnection()
+
+	// The genesis block is stored by the loader, with no chain hash
+	genesisBlk, _, err := createLoader(liteDB).LoadTip()
+	assert.NoError(err)
+
+	blocks := []*block.Block{genesisBlk}
+
+	for height := uint64(1); height <= 5; height++ {
+		blk := helper.RandomBlock(height, 0)
+		blk.Header.PrevBlockHash = blocks[height-1].Header.Hash
+
+		blk.Header.Hash, _ = blk.CalculateHash()
+		blocks = append(blocks, blk)
+	}
+
+	// Blocks are stored the way they are persisted when accepted
+	store := func(db database.DB, blocks []*block.Block) {
+		for _, blk := range blocks {
+			assert.NoError(db.Update(func(t database.Transaction) error {
+				if err := t.StoreBlock(blk, true); err != nil {
+					return err
+				}
+
+				return storeChainHash(t, blk)
+			}))
+		}
+	}
+
+	chainHash := func(db database.DB, height uint64) []byte {
+		var h []byte
+
+		assert.NoError(db.View(func(t database.Transaction) error {
+			var err error
+			h, err = t.FetchChainHash(height)
+			return err
+		}))
+
+		return h
+	}
+
+	// The genesis chain hash is backfilled at startup
+	assert.NoError(backfillChainHashes(liteDB, 0))
+
+	store(liteDB, blocks[1:])
+	store(heavyDB, blocks)
+
+	// The other chain differs from height 3
+	forked := make([]*block.Block, len(blocks))
+	copy(forked, blocks)
+	forked[3] = helper.RandomBlock(3, 0)
+
+	store(otherDB, forked)
+
+	for height := uint64(0); height <= 5; height++ {
+		assert.Equal(chainHash(liteDB, height), chainHash(heavyDB, height))
+
+		if height < 3 {
+			assert.Equal(chainHash(liteDB, height), chainHash(otherDB, height))
+		} else {
+			assert.NotEqual(chainHash(liteDB, height), chainHash(otherDB, height))
+		}
+	}
+
+	// The chain hashes of blocks stored without them are backfilled from
+	// the blocks, from the highest one stored
+	_, legacyDB := lite.CreateDBConnection()
+
+	for _, blk := range blocks {
+		assert.NoError(legacyDB.Update(func(t database.Transaction) error {
+			return t.StoreBlock(blk, true)
+		}))
+	}
+
+	assert.NoError(legacyDB.Update(func(t database.Transaction) error {
+		return storeChainHash(t, blocks[0])
+	}))
+
+	assert.NoError(backfillChainHashes(legacyDB, 5))
+
+	for height := uint64(0); height <= 5; height++ {
+		assert.Equal(chainHash(liteDB, height), chainHash(legacyDB, height))
+	}
+
+	// Accepting a block only extends the chain hash of its predecessor
+	assert.Error(otherDB.Update(func(t database.Transaction) error {
+		blk := helper.RandomBlock(7, 0)
+		if err := t.StoreBlock(blk, true); err != nil {
+			return err
+		}
+
+		return storeChainHash(t, blk)
+	}))
+}
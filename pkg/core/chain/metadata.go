@@ -0,0 +1,41 @@
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT License was not distributed with this
+// file, you can obtain one at https://opensource.org/licenses/MIT.
+//
+// Copyright (c) DUSK NETWORK. All rights reserved.
+
+package chain
+
+import (
+	"bytes"
+
+	"github.com/dusk-network/dusk-blockchain/pkg/core/data/block"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/database"
+	"github.com/dusk-network/dusk-blockchain/pkg/p2p/wire/message"
+)
+
+// newBlockMetadata derives the metadata of an accepted block. As for the
+// GraphQL block fees, the txs which can not be decoded pay no fee.
+func newBlockMetadata(b *block.Block) (database.BlockMetadata, error) {
+	// The block is encoded as it is stored and sent on the wire
+	buf := new(bytes.Buffer)
+	if err := message.MarshalBlock(buf, b); err != nil {
+		return database.BlockMetadata{}, err
+	}
+
+	var fees uint64
+
+	for _, tx := range b.Txs {
+		if gasPrice, err := tx.Fee(); err == nil {
+			fees += tx.GasSpent() * gasPrice
+		}
+	}
+
+	return database.BlockMetadata{
+		// MarshalBlock caps the tx count well below MaxUint32
+		TxCount:        uint32(len(b.Txs)),
+		TotalFees:      fees,
+		CoinbaseAmount: block.Emission(b.Header.Height) + fees,
+		Size:           uint64(buf.Len()),
+	}, nil
+}
@@ -0,0 +1,62 @@
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT License was not distributed with this
+// file, you can obtain one at https://opensource.org/licenses/MIT.
+//
+// Copyright (c) DUSK NETWORK. All rights reserved.
+
+package chain
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// Names of the block acceptance phases reported to the MetricsSink.
+const (
+	PhaseSanityCheck     = "sanity_check"
+	PhaseGetProvisioners = "get_provisioners"
+	PhaseStateTransition = "state_transition"
+	PhasePersist         = "persist"
+)
+
+// MetricsSink receives the measurements of the block acceptance, so that
+// they can be exported to a monitoring system.
+type MetricsSink interface {
+	// ObserveDuration records the duration of a block acceptance phase.
+	ObserveDuration(phase string, d time.Duration)
+	// CountBlock records the outcome of a block acceptance.
+	CountBlock(accepted bool)
+}
+
+// noopMetrics is the MetricsSink used unless one is set.
+type noopMetrics struct{}
+
+func (noopMetrics) ObserveDuration(string, time.Duration) {}
+
+func (noopMetrics) CountBlock(bool) {}
+
+// SetMetrics sets the sink of the block acceptance measurements. A nil sink
+// disables them. It must be called before any block is accepted.
+func (c *Chain) SetMetrics(m MetricsSink) {
+	if m == nil {
+		m = noopMetrics{}
+	}
+
+	c.metrics = m
+}
+
+// observe records the duration of a phase started at start.
+func (c *Chain) observe(phase string, start time.Time) {
+	c.metrics.ObserveDuration(phase, time.Since(start))
+}
+
+// countBlock records the outcome of a block acceptance. An acceptance
+// aborted by the chain closing is not counted.
+func (c *Chain) countBlock(err error) {
+	if errors.Is(err, context.Canceled) {
+		return
+	}
+
+	c.metrics.CountBlock(err == nil)
+}
@@ -25,13 +25,14 @@ func (v *MockVerifier) SanityCheckBlock(prevBlock block.Block, blk block.Block)
 
 // MockLoader is the mock of the DB loader to help testing the chain.
 type MockLoader struct {
-	blockchain []block.Block
+	blockchain  []block.Block
+	highestSeen uint64
 }
 
 // NewMockLoader creates a Mockup of the Loader interface.
 func NewMockLoader() Loader {
 	mockchain := make([]block.Block, 0)
-	return &MockLoader{mockchain}
+	return &MockLoader{blockchain: mockchain}
 }
 
 // Height returns the height currently known by the Loader.
@@ -65,6 +66,51 @@ func (m *MockLoader) Append(blk *block.Block) error {
 	return nil
 }
 
+// Iterate calls fn on each block of the internal blockchain representation
+// between two heights.
+func (m *MockLoader) Iterate(from, to uint64, fn func(block.Block) error) error {
+	for i := from; ; {
+		if err := fn(m.blockchain[i]); err != nil {
+			return err
+		}
+
+		switch {
+		case i == to:
+			return nil
+		case from > to:
+			i--
+		default:
+			i++
+		}
+	}
+}
+
+// Sync is a no-op, the mock being in-memory.
+func (m *MockLoader) Sync() error {
+	return nil
+}
+
+// StoreHighestSeen keeps the highest height seen in memory.
+func (m *MockLoader) StoreHighestSeen(height uint64) error {
+	m.highestSeen = height
+	return nil
+}
+
+// LoadHighestSeen returns the highest height seen kept in memory.
+func (m *MockLoader) LoadHighestSeen() (uint64, error) {
+	return m.highestSeen, nil
+}
+
+// Remove the last block of the internal blockchain representation.
+func (m *MockLoader) Remove(height uint64) error {
+	if len(m.blockchain) == 0 || height != uint64(len(m.blockchain)-1) {
+		return ErrNotTip
+	}
+
+	m.blockchain = m.blockchain[:height]
+	return nil
+}
+
 // BlockAt the block to the internal blockchain representation.
 func (m *MockLoader) BlockAt(index uint64) (block.Block, error) {
 	return m.blockchain[index], nil
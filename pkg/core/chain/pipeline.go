@@ -0,0 +1,127 @@
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT License was not distributed with this
+// file, you can obtain one at https://opensource.org/licenses/MIT.
+//
+// Copyright (c) DUSK NETWORK. All rights reserved.
+
+package chain
+
+import (
+	"bytes"
+	"context"
+	"sync"
+
+	"github.com/dusk-network/dusk-blockchain/pkg/core/data/block"
+)
+
+// verifyJob is a block to verify against the block preceding it.
+type verifyJob struct {
+	prev block.Block
+	blk  block.Block
+	res  *verifyResult
+}
+
+// verifyResult is the outcome of a verifyJob. err is set once done is closed.
+type verifyResult struct {
+	height   uint64
+	prevHash []byte
+	err      error
+	done     chan struct{}
+}
+
+// verifyPipeline runs the sanity check of the blocks queued while syncing on
+// a bounded pool of goroutines, so that the acceptance of a block only has to
+// verify its certificate and run the state transition. The certificate is
+// not verified ahead, as the provisioners it is checked against are updated
+// by the state transition of the preceding block.
+type verifyPipeline struct {
+	check func(prev, blk block.Block) error
+	queue chan verifyJob
+
+	lock    sync.Mutex
+	results map[string]*verifyResult
+}
+
+// newVerifyPipeline returns a pipeline checking up to size queued blocks with
+// check.
+func newVerifyPipeline(check func(prev, blk block.Block) error, size int) *verifyPipeline {
+	return &verifyPipeline{
+		check:   check,
+		queue:   make(chan verifyJob, size),
+		results: make(map[string]*verifyResult),
+	}
+}
+
+// run verifies the queued blocks until ctx is canceled.
+func (p *verifyPipeline) run(ctx context.Context) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case job := <-p.queue:
+			p.verify(job)
+		}
+	}
+}
+
+func (p *verifyPipeline) verify(job verifyJob) {
+	job.res.err = p.check(job.prev, job.blk)
+	close(job.res.done)
+}
+
+// submit queues blk for verification against prev. The block is not queued
+// if the pipeline is full, or if it is already queued against prev.
+func (p *verifyPipeline) submit(prev, blk block.Block) {
+	key := string(blk.Header.Hash)
+
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	if res, ok := p.results[key]; ok && bytes.Equal(res.prevHash, prev.Header.Hash) {
+		return
+	}
+
+	res := &verifyResult{
+		height:   blk.Header.Height,
+		prevHash: prev.Header.Hash,
+		done:     make(chan struct{}),
+	}
+
+	select {
+	case p.queue <- verifyJob{prev: prev, blk: blk, res: res}:
+		p.results[key] = res
+	default:
+	}
+}
+
+// take returns the outcome of the verification of blk against prev, waiting
+// for it if needed. It reports false if blk was not verified against prev,
+// in which case the caller has to verify it. Outcomes of blocks at or below
+// the height of prev are discarded.
+func (p *verifyPipeline) take(ctx context.Context, prev, blk block.Block) (bool, error) {
+	p.lock.Lock()
+
+	for key, res := range p.results {
+		if res.height <= prev.Header.Height {
+			delete(p.results, key)
+		}
+	}
+
+	res, ok := p.results[string(blk.Header.Hash)]
+	if ok {
+		delete(p.results, string(blk.Header.Hash))
+	}
+
+	p.lock.Unlock()
+
+	if !ok || !bytes.Equal(res.prevHash, prev.Header.Hash) {
+		return false, nil
+	}
+
+	select {
+	case <-res.done:
+		return true, res.err
+	case <-ctx.Done():
+		return false, nil
+	}
+}
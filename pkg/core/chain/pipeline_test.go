@@ -0,0 +1,169 @@
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT License was not distributed with this
+// file, you can obtain one at https://opensource.org/licenses/MIT.
+//
+// Copyright (c) DUSK NETWORK. All rights reserved.
+
+package chain
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/dusk-network/dusk-blockchain/pkg/config"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus/key"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus/user"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/data/block"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/data/ipc/transactions"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/tests/helper"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/verifiers"
+	assert "github.com/stretchr/testify/require"
+)
+
+// setupPipelineTest returns a chain verifying the block headers against the
+// DB, with `workers` goroutines verifying ahead the blocks queued while
+// syncing.
+func setupPipelineTest(tb testing.TB, workers int) (*Chain, []key.Keys, *user.Provisioners) {
+	orig := config.Get()
+	defer config.Mock(&orig)
+
+	r := config.Get()
+	r.Chain.VerifyWorkers = workers
+	config.Mock(&r)
+
+	_, c := setupChainTest(tb, 0)
+	c.verifier = createLoader(c.db)
+
+	p, keys := consensus.MockProvisioners(10)
+	c.proxy.(*transactions.MockProxy).E.(*transactions.PermissiveExecutor).P = p
+	c.p = p
+
+	return c, keys, p
+}
+
+// syncRun delivers the blocks to the synchronizer in reverse order, so that
+// they are accepted in a single batch once the first one arrives.
+func syncRun(c *Chain, blks []*block.Block) error {
+	for i := len(blks) - 1; i >= 0; i-- {
+		if _, err := c.synchronizer.processBlock("peer", c.tip().Header.Height, *blks[i], nil); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func linkedBlocks(tip *block.Block, n int, keys []key.Keys, p *user.Provisioners) []*block.Block {
+	blks := make([]*block.Block, n)
+
+	prev := tip
+	for i := range blks {
+		blks[i] = certifiedBlock(prev, 3, keys, p)
+		prev = blks[i]
+	}
+
+	return blks
+}
+
+func TestVerifyPipeline(t *testing.T) {
+	assert := assert.New(t)
+	c, keys, p := setupPipelineTest(t, 4)
+
+	m := new(recordingMetrics)
+	c.SetMetrics(m)
+
+	assert.NoError(syncRun(c, linkedBlocks(c.tip(), 5, keys, p)))
+	assert.Equal(uint64(5), c.tip().Header.Height)
+	assert.Equal(5, m.accepted)
+
+	// Only the first block is checked by the sync, the others are accepted
+	// as verified by the pipeline
+	var checks int
+
+	for _, phase := range m.phases {
+		if phase == PhaseSanityCheck {
+			checks++
+		}
+	}
+
+	assert.Equal(1, checks)
+}
+
+func TestVerifyPipelineRejectsBadBlock(t *testing.T) {
+	assert := assert.New(t)
+	c, keys, p := setupPipelineTest(t, 4)
+
+	m := new(recordingMetrics)
+	c.SetMetrics(m)
+
+	// Block 3 does not match its hash
+	blks := linkedBlocks(c.tip(), 5, keys, p)
+	blks[2].Header.Timestamp++
+
+	assert.ErrorIs(syncRun(c, blks), verifiers.ErrInvalidBlockHash)
+	assert.Equal(uint64(2), c.tip().Header.Height)
+	assert.Equal(2, m.accepted)
+	assert.Equal(1, m.rejected)
+
+	// A block passing the verification ahead is still rejected if its
+	// certificate is not valid
+	blks = linkedBlocks(c.tip(), 2, keys, p)
+	blks[0].Header.Certificate = blks[1].Header.Certificate
+
+	assert.ErrorIs(syncRun(c, blks), errInvalidCertificate)
+	assert.Equal(uint64(2), c.tip().Header.Height)
+}
+
+func TestVerifyPipelineTip(t *testing.T) {
+	assert := assert.New(t)
+
+	var calls int
+
+	pl := newVerifyPipeline(func(prev, blk block.Block) error {
+		calls++
+		return verifiers.CheckBlockLinkage(prev, blk)
+	}, 1)
+
+	tip := helper.RandomBlock(1, 1)
+	blk := helper.RandomBlock(2, 1)
+	blk.Header.PrevBlockHash = tip.Header.Hash
+
+	pl.submit(*tip, *blk)
+
+	// The pipeline is full
+	pl.submit(*blk, *helper.RandomBlock(3, 1))
+	assert.Len(pl.results, 1)
+
+	pl.verify(<-pl.queue)
+	assert.Equal(1, calls)
+
+	// A block verified against another tip has to be verified again
+	checked, err := pl.take(context.Background(), *helper.RandomBlock(1, 1), *blk)
+	assert.False(checked)
+	assert.NoError(err)
+	assert.Empty(pl.results)
+}
+
+func BenchmarkSyncVerifyWorkers(b *testing.B) {
+	for _, workers := range []int{0, 4} {
+		b.Run(fmt.Sprintf("workers=%d", workers), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				b.StopTimer()
+
+				c, keys, p := setupPipelineTest(b, workers)
+				blks := linkedBlocks(c.tip(), 100, keys, p)
+
+				b.StartTimer()
+
+				if err := syncRun(c, blks); err != nil {
+					b.Fatal(err)
+				}
+
+				b.StopTimer()
+				c.Close()
+			}
+		})
+	}
+}
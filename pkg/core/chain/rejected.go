@@ -0,0 +1,292 @@
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT License was not distributed with this
+// file, you can obtain one at https://opensource.org/licenses/MIT.
+//
+// Copyright (c) DUSK NETWORK. All rights reserved.
+
+package chain
+
+import (
+	"bytes"
+	"errors"
+	"math"
+	"sync"
+	"time"
+
+	"github.com/dusk-network/dusk-blockchain/pkg/config"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/verifiers"
+	"github.com/dusk-network/dusk-blockchain/pkg/p2p/peer/dupemap"
+	"github.com/dusk-network/dusk-blockchain/pkg/p2p/wire/message"
+	"github.com/dusk-network/dusk-blockchain/pkg/p2p/wire/topics"
+	"github.com/dusk-network/dusk-blockchain/pkg/util/diagnostics"
+	"github.com/dusk-network/dusk-blockchain/pkg/util/nativeutils/eventbus"
+)
+
+const (
+	// rejectedCapacity is the maximum number of rejected blocks cached.
+	rejectedCapacity = 1000
+	// rejectedExpiry is the number of seconds before the cache is reset.
+	rejectedExpiry = 120
+	// rejectedHalfLife is the time it takes for a misbehavior score to be
+	// halved.
+	rejectedHalfLife = 2 * time.Minute
+)
+
+// misbehavior is the reason a block delivered by a peer is rejected.
+type misbehavior uint8
+
+const (
+	misbehaviorInvalidBlock misbehavior = iota
+	misbehaviorInvalidCertificate
+	misbehaviorFutureTimestamp
+	misbehaviorEquivocation
+)
+
+var misbehaviorNames = [...]string{"invalid_block", "invalid_certificate", "future_timestamp", "equivocation"}
+
+func (m misbehavior) String() string {
+	return misbehaviorNames[m]
+}
+
+// classifyMisbehavior returns the misbehavior a block rejection error stands
+// for.
+func classifyMisbehavior(err error) misbehavior {
+	switch {
+	case errors.Is(err, errInvalidCertificate):
+		return misbehaviorInvalidCertificate
+	case errors.Is(err, verifiers.ErrFutureTimestamp):
+		return misbehaviorFutureTimestamp
+	case errors.Is(err, errEquivocation):
+		return misbehaviorEquivocation
+	default:
+		return misbehaviorInvalidBlock
+	}
+}
+
+// weight returns the score of a misbehavior, as configured in
+// Network.Misbehavior. A zero weight leaves the misbehavior unscored.
+func (m misbehavior) weight() uint32 {
+	cfg := config.Get().Network.Misbehavior
+
+	switch m {
+	case misbehaviorInvalidCertificate:
+		return cfg.InvalidCertificate
+	case misbehaviorFutureTimestamp:
+		return cfg.FutureTimestamp
+	case misbehaviorEquivocation:
+		return cfg.Equivocation
+	default:
+		return cfg.InvalidBlock
+	}
+}
+
+// peerScore is the misbehavior score of a peer. It is halved every
+// rejectedHalfLife.
+type peerScore struct {
+	value uint32
+
+	// point in time the score was last halved.
+	decayed time.Time
+}
+
+// decay halves the score once per half-life elapsed since it was last halved.
+func (s *peerScore) decay(now time.Time) {
+	periods := now.Sub(s.decayed) / rejectedHalfLife
+	if periods <= 0 {
+		return
+	}
+
+	if periods >= 32 {
+		s.value = 0
+	} else {
+		s.value >>= uint(periods)
+	}
+
+	s.decayed = s.decayed.Add(periods * rejectedHalfLife)
+}
+
+// banEnd returns the point in time the score decays below banScore.
+func (s *peerScore) banEnd(banScore uint32) time.Time {
+	end := s.decayed
+
+	for v := s.value; v >= banScore; v >>= 1 {
+		end = end.Add(rejectedHalfLife)
+	}
+
+	return end
+}
+
+// rejectedBlocks is a negative cache of the blocks which could not be
+// accepted, keyed by the peer which delivered them. It allows to cheaply drop
+// the re-delivery of a known-bad block from the same peer, and keeps track of
+// the misbehavior of each peer. A peer whose score reaches the ban score is
+// notified over topics.PeerBanned, so that the p2p layer can drop it.
+type rejectedBlocks struct {
+	lock   sync.Mutex
+	cache  *dupemap.TmpMap
+	scores map[string]*peerScore
+
+	publisher eventbus.Publisher
+
+	// now returns the current time. Replaced in tests.
+	now func() time.Time
+}
+
+func newRejectedBlocks(publisher eventbus.Publisher) *rejectedBlocks {
+	return &rejectedBlocks{
+		cache:     dupemap.NewTmpMap(rejectedCapacity, rejectedExpiry),
+		scores:    make(map[string]*peerScore),
+		publisher: publisher,
+		now:       time.Now,
+	}
+}
+
+// scoreLocked returns the decayed score of a peer, and forgets the peers
+// whose score decayed to zero.
+func (r *rejectedBlocks) scoreLocked(peerID string) uint32 {
+	s, ok := r.scores[peerID]
+	if !ok {
+		return 0
+	}
+
+	s.decay(r.now())
+
+	if s.value == 0 {
+		delete(r.scores, peerID)
+	}
+
+	return s.value
+}
+
+// deterministic returns true if a block rejection only depends on the block
+// and its predecessor. The certificate and the state hash are verified against
+// the node view of the provisioners and of the contract state, which may be
+// stale.
+func deterministic(err error) bool {
+	return !errors.Is(err, errInvalidCertificate) && !errors.Is(err, errInvalidStateHash)
+}
+
+// add a block hash to the cache of the given peer, and penalize the peer for
+// the misbehavior the rejection error stands for. The block is only cached if
+// its rejection is deterministic.
+func (r *rejectedBlocks) add(peerID string, hash []byte, err error) {
+	r.lock.Lock()
+
+	r.cache.CleanExpired()
+
+	if deterministic(err) {
+		r.cache.Add(rejectedKey(peerID, hash))
+	}
+
+	ban := r.penalizeLocked(peerID, classifyMisbehavior(err))
+	r.lock.Unlock()
+
+	r.publish(ban)
+}
+
+// penalize increases the misbehavior score of a peer by the weight of m.
+func (r *rejectedBlocks) penalize(peerID string, m misbehavior) {
+	r.lock.Lock()
+	ban := r.penalizeLocked(peerID, m)
+	r.lock.Unlock()
+
+	r.publish(ban)
+}
+
+// penalizeLocked increases the score of a peer, and returns the ban to
+// publish if the score crosses the ban score.
+func (r *rejectedBlocks) penalizeLocked(peerID string, m misbehavior) *message.PeerBanned {
+	w := m.weight()
+	if w == 0 {
+		return nil
+	}
+
+	prev := r.scoreLocked(peerID)
+
+	s, ok := r.scores[peerID]
+	if !ok {
+		s = &peerScore{decayed: r.now()}
+		r.scores[peerID] = s
+	}
+
+	s.value = prev + w
+	if s.value < prev {
+		s.value = math.MaxUint32
+	}
+
+	banScore := config.Get().Network.Misbehavior.BanScore
+	if banScore == 0 || prev >= banScore || s.value < banScore {
+		return nil
+	}
+
+	return &message.PeerBanned{
+		Address:  peerID,
+		Reason:   m.String(),
+		Score:    s.value,
+		Duration: s.banEnd(banScore).Sub(r.now()),
+	}
+}
+
+// publish notifies a ban over topics.PeerBanned.
+func (r *rejectedBlocks) publish(ban *message.PeerBanned) {
+	if ban == nil {
+		return
+	}
+
+	log.WithField("r_addr", ban.Address).
+		WithField("misbehavior", ban.Reason).
+		WithField("score", ban.Score).
+		WithField("duration", ban.Duration).
+		Warn("peer banned")
+
+	errList := r.publisher.Publish(topics.PeerBanned, message.New(topics.PeerBanned, *ban))
+	diagnostics.LogPublishErrors("chain/rejected.go, topics.PeerBanned", errList)
+}
+
+// banned returns true if the misbehavior score of a peer reached
+// Network.Misbehavior.BanScore.
+func (r *rejectedBlocks) banned(peerID string) bool {
+	banScore := config.Get().Network.Misbehavior.BanScore
+	if banScore == 0 {
+		return false
+	}
+
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	return r.scoreLocked(peerID) >= banScore
+}
+
+// has returns true if the block hash has been recently rejected when
+// delivered by the given peer. A positive lookup penalizes the peer.
+func (r *rejectedBlocks) has(peerID string, hash []byte) bool {
+	r.lock.Lock()
+
+	r.cache.CleanExpired()
+
+	if !r.cache.Has(rejectedKey(peerID, hash)) {
+		r.lock.Unlock()
+		return false
+	}
+
+	ban := r.penalizeLocked(peerID, misbehaviorInvalidBlock)
+	r.lock.Unlock()
+
+	r.publish(ban)
+	return true
+}
+
+// score returns the misbehavior score of a peer.
+func (r *rejectedBlocks) score(peerID string) uint32 {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	return r.scoreLocked(peerID)
+}
+
+func rejectedKey(peerID string, hash []byte) *bytes.Buffer {
+	buf := bytes.NewBufferString(peerID)
+	_, _ = buf.Write(hash)
+
+	return buf
+}
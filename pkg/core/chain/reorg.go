@@ -0,0 +1,226 @@
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT License was not distributed with this
+// file, you can obtain one at https://opensource.org/licenses/MIT.
+//
+// Copyright (c) DUSK NETWORK. All rights reserved.
+
+package chain
+
+import (
+	"bytes"
+	"context"
+	"errors"
+
+	"github.com/dusk-network/dusk-blockchain/pkg/core/data/block"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/data/ipc/transactions"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/database"
+	"github.com/dusk-network/dusk-blockchain/pkg/p2p/wire/message"
+	"github.com/dusk-network/dusk-blockchain/pkg/p2p/wire/topics"
+	"github.com/dusk-network/dusk-blockchain/pkg/util"
+	"github.com/dusk-network/dusk-blockchain/pkg/util/diagnostics"
+	"github.com/sirupsen/logrus"
+)
+
+var (
+	// errReorgOutOfRange is returned on a block which does not replace any
+	// block of the chain.
+	errReorgOutOfRange = errors.New("block does not replace a chain block")
+	// errNoCommonAncestor is returned on a block whose parent is not part of
+	// the chain.
+	errNoCommonAncestor = errors.New("no common ancestor")
+	// errLighterBranch is returned on a block certified at a higher
+	// iteration than the one it would replace.
+	errLighterBranch = errors.New("higher certificate step")
+	// errReorgFinalized is returned on a block which would replace a
+	// finalized one.
+	errReorgFinalized = errors.New("cannot replace a finalized block")
+)
+
+// finalizedStep is the certificate step of a block agreed on the first
+// iteration. Such a block is finalized by Rusk.
+const finalizedStep = 3
+
+// ReorganizeTo replaces the chain blocks from the height of blk onwards with
+// blk, if blk is certified at a lower iteration than the block it replaces.
+// The parent of blk is the common ancestor of both branches.
+//
+// The contract state is reverted to the most recent finalized block, and the
+// blocks up to the common ancestor are executed again before blk. A finalized
+// block is never replaced. ctx allows to abort the reorganization before any
+// change is made.
+func (c *Chain) ReorganizeTo(ctx context.Context, blk block.Block) error {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	return c.reorganizeTo(ctx, blk)
+}
+
+// reorganizeTo implements ReorganizeTo. The caller holds the chain lock.
+func (c *Chain) reorganizeTo(ctx context.Context, blk block.Block) error {
+	l := log.WithField("event", "reorg").
+		WithField("curr_h", c.tip().Header.Height).
+		WithField("recv_blk_h", blk.Header.Height).
+		WithField("recv_blk_step", blk.Header.Certificate.Step)
+
+	ancestor, err := c.checkReorg(blk, l)
+	if err != nil {
+		return err
+	}
+
+	if err = ctx.Err(); err != nil {
+		return err
+	}
+
+	l.Info("initialize procedure")
+
+	evicted, err := c.unwindTo(ancestor, l)
+	if err != nil {
+		return err
+	}
+
+	var evictedTxs []transactions.ContractCall
+	for _, b := range evicted {
+		evictedTxs = append(evictedTxs, b.Txs...)
+	}
+
+	if err = c.acceptBlock(blk, true); err != nil {
+		l.WithError(err).Error("new branch rejected, restore the previous one")
+		c.restoreBranch(evicted, l)
+
+		return err
+	}
+
+	// The replaced block is filtered out if propagated back while syncing.
+	c.blacklisted.Add(bytes.NewBuffer(evicted[0].Header.Hash))
+
+	msg := message.New(topics.ChainReorg, message.ChainReorg{Evicted: evictedTxs, Accepted: blk.Txs})
+	errList := c.eventBus.Publish(topics.ChainReorg, msg)
+	diagnostics.LogPublishErrors("chain/reorg.go, topics.ChainReorg", errList)
+
+	if err = c.RestartConsensus(); err != nil {
+		l.WithError(err).Warn("could not restart consensus loop")
+	}
+
+	l.WithField("hash", util.StringifyBytes(blk.Header.Hash)).Info("completed")
+	return nil
+}
+
+// checkReorg ensures blk may replace the chain blocks from its height
+// onwards. It returns the common ancestor of both branches.
+func (c *Chain) checkReorg(blk block.Block, l *logrus.Entry) (block.Block, error) {
+	height := blk.Header.Height
+	tip := c.tip()
+
+	if height == 0 || height > tip.Header.Height {
+		return block.Block{}, errReorgOutOfRange
+	}
+
+	ancestor, err := c.loader.BlockAt(height - 1)
+	if err != nil {
+		return block.Block{}, err
+	}
+
+	if !bytes.Equal(ancestor.Header.Hash, blk.Header.PrevBlockHash) {
+		return block.Block{}, errNoCommonAncestor
+	}
+
+	replaced, err := c.loader.BlockAt(height)
+	if err != nil {
+		return block.Block{}, err
+	}
+
+	switch {
+	case bytes.Equal(replaced.Header.Hash, blk.Header.Hash):
+		return block.Block{}, ErrBlockAlreadyAccepted
+	case blk.Header.Certificate.Step > replaced.Header.Certificate.Step:
+		return block.Block{}, errLighterBranch
+	case blk.Header.Certificate.Step == replaced.Header.Certificate.Step:
+		return block.Block{}, errEquivocation
+	}
+
+	// Rusk can only revert the blocks above the most recent finalized one.
+	if err = c.loader.Iterate(height, tip.Header.Height, func(b block.Block) error {
+		if b.Header.Certificate.Step == finalizedStep {
+			return errReorgFinalized
+		}
+
+		return nil
+	}); err != nil {
+		return block.Block{}, err
+	}
+
+	// Verify the certificate with the provisioners of that round, as done
+	// for the blocks from a fork.
+	p, err := c.provisionersAt(height)
+	if err != nil {
+		p = *c.p
+	}
+
+	if err = c.isValidHeader(blk, ancestor, p, l, true); err != nil {
+		return block.Block{}, err
+	}
+
+	return ancestor, nil
+}
+
+// unwindTo reverts the contract state to the most recent finalized block, and
+// the blockchain along with it, in a single database transaction. The blocks
+// from the finalized one to the ancestor are then executed again. It returns
+// the blocks above the ancestor, in ascending order.
+func (c *Chain) unwindTo(ancestor block.Block, l *logrus.Entry) ([]block.Block, error) {
+	stateHash, err := c.proxy.Executor().Revert(c.ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = c.proxy.Executor().Persist(c.ctx, stateHash); err != nil {
+		return nil, err
+	}
+
+	var finalized *block.Block
+
+	if err = c.db.View(func(t database.Transaction) error {
+		var e error
+		finalized, e = t.FetchBlockByStateRoot(ancestor.Header.Height, stateHash)
+		return e
+	}); err != nil {
+		return nil, err
+	}
+
+	// The blocks reverted along with the contract state
+	tip := c.tip()
+	reverted := make([]block.Block, 0, tip.Header.Height-finalized.Header.Height)
+
+	if err = c.loader.Iterate(finalized.Header.Height+1, tip.Header.Height, func(b block.Block) error {
+		reverted = append(reverted, b)
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	if _, err = c.revertBlockchain(tip, finalized, l); err != nil {
+		return nil, err
+	}
+
+	common := reverted[:ancestor.Header.Height-finalized.Header.Height]
+
+	// Execute again the blocks of the common branch, not finalized yet.
+	for _, b := range common {
+		if err = c.acceptTrustedBlock(b); err != nil {
+			return nil, err
+		}
+	}
+
+	return reverted[len(common):], nil
+}
+
+// restoreBranch accepts again the blocks removed by a failed reorganization.
+func (c *Chain) restoreBranch(blocks []block.Block, l *logrus.Entry) {
+	for _, b := range blocks {
+		if err := c.acceptTrustedBlock(b); err != nil {
+			l.WithError(err).WithField("height", b.Header.Height).
+				Error("could not restore block")
+			return
+		}
+	}
+}
@@ -0,0 +1,187 @@
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT License was not distributed with this
+// file, you can obtain one at https://opensource.org/licenses/MIT.
+//
+// Copyright (c) DUSK NETWORK. All rights reserved.
+
+package chain
+
+import (
+	"context"
+	"testing"
+
+	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus/key"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus/user"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/data/block"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/data/ipc/transactions"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/database"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/tests/helper"
+	"github.com/dusk-network/dusk-blockchain/pkg/p2p/wire/message"
+	"github.com/dusk-network/dusk-blockchain/pkg/p2p/wire/topics"
+	"github.com/dusk-network/dusk-blockchain/pkg/util/nativeutils/eventbus"
+	assert "github.com/stretchr/testify/require"
+)
+
+// certifiedBlock returns a block following prev, certified at the given step.
+func certifiedBlock(prev *block.Block, step uint8, keys []key.Keys, p *user.Provisioners) *block.Block {
+	blk := helper.RandomBlock(prev.Header.Height+1, 1)
+	blk.Header.PrevBlockHash = prev.Header.Hash
+	blk.Header.Seed = []byte{0, 0, 0, 0}
+	blk.Header.StateHash = make([]byte, 32)
+	blk.Header.Hash, _ = blk.CalculateHash()
+	blk.Header.Certificate = message.MockAgreement(blk.Header.Hash, blk.Header.Height, step, keys, p).GenerateCertificate()
+
+	return blk
+}
+
+func setupReorgTest(t *testing.T) (*eventbus.EventBus, *Chain, []key.Keys, *user.Provisioners) {
+	eb, c := setupChainTest(t, 0)
+
+	p, keys := consensus.MockProvisioners(10)
+
+	proxy := c.proxy.(*transactions.MockProxy)
+	proxy.E.(*transactions.PermissiveExecutor).P = p
+	// Reverting restores the state hash of the blocks built by certifiedBlock
+	proxy.E = stubExecutor{
+		PermissiveExecutor: proxy.E.(*transactions.PermissiveExecutor),
+		revert: func(context.Context) ([]byte, error) {
+			return make([]byte, 32), nil
+		},
+	}
+	c.p = p
+
+	return eb, c, keys, p
+}
+
+func TestReorganizeTo(t *testing.T) {
+	assert := assert.New(t)
+	eb, c, keys, p := setupReorgTest(t)
+
+	reorgs := make(chan message.Message, 1)
+	eb.Subscribe(topics.ChainReorg, eventbus.NewChanListener(reorgs))
+
+	// Block 1 is finalized, blocks 2 and 3 are agreed on the second iteration
+	b1 := certifiedBlock(c.tip(), 3, keys, p)
+	assert.NoError(c.acceptBlock(*b1, true))
+
+	b2 := certifiedBlock(b1, 6, keys, p)
+	assert.NoError(c.acceptBlock(*b2, true))
+
+	b3 := certifiedBlock(b2, 6, keys, p)
+	assert.NoError(c.acceptBlock(*b3, true))
+
+	// A competing block 2, agreed on the first iteration, wins
+	fork := certifiedBlock(b1, 3, keys, p)
+	assert.NoError(c.ReorganizeTo(context.Background(), *fork))
+
+	assert.Equal(fork.Header.Hash, c.tip().Header.Hash)
+
+	stored, err := c.loader.BlockAt(2)
+	assert.NoError(err)
+	assert.Equal(fork.Header.Hash, stored.Header.Hash)
+
+	_, err = c.loader.BlockAt(3)
+	assert.Error(err)
+
+	height, err := c.loader.Height()
+	assert.NoError(err)
+	assert.Equal(uint64(2), height)
+
+	// The txs of the replaced blocks are evicted
+	reorg := (<-reorgs).Payload().(message.ChainReorg)
+	assert.Len(reorg.Evicted, len(b2.Txs)+len(b3.Txs))
+	assert.Equal(fork.Txs, reorg.Accepted)
+}
+
+func TestReorganizeFromNetwork(t *testing.T) {
+	assert := assert.New(t)
+	_, c, keys, p := setupReorgTest(t)
+
+	b1 := certifiedBlock(c.tip(), 3, keys, p)
+	assert.NoError(c.acceptBlock(*b1, true))
+
+	b2 := certifiedBlock(b1, 6, keys, p)
+	assert.NoError(c.acceptBlock(*b2, true))
+
+	b3 := certifiedBlock(b2, 6, keys, p)
+	assert.NoError(c.acceptBlock(*b3, true))
+
+	// A block of a fork, agreed on an earlier iteration, replaces the chain
+	// blocks from its height
+	fork := certifiedBlock(b1, 3, keys, p)
+
+	_, err := c.ProcessBlockFromNetwork("peer", message.New(topics.Block, *fork))
+	assert.NoError(err)
+	assert.Equal(fork.Header.Hash, c.tip().Header.Hash)
+
+	// The replaced blocks are deleted in a single transaction, along with
+	// their txs
+	_, err = c.loader.BlockAt(3)
+	assert.Error(err)
+
+	for _, tx := range b2.Txs {
+		txID, _ := tx.CalculateHash()
+
+		assert.NoError(c.db.View(func(t database.Transaction) error {
+			_, _, _, err := t.FetchBlockTxByHash(txID)
+			assert.ErrorIs(err, database.ErrTxNotFound)
+			return nil
+		}))
+	}
+}
+
+func TestReorganizeToRejected(t *testing.T) {
+	assert := assert.New(t)
+	_, c, keys, p := setupReorgTest(t)
+
+	b1 := certifiedBlock(c.tip(), 3, keys, p)
+	assert.NoError(c.acceptBlock(*b1, true))
+
+	b2 := certifiedBlock(b1, 6, keys, p)
+	assert.NoError(c.acceptBlock(*b2, true))
+
+	// A block agreed on a later iteration does not replace the chain block
+	assert.ErrorIs(c.ReorganizeTo(context.Background(), *certifiedBlock(b1, 9, keys, p)), errLighterBranch)
+	assert.ErrorIs(c.ReorganizeTo(context.Background(), *certifiedBlock(b1, 6, keys, p)), errEquivocation)
+
+	// Nor does a block out of the chain
+	assert.ErrorIs(c.ReorganizeTo(context.Background(), *certifiedBlock(b2, 3, keys, p)), errReorgOutOfRange)
+	assert.ErrorIs(c.ReorganizeTo(context.Background(), *certifiedBlock(helper.RandomBlock(1, 1), 3, keys, p)), errNoCommonAncestor)
+
+	// Nothing is changed once canceled
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	assert.ErrorIs(c.ReorganizeTo(ctx, *certifiedBlock(b1, 3, keys, p)), context.Canceled)
+	assert.Equal(b2.Header.Hash, c.tip().Header.Hash)
+
+	// A finalized block is never replaced
+	b3 := certifiedBlock(b2, 3, keys, p)
+	assert.NoError(c.acceptBlock(*b3, true))
+
+	assert.ErrorIs(c.ReorganizeTo(context.Background(), *certifiedBlock(b1, 3, keys, p)), errReorgFinalized)
+	assert.Equal(b3.Header.Hash, c.tip().Header.Hash)
+}
+
+func TestLoaderRemove(t *testing.T) {
+	assert := assert.New(t)
+	_, c := setupChainTest(t, 0)
+
+	genesis := c.tip()
+
+	blk := helper.RandomBlock(1, 1)
+	blk.Header.PrevBlockHash = genesis.Header.Hash
+	blk.Header.Hash, _ = blk.CalculateHash()
+	assert.NoError(c.acceptBlock(*blk, true))
+
+	// Only the tip can be removed, and never the genesis block
+	assert.ErrorIs(c.loader.Remove(0), ErrNotTip)
+	assert.ErrorIs(c.loader.Remove(2), ErrNotTip)
+	assert.NoError(c.loader.Remove(1))
+	assert.ErrorIs(c.loader.Remove(0), ErrNotTip)
+
+	tip, _, err := c.loader.LoadTip()
+	assert.NoError(err)
+	assert.Equal(genesis.Header.Hash, tip.Header.Hash)
+}
@@ -0,0 +1,92 @@
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT License was not distributed with this
+// file, you can obtain one at https://opensource.org/licenses/MIT.
+//
+// Copyright (c) DUSK NETWORK. All rights reserved.
+
+package chain
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/dusk-network/dusk-blockchain/pkg/core/verifiers"
+)
+
+var errReplayGenesis = errors.New("genesis block can not be replayed")
+
+// ReplayError reports the first block which failed a replay verification.
+type ReplayError struct {
+	Height uint64
+	Err    error
+}
+
+func (e *ReplayError) Error() string {
+	return fmt.Sprintf("replay failed at height %d: %v", e.Height, e.Err)
+}
+
+// Unwrap returns the verification error.
+func (e *ReplayError) Unwrap() error {
+	return e.Err
+}
+
+// ReplayVerify verifies the stored blocks between the heights from and to
+// (both included), without altering the chain state. Each block header is
+// checked against its predecessor, its certificate against the provisioners
+// of its round, and the gas spent by its transactions against its limit.
+//
+// The transactions are not executed again, as Rusk only exposes its current
+// state. The first failing block is reported as a *ReplayError.
+func (c *Chain) ReplayVerify(ctx context.Context, from, to uint64) error {
+	if from == 0 {
+		return errReplayGenesis
+	}
+
+	c.lock.RLock()
+	current := c.p.Copy()
+	c.lock.RUnlock()
+
+	l := log.WithField("event", "replay").
+		WithField("from", from).
+		WithField("to", to)
+
+	prevBlk, err := c.loader.BlockAt(from - 1)
+	if err != nil {
+		return &ReplayError{Height: from - 1, Err: err}
+	}
+
+	for h := from; h <= to; h++ {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		default:
+		}
+
+		blk, err := c.loader.BlockAt(h)
+		if err != nil {
+			return &ReplayError{Height: h, Err: err}
+		}
+
+		if err = verifiers.CheckBlockHeader(prevBlk, blk); err != nil {
+			return &ReplayError{Height: h, Err: err}
+		}
+
+		// Outside of the retention window, the current provisioners are
+		// assumed, as on fork detection.
+		p, err := c.provisionersAt(h)
+		if err != nil {
+			p = current
+		}
+
+		if err = c.isValidHeader(blk, prevBlk, p, l.WithField("height", h), false); err != nil {
+			return &ReplayError{Height: h, Err: err}
+		}
+
+		prevBlk = blk
+	}
+
+	l.Info("replay completed")
+
+	return nil
+}
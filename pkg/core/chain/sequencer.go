@@ -11,6 +11,7 @@ import (
 	"errors"
 	"sync"
 
+	"github.com/dusk-network/dusk-blockchain/pkg/config"
 	"github.com/dusk-network/dusk-blockchain/pkg/core/data/block"
 	"github.com/sirupsen/logrus"
 )
@@ -23,16 +24,68 @@ import (
 type sequencer struct {
 	lock      sync.RWMutex
 	blockPool map[uint64]block.Block
+
+	// arrival order of the pooled blocks, by height, to evict the oldest one
+	// once the pool is full.
+	arrivals map[uint64]uint64
+	counter  uint64
 }
 
 func newSequencer() *sequencer {
-	return &sequencer{blockPool: make(map[uint64]block.Block)}
+	return &sequencer{
+		blockPool: make(map[uint64]block.Block),
+		arrivals:  make(map[uint64]uint64),
+	}
 }
 
+// add pools a block until its predecessors are accepted. If the pool is full,
+// the block received first is evicted.
 func (s *sequencer) add(blk block.Block) {
+	maxBlocks := maxFutureBlocks()
+
 	s.lock.Lock()
 	defer s.lock.Unlock()
-	s.blockPool[blk.Header.Height] = blk
+
+	height := blk.Header.Height
+	if _, ok := s.blockPool[height]; !ok && len(s.blockPool) >= maxBlocks {
+		s.evictOldest()
+	}
+
+	s.counter++
+	s.blockPool[height] = blk
+	s.arrivals[height] = s.counter
+}
+
+// evictOldest removes the block received first. It must be called with the
+// lock held.
+func (s *sequencer) evictOldest() {
+	var (
+		oldest  uint64
+		evicted uint64
+		found   bool
+	)
+
+	for height, arrival := range s.arrivals {
+		if !found || arrival < oldest {
+			oldest, evicted, found = arrival, height, true
+		}
+	}
+
+	if found {
+		log.WithField("height", evicted).Debug("sequencer full, evict block")
+		delete(s.blockPool, evicted)
+		delete(s.arrivals, evicted)
+	}
+}
+
+// maxFutureBlocks returns the capacity of the sequencer.
+func maxFutureBlocks() int {
+	n := config.Get().Chain.MaxFutureBlocks
+	if n <= 0 {
+		return config.DefaultMaxFutureBlocks
+	}
+
+	return n
 }
 
 func (s *sequencer) get(height uint64) (block.Block, error) {
@@ -51,6 +104,33 @@ func (s *sequencer) remove(height uint64) {
 	s.lock.Lock()
 	defer s.lock.Unlock()
 	delete(s.blockPool, height)
+	delete(s.arrivals, height)
+}
+
+// len returns the number of blocks waiting in the sequencer.
+func (s *sequencer) len() int {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	return len(s.blockPool)
+}
+
+// next returns the lowest height of the blocks pooled above the given height.
+func (s *sequencer) next(height uint64) (uint64, bool) {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	var (
+		lowest uint64
+		found  bool
+	)
+
+	for h := range s.blockPool {
+		if h > height && (!found || h < lowest) {
+			lowest, found = h, true
+		}
+	}
+
+	return lowest, found
 }
 
 // cleanup removes all blocks that are lower than currentHeight.
@@ -61,6 +141,7 @@ func (s *sequencer) cleanup(currentHeight uint64) {
 	for height := range s.blockPool {
 		if height < currentHeight {
 			delete(s.blockPool, height)
+			delete(s.arrivals, height)
 		}
 	}
 }
@@ -10,6 +10,7 @@ import (
 	"sync"
 	"testing"
 
+	"github.com/dusk-network/dusk-blockchain/pkg/config"
 	"github.com/dusk-network/dusk-blockchain/pkg/core/data/block"
 	"github.com/dusk-network/dusk-blockchain/pkg/core/tests/helper"
 	assert "github.com/stretchr/testify/require"
@@ -58,6 +59,38 @@ func TestSequencer(t *testing.T) {
 	}
 }
 
+func TestSequencerEviction(t *testing.T) {
+	assert := assert.New(t)
+
+	orig := config.Get()
+	defer config.Mock(&orig)
+
+	r := config.Registry{}
+	r.Chain.MaxFutureBlocks = 3
+	config.Mock(&r)
+
+	seq := newSequencer()
+
+	// Blocks are received out of order
+	for _, height := range []uint64{5, 3, 4} {
+		seq.add(*helper.RandomBlock(height, 1))
+	}
+
+	// Replacing a pooled block does not evict any other
+	seq.add(*helper.RandomBlock(3, 1))
+	assert.Equal(3, seq.len())
+
+	// Once full, the block received first is evicted
+	seq.add(*helper.RandomBlock(2, 1))
+	assert.Equal(3, seq.len())
+	assert.NotContains(seq.blockPool, uint64(5))
+
+	seq.add(*helper.RandomBlock(6, 1))
+	assert.Equal(3, seq.len())
+	assert.NotContains(seq.blockPool, uint64(4))
+	assert.Contains(seq.blockPool, uint64(3))
+}
+
 func TestSequencerConcurrency(t *testing.T) {
 	seq := newSequencer()
 
@@ -0,0 +1,133 @@
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT License was not distributed with this
+// file, you can obtain one at https://opensource.org/licenses/MIT.
+//
+// Copyright (c) DUSK NETWORK. All rights reserved.
+
+package chain
+
+import (
+	"sync"
+
+	"github.com/dusk-network/dusk-blockchain/pkg/config"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/data/block"
+)
+
+// chainState holds the state of the chain read by handlers running outside
+// of the block processing (gRPC, GraphQL, message filters). All fields are
+// protected by a single lock, so that readers get a consistent snapshot.
+//
+// The tip is replaced, never mutated in place, hence a snapshot can share it.
+type chainState struct {
+	lock sync.RWMutex
+
+	// current blockchain tip of local state.
+	tip *block.Block
+
+	// height of the highest block considered final. With certificate-based
+	// consensus, this is the tip height.
+	finalizedHeight uint64
+
+	// highest block height advertised by the network.
+	highestSeen uint64
+
+	// progress is the last sync progress reported while syncing.
+	progress float64
+}
+
+// stateSnapshot is a consistent copy of the chainState.
+type stateSnapshot struct {
+	tip             *block.Block
+	finalizedHeight uint64
+	highestSeen     uint64
+}
+
+// snapshot returns a consistent copy of the state.
+func (s *chainState) snapshot() stateSnapshot {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	return stateSnapshot{
+		tip:             s.tip,
+		finalizedHeight: s.finalizedHeight,
+		highestSeen:     s.highestSeen,
+	}
+}
+
+// setTip updates the chain tip. An accepted block is final, hence the
+// finalized height follows the tip.
+func (s *chainState) setTip(b *block.Block) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	s.tip = b
+	s.finalizedHeight = b.Header.Height
+}
+
+// observeHeight records the height of a block received from the network. The
+// height is clamped to Chain.SyncProgressMaxLead blocks ahead of the tip, so
+// that a single far ahead (or bogus) block does not stall the sync progress.
+// It reports whether the highest height seen is raised.
+func (s *chainState) observeHeight(height uint64) bool {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if lead := config.Get().Chain.SyncProgressMaxLead; lead > 0 && s.tip != nil && height > s.tip.Header.Height+lead {
+		height = s.tip.Header.Height + lead
+	}
+
+	if height > s.highestSeen {
+		s.highestSeen = height
+		return true
+	}
+
+	return false
+}
+
+// restoreHighestSeen sets the highest height seen, clamped to the tip height.
+func (s *chainState) restoreHighestSeen(height uint64) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if s.tip != nil && height < s.tip.Header.Height {
+		height = s.tip.Header.Height
+	}
+
+	s.highestSeen = height
+}
+
+// lastCertificate returns a copy of the certificate of the tip, or an empty
+// certificate if the tip has none.
+func (s stateSnapshot) lastCertificate() *block.Certificate {
+	if s.tip.Header.Certificate == nil {
+		return block.EmptyCertificate()
+	}
+
+	return s.tip.Header.Certificate.Copy()
+}
+
+// syncProgress returns how close the tip is to the highest block seen, as a
+// percentage value. While syncing, the progress does not regress: a higher
+// block seen in the meantime only slows it down. The next sync starts from
+// the actual progress once the tip has caught up.
+func (s *chainState) syncProgress() float64 {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if s.highestSeen == 0 {
+		return 0.0
+	}
+
+	progressPercentage := (float64(s.tip.Header.Height) / float64(s.highestSeen)) * 100
+	if progressPercentage >= 100 {
+		s.progress = 0
+		return 100
+	}
+
+	if progressPercentage < s.progress {
+		return s.progress
+	}
+
+	s.progress = progressPercentage
+	return progressPercentage
+}
@@ -0,0 +1,160 @@
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT License was not distributed with this
+// file, you can obtain one at https://opensource.org/licenses/MIT.
+//
+// Copyright (c) DUSK NETWORK. All rights reserved.
+
+package chain
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/dusk-network/dusk-blockchain/pkg/config"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/data/block"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/tests/helper"
+	assert "github.com/stretchr/testify/require"
+)
+
+// Test that the chain state can be read while being updated, and that
+// snapshots are consistent. Meant to run with -race.
+func TestChainStateConcurrentAccess(t *testing.T) {
+	const updates = 200
+
+	c := &Chain{}
+	c.setTip(helper.RandomBlock(0, 0))
+
+	tips := make([]*block.Block, updates+1)
+	for h := range tips {
+		tips[h] = helper.RandomBlock(uint64(h), 0)
+		tips[h].Header.Certificate.Step = uint8(h)
+	}
+
+	var wg sync.WaitGroup
+
+	wg.Add(2)
+
+	// Block processing
+	go func() {
+		defer wg.Done()
+
+		for h := 1; h <= updates; h++ {
+			c.setTip(tips[h])
+		}
+	}()
+
+	// Blocks received from the network
+	go func() {
+		defer wg.Done()
+
+		for h := 1; h <= 2*updates; h++ {
+			c.chainState.observeHeight(uint64(h))
+		}
+	}()
+
+	// Off-loop handlers
+	errs := make(chan string, 4*updates)
+
+	for r := 0; r < 4; r++ {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			for i := 0; i < updates; i++ {
+				s := c.chainState.snapshot()
+				if s.finalizedHeight != s.tip.Header.Height {
+					errs <- "finalized height does not follow the tip"
+				}
+
+				if cert := s.lastCertificate(); uint64(cert.Step) != s.tip.Header.Height%256 {
+					errs <- "certificate does not belong to the tip"
+				}
+
+				if p := c.CalculateSyncProgress(); p < 0 || p > 100 {
+					errs <- "sync progress out of range"
+				}
+
+				_ = c.FinalizedHeight()
+				_ = c.LastCertificate()
+			}
+		}()
+	}
+
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		assert.Fail(t, err)
+	}
+
+	s := c.chainState.snapshot()
+	assert.Equal(t, uint64(updates), s.tip.Header.Height)
+	assert.Equal(t, uint64(updates), s.finalizedHeight)
+	assert.Equal(t, uint64(2*updates), s.highestSeen)
+	// The progress does not regress from the highest value reported while
+	// the tip and the heights seen were updated concurrently
+	assert.GreaterOrEqual(t, c.chainState.syncProgress(), float64(50))
+}
+
+// Test that the sync progress does not regress while syncing, even if the
+// heights seen from the network are noisy.
+func TestSyncProgressNoisyHighestSeen(t *testing.T) {
+	assert := assert.New(t)
+
+	orig := config.Get()
+	defer config.Mock(&orig)
+
+	r := config.Get()
+	r.Chain.SyncProgressMaxLead = 100
+	config.Mock(&r)
+
+	const target = 1000
+
+	var s chainState
+
+	s.setTip(helper.RandomBlock(0, 0))
+
+	// A bogus far ahead block is clamped to the max lead
+	s.observeHeight(1_000_000)
+	assert.Equal(uint64(100), s.snapshot().highestSeen)
+
+	var last float64
+
+	for tip := uint64(0); tip <= target; tip += 10 {
+		s.setTip(helper.RandomBlock(tip, 0))
+
+		// The actual target, a lagging peer, and a bogus one
+		for _, h := range []uint64{target, tip / 2, tip + 1_000_000} {
+			s.observeHeight(h)
+
+			p := s.syncProgress()
+			assert.GreaterOrEqual(p, last, "progress regressed at tip %d, seen %d", tip, h)
+			last = p
+		}
+	}
+
+	// The bogus peer still leads the tip by the max lead
+	assert.Equal(uint64(target+100), s.snapshot().highestSeen)
+	assert.InDelta(float64(target)/float64(target+100)*100, last, 1)
+
+	// Without a max lead, a bogus block stalls the progress instead of
+	// resetting it
+	r.Chain.SyncProgressMaxLead = 0
+	config.Mock(&r)
+
+	s = chainState{}
+	s.setTip(helper.RandomBlock(target, 0))
+	s.observeHeight(2 * target)
+	assert.Equal(float64(50), s.syncProgress())
+
+	s.observeHeight(1_000_000)
+	assert.Equal(float64(50), s.syncProgress())
+
+	// Once synced, the next sync starts over
+	s.setTip(helper.RandomBlock(1_000_000, 0))
+	assert.Equal(float64(100), s.syncProgress())
+
+	s.observeHeight(4_000_000)
+	assert.Equal(float64(25), s.syncProgress())
+}
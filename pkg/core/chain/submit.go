@@ -0,0 +1,70 @@
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT License was not distributed with this
+// file, you can obtain one at https://opensource.org/licenses/MIT.
+//
+// Copyright (c) DUSK NETWORK. All rights reserved.
+
+package chain
+
+import (
+	"bytes"
+	"errors"
+	"time"
+
+	"github.com/dusk-network/dusk-blockchain/pkg/config"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/data/block"
+	"github.com/dusk-network/dusk-blockchain/pkg/p2p/wire/message"
+	"github.com/dusk-network/dusk-blockchain/pkg/util"
+	"github.com/dusk-network/dusk-blockchain/pkg/util/nativeutils/rpcbus"
+)
+
+// SubmitBlock accepts a block constructed outside of the node (e.g. by a
+// replay tool). The block undergoes the same verification as a block
+// received from the network while in sync, and is propagated once accepted.
+// Returns the verification error if the block is rejected.
+func (c *Chain) SubmitBlock(blk block.Block) error {
+	if err := c.preFilterBlock(&blk); err != nil {
+		return err
+	}
+
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	log.WithField("height", blk.Header.Height).
+		WithField("hash", util.StringifyBytes(blk.Header.Hash)).
+		Info("block submitted")
+
+	return c.TryNextConsecutiveBlockInSync(blk, nil)
+}
+
+// serveSubmitBlockRequests answers topics.SubmitBlock requests until the
+// chain context is canceled. The request params hold the marshaled block.
+func (c *Chain) serveSubmitBlockRequests(reqChan <-chan rpcbus.Request) {
+	for {
+		select {
+		case r := <-reqChan:
+			err := c.processSubmitBlockRequest(r)
+
+			timeout := time.Duration(config.Get().Timeout.TimeoutSendResponse) * time.Millisecond
+			if err := r.Respond(rpcbus.NewResponse(nil, err), timeout); err != nil {
+				log.WithError(err).Warn("failed to send submit block response")
+			}
+		case <-c.ctx.Done():
+			return
+		}
+	}
+}
+
+func (c *Chain) processSubmitBlockRequest(r rpcbus.Request) error {
+	params, ok := r.Params.(bytes.Buffer)
+	if !ok {
+		return errors.New("invalid block")
+	}
+
+	blk := block.NewBlock()
+	if err := message.UnmarshalBlock(&params, blk); err != nil {
+		return err
+	}
+
+	return c.SubmitBlock(*blk)
+}
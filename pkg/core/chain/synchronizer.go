@@ -14,6 +14,7 @@ import (
 	"github.com/dusk-network/dusk-blockchain/pkg/config"
 	"github.com/dusk-network/dusk-blockchain/pkg/core/data/block"
 	"github.com/dusk-network/dusk-blockchain/pkg/core/database"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/verifiers"
 	"github.com/dusk-network/dusk-blockchain/pkg/p2p/wire/message"
 	"github.com/dusk-network/dusk-blockchain/pkg/p2p/wire/topics"
 	"github.com/sirupsen/logrus"
@@ -22,6 +23,15 @@ import (
 const (
 	syncTimeout      = time.Duration(5) * time.Second
 	changeStatelabel = "change state"
+
+	// missingBlockTimeout is the time a gap in the sequencer may last before
+	// the blocks filling it are requested, as the blocks of a batch may be
+	// received out of order. It is below syncTimeout, so that the request is
+	// made before the sync is given up.
+	missingBlockTimeout = time.Second
+	// missingBlockRequests is the maximum number of requests of the blocks
+	// filling a gap.
+	missingBlockRequests = 3
 )
 
 var slog = logrus.WithField("process", "sync")
@@ -70,6 +80,8 @@ func (s *synchronizer) outSync(srcPeerAddr string, currentHeight uint64, blk blo
 		blk.Header.Height == s.hrange.from+1 {
 		// This validation should happen only once to ensure we can trust
 		// this peer for syncing up
+		s.setSyncPhase(SyncVerifying)
+
 		if err = s.chain.TryNextConsecutiveBlockIsValid(blk); err != nil {
 			if srcPeerAddr == s.timer.ownerID {
 				// Syncing Peer has provided invalid next block
@@ -77,6 +89,7 @@ func (s *synchronizer) outSync(srcPeerAddr string, currentHeight uint64, blk blo
 				slog.WithField("state", "insync").Debug(changeStatelabel)
 
 				s.state = s.inSync
+				s.setSyncPhase(SyncIdle)
 			}
 
 			return nil, err
@@ -93,14 +106,22 @@ func (s *synchronizer) outSync(srcPeerAddr string, currentHeight uint64, blk blo
 
 		blk, err = s.sequencer.get(currentHeight + 1)
 		if err != nil {
+			s.requestMissingBlocks(srcPeerAddr, currentHeight+1)
 			return nil, nil
 		}
 	}
 
+	s.missing = missingRequest{}
+
 	// Retrieve all successive blocks that need to be accepted
 	blks := s.sequencer.provideSuccessors(blk)
+	s.chain.QueueVerification(blks)
 
 	for _, blk := range blks {
+		currentHeight = blk.Header.Height
+
+		s.setSyncPhase(SyncDownloading)
+
 		// append them all to the ledger
 		if err = s.chain.TryNextConsecutiveBlockOutSync(blk, metadata); err != nil {
 			slog.WithError(err).WithField("state", "outsync").
@@ -129,10 +150,21 @@ func (s *synchronizer) outSync(srcPeerAddr string, currentHeight uint64, blk blo
 			slog.WithField("state", "insync").Debug(changeStatelabel)
 
 			s.state = s.inSync
-			break
+			s.setSyncPhase(SyncCaughtUp)
+			return nil, nil
 		}
 	}
 
+	// Resume the block requests paused by a saturated sequencer, once it
+	// has drained.
+	if s.paused && !s.throttled() {
+		slog.WithField("pending", s.sequencer.len()).
+			WithField("curr_h", currentHeight).
+			Info("resume block requests")
+
+		return s.requestBlocks(currentHeight)
+	}
+
 	return nil, nil
 }
 
@@ -152,14 +184,33 @@ type synchronizer struct {
 	}
 
 	timer *outSyncTimer
+
+	// phase is the current SyncPhase. It is accessed atomically, as it is
+	// read outside of the chain lock.
+	phase uint32
+
+	// paused is set while block requests are held back by too many pending
+	// blocks in the sequencer.
+	paused bool
+
+	// missing tracks the request of the blocks filling a gap in the
+	// sequencer.
+	missing        missingRequest
+	missingTimeout time.Duration
+
+	// unsupported holds the block format version advertised by the peers
+	// whose blocks cannot be decoded by this node.
+	unsupported map[string]uint8
 }
 
 // newSynchronizer returns an initialized synchronizer, ready for use.
 func newSynchronizer(db database.DB, chain Ledger) *synchronizer {
 	s := &synchronizer{
-		db:        db,
-		sequencer: newSequencer(),
-		chain:     chain,
+		db:             db,
+		sequencer:      newSequencer(),
+		chain:          chain,
+		missingTimeout: missingBlockTimeout,
+		unsupported:    make(map[string]uint8),
 	}
 
 	s.timer = newSyncTimer(syncTimeout, chain.ProcessSyncTimerExpired)
@@ -176,14 +227,40 @@ func (s *synchronizer) processBlock(srcPeerID string, currentHeight uint64, blk
 	s.sequencer.cleanup(currentHeight)
 	s.sequencer.dump()
 
+	// Never sync from a peer advertising a block format this node cannot
+	// decode.
+	if !s.checkPeerVersion(srcPeerID, blk.Header.Version) {
+		return nil, verifiers.ErrUnsupportedVersion
+	}
+
 	currState := s.state
 	res, err = currState(srcPeerID, currentHeight, blk, metadata)
 	return
 }
 
+// checkPeerVersion records the block format version advertised by a peer, and
+// tells if it is supported. The unsupported format of a peer is logged only
+// once, unless it changes.
+func (s *synchronizer) checkPeerVersion(srcPeerID string, version uint8) bool {
+	if block.IsSupportedVersion(version) {
+		delete(s.unsupported, srcPeerID)
+		return true
+	}
+
+	if v, ok := s.unsupported[srcPeerID]; !ok || v != version {
+		slog.WithField("r_addr", srcPeerID).
+			WithField("min", block.MinSupportedVersion).
+			WithField("max", block.MaxSupportedVersion).
+			Warnf("peer uses unsupported block format v%d", version)
+	}
+
+	s.unsupported[srcPeerID] = version
+	return false
+}
+
 func (s *synchronizer) startSync(strPeerAddr string, tipHeight, currentHeight uint64, _ *message.Metadata) ([]bytes.Buffer, error) {
 	s.hrange.from = currentHeight
-	s.setSyncTarget(tipHeight, currentHeight+config.MaxInvBlocks)
+	s.setSyncTarget(tipHeight, currentHeight+syncBatchSize())
 
 	slog.WithField("curr_h", currentHeight).
 		WithField("tip", tipHeight).
@@ -191,6 +268,19 @@ func (s *synchronizer) startSync(strPeerAddr string, tipHeight, currentHeight ui
 		WithField("r_addr", strPeerAddr).
 		Info("start syncing")
 
+	s.setSyncPhase(SyncRequesting)
+
+	if s.throttled() {
+		slog.WithField("pending", s.sequencer.len()).Warn("pause block requests")
+		return nil, nil
+	}
+
+	return s.requestBlocks(currentHeight)
+}
+
+// requestBlocks returns a GetBlocks message for the blocks following
+// currentHeight.
+func (s *synchronizer) requestBlocks(currentHeight uint64) ([]bytes.Buffer, error) {
 	var hash []byte
 
 	if err := s.db.View(func(t database.Transaction) error {
@@ -201,10 +291,80 @@ func (s *synchronizer) startSync(strPeerAddr string, tipHeight, currentHeight ui
 		return nil, err
 	}
 
-	msgGetBlocks := createGetBlocksMsg(hash)
+	// Only the blocks up to the sync target are requested
+	msgGetBlocks := createGetBlocksMsg(hash, s.hrange.to-currentHeight)
 	return marshalGetBlocks(msgGetBlocks)
 }
 
+// missingRequest is the request of the blocks filling a gap in the sequencer,
+// from height onwards.
+type missingRequest struct {
+	height uint64
+	// point in time the gap was detected, or the blocks last requested
+	since    time.Time
+	requests int
+}
+
+// requestMissingBlocks asks a single peer for the blocks filling the gap from
+// height up to the first pooled block. The request is made only once the gap
+// has lasted missingTimeout, and repeated on each timeout, up to
+// missingBlockRequests times.
+func (s *synchronizer) requestMissingBlocks(srcPeerAddr string, height uint64) {
+	now := time.Now()
+
+	if s.missing.height != height {
+		s.missing = missingRequest{height: height, since: now}
+	}
+
+	if now.Sub(s.missing.since) < s.missingTimeout || s.missing.requests >= missingBlockRequests {
+		return
+	}
+
+	count := syncBatchSize()
+	if next, ok := s.sequencer.next(height); ok && next-height < count {
+		count = next - height
+	}
+
+	if err := s.chain.RequestBlockAtHeight(srcPeerAddr, height, count); err != nil {
+		slog.WithError(err).WithField("height", height).
+			Warn("could not request missing blocks")
+		return
+	}
+
+	s.missing.since = now
+	s.missing.requests++
+}
+
+// throttled tells if block requests should be held back, as too many blocks
+// are waiting in the sequencer. Once throttled, requests resume only after
+// half of the pending blocks are accepted.
+func (s *synchronizer) throttled() bool {
+	maxPending := config.Get().Network.MaxPendingBlocks
+	if maxPending <= 0 {
+		maxPending = config.MaxInvBlocks
+	}
+
+	switch pending := s.sequencer.len(); {
+	case pending >= maxPending:
+		s.paused = true
+	case pending <= maxPending/2:
+		s.paused = false
+	}
+
+	return s.paused
+}
+
+// syncBatchSize returns the number of blocks requested per round-trip while
+// syncing.
+func syncBatchSize() uint64 {
+	n := config.Get().Chain.SyncBatchSize
+	if n <= 0 || n > config.MaxInvBlocks {
+		return config.MaxInvBlocks
+	}
+
+	return uint64(n)
+}
+
 func (s *synchronizer) setSyncTarget(tipHeight, maxHeight uint64) {
 	s.hrange.to = tipHeight
 	if tipHeight > maxHeight {
@@ -212,8 +372,8 @@ func (s *synchronizer) setSyncTarget(tipHeight, maxHeight uint64) {
 	}
 }
 
-func createGetBlocksMsg(latestHash []byte) *message.GetBlocks {
-	msg := &message.GetBlocks{}
+func createGetBlocksMsg(latestHash []byte, count uint64) *message.GetBlocks {
+	msg := &message.GetBlocks{Count: count}
 	msg.Locators = append(msg.Locators, latestHash)
 	return msg
 }
@@ -9,12 +9,14 @@ package chain
 import (
 	"testing"
 
+	"github.com/dusk-network/dusk-blockchain/pkg/config"
 	"github.com/dusk-network/dusk-blockchain/pkg/config/genesis"
 	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus"
 	"github.com/dusk-network/dusk-blockchain/pkg/core/data/block"
 	"github.com/dusk-network/dusk-blockchain/pkg/core/database"
 	"github.com/dusk-network/dusk-blockchain/pkg/core/database/lite"
 	"github.com/dusk-network/dusk-blockchain/pkg/core/tests/helper"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/verifiers"
 	"github.com/dusk-network/dusk-blockchain/pkg/p2p/wire/message"
 	"github.com/dusk-network/dusk-blockchain/pkg/p2p/wire/topics"
 	assert "github.com/stretchr/testify/require"
@@ -51,6 +53,198 @@ func TestFutureBlocks(t *testing.T) {
 	assert.NotEmpty(s.sequencer.blockPool[height])
 }
 
+func TestOutOfOrderBlocks(t *testing.T) {
+	assert := assert.New(t)
+	s, _ := setupSynchronizerTest()
+	m := s.chain.(*mockChain)
+
+	// Block 2 is buffered until block 1 fills the gap
+	resp, err := s.processBlock("", 0, *helper.RandomBlock(2, 1), nil)
+	assert.NoError(err)
+	assert.Equal(uint8(topics.GetBlocks), resp[0].Bytes()[0])
+	assert.Empty(m.accepted)
+
+	resp, err = s.processBlock("", 0, *helper.RandomBlock(1, 1), nil)
+	assert.NoError(err)
+	assert.Nil(resp)
+
+	// Both are accepted, in order
+	assert.Equal([]uint64{1, 2}, m.accepted)
+	assert.Zero(s.sequencer.len())
+}
+
+func TestSyncBatchSize(t *testing.T) {
+	assert := assert.New(t)
+	s, _ := setupSynchronizerTest()
+
+	orig := config.Get()
+	defer config.Mock(&orig)
+
+	r := config.Registry{}
+	r.Chain.SyncBatchSize = 50
+	config.Mock(&r)
+
+	resp, err := s.processBlock("", 0, *helper.RandomBlock(200, 1), nil)
+	assert.NoError(err)
+	assert.Equal(uint8(topics.GetBlocks), resp[0].Bytes()[0])
+
+	// Only the configured batch of blocks is synced up in this round-trip
+	assert.Equal(uint64(0), s.hrange.from)
+	assert.Equal(uint64(50), s.hrange.to)
+
+	msg, err := message.Unmarshal(&resp[0], nil)
+	assert.NoError(err)
+	assert.Equal(uint64(50), msg.Payload().(message.GetBlocks).Count)
+}
+
+func TestUnsupportedBlockFormat(t *testing.T) {
+	assert := assert.New(t)
+	s, _ := setupSynchronizerTest()
+
+	// A peer advertising an unsupported block format can not start a sync
+	blk := helper.RandomBlock(10, 1)
+	blk.Header.Version = block.MaxSupportedVersion + 1
+
+	resp, err := s.processBlock("peer", 0, *blk, nil)
+	assert.ErrorIs(err, verifiers.ErrUnsupportedVersion)
+	assert.Nil(resp)
+	assert.Empty(s.sequencer.blockPool)
+	assert.Contains(s.unsupported, "peer")
+
+	// Once the peer moves to a supported format, blocks are requested
+	resp, err = s.processBlock("peer", 0, *helper.RandomBlock(10, 1), nil)
+	assert.NoError(err)
+	assert.Equal(uint8(topics.GetBlocks), resp[0].Bytes()[0])
+	assert.NotContains(s.unsupported, "peer")
+}
+
+func TestPauseBlockRequests(t *testing.T) {
+	assert := assert.New(t)
+	s, _ := setupSynchronizerTest()
+
+	orig := config.Get()
+	defer config.Mock(&orig)
+
+	r := config.Registry{}
+	r.Network.MaxPendingBlocks = 4
+	config.Mock(&r)
+
+	// Blocks keep arriving while block 1 is not accepted yet, until the
+	// sequencer saturates
+	resp, err := s.processBlock("", 0, *helper.RandomBlock(10, 1), nil)
+	assert.NoError(err)
+	assert.Equal(uint8(topics.GetBlocks), resp[0].Bytes()[0])
+
+	for height := uint64(11); height <= 13; height++ {
+		blk := helper.RandomBlock(height, 1)
+
+		resp, err = s.processBlock("", 0, *blk, nil)
+		assert.NoError(err)
+		assert.Nil(resp)
+
+		// The new request starts from the last accepted block
+		if height == 13 {
+			assert.NoError(s.db.Update(func(t database.Transaction) error {
+				return t.StoreBlock(blk, false)
+			}))
+		}
+	}
+
+	// A new sync does not request any block
+	s.state = s.inSync
+
+	resp, err = s.processBlock("", 0, *helper.RandomBlock(20, 1), nil)
+	assert.NoError(err)
+	assert.Nil(resp)
+	assert.True(s.paused)
+
+	// Accepting blocks drains the sequencer, and requests resume
+	for height := uint64(1); height < 9; height++ {
+		resp, err = s.processBlock("", height-1, *helper.RandomBlock(height, 1), nil)
+		assert.NoError(err)
+		assert.Nil(resp)
+	}
+
+	resp, err = s.processBlock("", 8, *helper.RandomBlock(9, 1), nil)
+	assert.NoError(err)
+	assert.Equal(uint8(topics.GetBlocks), resp[0].Bytes()[0])
+	assert.False(s.paused)
+	assert.Equal(1, s.sequencer.len())
+}
+
+func TestSyncPhases(t *testing.T) {
+	assert := assert.New(t)
+	s, _ := setupSynchronizerTest()
+	m := s.chain.(*mockChain)
+
+	var phases []SyncPhase
+
+	record := func() {
+		if p := s.syncPhase(); len(phases) == 0 || phases[len(phases)-1] != p {
+			phases = append(phases, p)
+		}
+	}
+
+	m.onCall = record
+
+	record()
+
+	// Block 3 triggers a sync up to it
+	_, err := s.processBlock("peer", 0, *helper.RandomBlock(3, 1), nil)
+	assert.NoError(err)
+	record()
+
+	// Block 1 is verified, then accepted along with the following ones
+	for height := uint64(1); height <= 2; height++ {
+		_, err = s.processBlock("peer", height-1, *helper.RandomBlock(height, 1), nil)
+		assert.NoError(err)
+		record()
+	}
+
+	assert.Equal([]SyncPhase{SyncIdle, SyncRequesting, SyncVerifying, SyncDownloading, SyncCaughtUp}, phases)
+	assert.Equal([]uint64{1, 2, 3}, m.accepted)
+}
+
+func TestRequestMissingBlock(t *testing.T) {
+	assert := assert.New(t)
+	s, _ := setupSynchronizerTest()
+	m := s.chain.(*mockChain)
+
+	// Block 4 triggers a sync
+	_, err := s.processBlock("peer", 0, *helper.RandomBlock(4, 1), nil)
+	assert.NoError(err)
+	assert.Empty(m.requested)
+
+	// Block 3 reveals a gap, which is not requested before it times out
+	_, err = s.processBlock("peer", 0, *helper.RandomBlock(3, 1), nil)
+	assert.NoError(err)
+	assert.Empty(m.requested)
+
+	// Once timed out, the blocks below the first pooled one are requested
+	// from the peer, and again on each timeout, up to missingBlockRequests
+	// times
+	for i := 0; i < missingBlockRequests+1; i++ {
+		s.missing.since = s.missing.since.Add(-missingBlockTimeout)
+
+		_, err = s.processBlock("peer", 0, *helper.RandomBlock(3, 1), nil)
+		assert.NoError(err)
+	}
+
+	assert.Len(m.requested, missingBlockRequests)
+	assert.Equal(blockRequest{peer: "peer", height: 1, count: 2}, m.requested[0])
+	assert.Empty(m.accepted)
+
+	// Filling the gap drains the sequencer
+	_, err = s.processBlock("peer", 0, *helper.RandomBlock(2, 1), nil)
+	assert.NoError(err)
+	assert.Empty(m.accepted)
+
+	_, err = s.processBlock("peer", 0, *helper.RandomBlock(1, 1), nil)
+	assert.NoError(err)
+	assert.Equal([]uint64{1, 2, 3, 4}, m.accepted)
+	assert.Zero(s.missing.height)
+}
+
 func setupSynchronizerTest() (*synchronizer, chan consensus.Results) {
 	c := make(chan consensus.Results, 1)
 	m := &mockChain{tipHeight: 0, catchBlockChan: c}
@@ -70,6 +264,21 @@ func setupSynchronizerTest() (*synchronizer, chan consensus.Results) {
 type mockChain struct {
 	tipHeight      uint64
 	catchBlockChan chan consensus.Results
+
+	// heights of the blocks accepted while out of sync
+	accepted []uint64
+
+	// onCall, if set, is called when a block is validated or accepted out
+	// of sync
+	onCall func()
+
+	// requests of the missing blocks
+	requested []blockRequest
+}
+
+type blockRequest struct {
+	peer          string
+	height, count uint64
 }
 
 func (m *mockChain) CurrentHeight() uint64 {
@@ -81,14 +290,25 @@ func (m *mockChain) TryNextConsecutiveBlockInSync(blk block.Block, _ *message.Me
 	return nil
 }
 
-func (m *mockChain) TryNextConsecutiveBlockOutSync(_ block.Block, _ *message.Metadata) error {
+func (m *mockChain) TryNextConsecutiveBlockOutSync(blk block.Block, _ *message.Metadata) error {
+	if m.onCall != nil {
+		m.onCall()
+	}
+
+	m.accepted = append(m.accepted, blk.Header.Height)
 	return nil
 }
 
 func (m *mockChain) TryNextConsecutiveBlockIsValid(blk block.Block) error {
+	if m.onCall != nil {
+		m.onCall()
+	}
+
 	return nil
 }
 
+func (m *mockChain) QueueVerification([]block.Block) {}
+
 func (m *mockChain) RestartConsensus() error {
 	return nil
 }
@@ -98,3 +318,8 @@ func (m *mockChain) StopConsensus() {}
 func (m *mockChain) ProcessSyncTimerExpired(string) error {
 	return nil
 }
+
+func (m *mockChain) RequestBlockAtHeight(peerAddr string, height, count uint64) error {
+	m.requested = append(m.requested, blockRequest{peer: peerAddr, height: height, count: count})
+	return nil
+}
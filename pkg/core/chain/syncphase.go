@@ -0,0 +1,87 @@
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT License was not distributed with this
+// file, you can obtain one at https://opensource.org/licenses/MIT.
+//
+// Copyright (c) DUSK NETWORK. All rights reserved.
+
+package chain
+
+import (
+	"sync/atomic"
+	"time"
+
+	"github.com/dusk-network/dusk-blockchain/pkg/config"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus/capi"
+	"github.com/dusk-network/dusk-blockchain/pkg/util/nativeutils/rpcbus"
+)
+
+// SyncPhase is the phase of the synchronization with the network.
+type SyncPhase uint32
+
+const (
+	// SyncIdle is the phase of a node following the network, with no sync
+	// in progress.
+	SyncIdle SyncPhase = iota
+	// SyncRequesting is the phase of a node waiting for the blocks it
+	// requested from a syncing peer.
+	SyncRequesting
+	// SyncVerifying is the phase of a node validating the first block
+	// provided by the syncing peer.
+	SyncVerifying
+	// SyncDownloading is the phase of a node accepting the blocks provided
+	// by the syncing peer.
+	SyncDownloading
+	// SyncCaughtUp is the phase of a node which reached its sync target.
+	SyncCaughtUp
+)
+
+var syncPhaseNames = [...]string{
+	SyncIdle:        "idle",
+	SyncRequesting:  "requesting",
+	SyncVerifying:   "verifying",
+	SyncDownloading: "downloading",
+	SyncCaughtUp:    "caught-up",
+}
+
+func (p SyncPhase) String() string {
+	if int(p) < len(syncPhaseNames) {
+		return syncPhaseNames[p]
+	}
+
+	return "unknown"
+}
+
+// syncPhase returns the current sync phase. It is safe to call it
+// concurrently with the synchronizer.
+func (s *synchronizer) syncPhase() SyncPhase {
+	return SyncPhase(atomic.LoadUint32(&s.phase))
+}
+
+// setSyncPhase moves the synchronizer to the given phase.
+func (s *synchronizer) setSyncPhase(p SyncPhase) {
+	prev := SyncPhase(atomic.SwapUint32(&s.phase, uint32(p)))
+	if prev != p {
+		slog.WithField("from", prev).WithField("to", p).Debug("change sync phase")
+	}
+}
+
+// serveSyncStatusRequests answers topics.GetSyncProgress requests until the
+// chain context is canceled.
+func (c *Chain) serveSyncStatusRequests(reqChan <-chan rpcbus.Request) {
+	for {
+		select {
+		case r := <-reqChan:
+			status := capi.SyncStatus{
+				Phase:    c.syncPhase().String(),
+				Progress: c.CalculateSyncProgress(),
+			}
+
+			timeout := time.Duration(config.Get().Timeout.TimeoutSendResponse) * time.Millisecond
+			if err := r.Respond(rpcbus.NewResponse(status, nil), timeout); err != nil {
+				log.WithError(err).Warn("failed to send sync status response")
+			}
+		case <-c.ctx.Done():
+			return
+		}
+	}
+}
@@ -0,0 +1,164 @@
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT License was not distributed with this
+// file, you can obtain one at https://opensource.org/licenses/MIT.
+//
+// Copyright (c) DUSK NETWORK. All rights reserved.
+
+package chain
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/dusk-network/dusk-blockchain/pkg/core/database"
+)
+
+// txHistoryRetryDelay is the delay before indexing again a block which could
+// not be indexed.
+const txHistoryRetryDelay = time.Second
+
+// txHistoryIndexer indexes the txs of the accepted blocks by the addresses
+// they involve. Blocks are indexed in chain order, by a single goroutine,
+// from the height of the last block indexed, which is stored along with the
+// index. The index is thus never updated on the block acceptance path, and
+// catches up with the chain on startup.
+type txHistoryIndexer struct {
+	db database.DB
+
+	// mu serializes the indexing with the rewinding on a chain revert.
+	mu sync.Mutex
+
+	// notify wakes up the indexer when a block is accepted.
+	notify chan struct{}
+}
+
+func newTxHistoryIndexer(db database.DB) *txHistoryIndexer {
+	return &txHistoryIndexer{
+		db:     db,
+		notify: make(chan struct{}, 1),
+	}
+}
+
+// push notifies the indexer of an accepted block. It never blocks, as the
+// indexer reads the blocks from the database.
+func (i *txHistoryIndexer) push() {
+	select {
+	case i.notify <- struct{}{}:
+	default:
+	}
+}
+
+// rewind lowers the height of the last block indexed to height, in t. It is
+// called when the blocks above height are reverted, so that the blocks
+// replacing them are indexed. The caller holds mu for the whole transaction.
+func (i *txHistoryIndexer) rewind(t database.Transaction, height uint64) error {
+	last, err := t.FetchTxHistoryHeight()
+	if err != nil {
+		return err
+	}
+
+	if last <= height {
+		return nil
+	}
+
+	return t.StoreTxHistoryHeight(height)
+}
+
+// run indexes the stored blocks until ctx is canceled. A block which cannot
+// be indexed is retried after txHistoryRetryDelay, as skipping it would
+// leave a gap in the index.
+func (i *txHistoryIndexer) run(ctx context.Context) {
+	for {
+		if err := i.catchUp(ctx); err != nil {
+			log.WithError(err).Warn("could not index tx history")
+
+			select {
+			case <-time.After(txHistoryRetryDelay):
+				continue
+			case <-ctx.Done():
+				return
+			}
+		}
+
+		select {
+		case <-i.notify:
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// catchUp indexes the blocks stored above the last block indexed, up to the
+// chain tip.
+func (i *txHistoryIndexer) catchUp(ctx context.Context) error {
+	for ctx.Err() == nil {
+		indexed, err := i.indexNext()
+		if err != nil || !indexed {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// indexNext stores the txs of the block following the last block indexed
+// under the addresses they involve, along with its height, in a single
+// database transaction. It returns false if the chain tip is indexed already.
+func (i *txHistoryIndexer) indexNext() (bool, error) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	var indexed bool
+
+	err := i.db.Update(func(t database.Transaction) error {
+		last, err := t.FetchTxHistoryHeight()
+		if err != nil {
+			return err
+		}
+
+		tip, err := t.FetchCurrentHeight()
+		if err != nil {
+			return err
+		}
+
+		if last >= tip {
+			return nil
+		}
+
+		height := last + 1
+
+		hash, err := t.FetchBlockHashByHeight(height)
+		if err != nil {
+			return err
+		}
+
+		txs, err := t.FetchBlockTxs(hash)
+		if err != nil {
+			return err
+		}
+
+		for idx, tx := range txs {
+			txID, err := tx.CalculateHash()
+			if err != nil {
+				return err
+			}
+
+			decoded, err := tx.Decode()
+			if err != nil {
+				return err
+			}
+
+			for _, addr := range decoded.Addresses() {
+				if err := t.StoreTxHistory(addr, height, uint32(idx), txID); err != nil {
+					return err
+				}
+			}
+		}
+
+		indexed = true
+		return t.StoreTxHistoryHeight(height)
+	})
+
+	return indexed, err
+}
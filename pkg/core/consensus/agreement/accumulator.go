@@ -58,52 +58,61 @@ func (a *Accumulator) Process(ev message.Agreement) {
 	a.verificationChan <- ev
 }
 
-// Accumulate agreements per block hash until a quorum is reached or a stop is detected (by closing the internal event channel). Supposed to run in a goroutine.
+// Accumulate agreements per block hash until a super-quorum is reached or a stop is detected (by closing the internal event channel). Supposed to run in a goroutine.
 func (a *Accumulator) Accumulate() {
 	for ev := range a.eventChan {
-		hdr := ev.State()
-
-		// Obtain corresponding block agreement cache given its hash
-		var s *store
-		if s = a.storeMap.getStoreByHash(hdr.BlockHash); s == nil {
-			s = a.storeMap.makeStoreByHash(hdr.BlockHash)
+		if votes, ok := a.accumulate(ev); ok {
+			a.CollectedVotesChan <- votes
+			return
 		}
+	}
+}
 
-		// Try to add agreement to our cache
-		collected := s.Get(hdr.Step)
-		weight := a.handler.VotesFor(hdr.PubKeyBLS, hdr.Round, hdr.Step)
+// accumulate adds a verified agreement to the cache of its block hash. It
+// returns the agreements collected for its step once they reach a
+// super-quorum.
+func (a *Accumulator) accumulate(ev message.Agreement) ([]message.Agreement, bool) {
+	hdr := ev.State()
 
-		count := s.Insert(ev, weight)
-		if count == len(collected) {
-			lg.Warnln("Agreement was not accumulated since it is a duplicate")
-			continue
-		}
+	// Obtain corresponding block agreement cache given its hash
+	var s *store
+	if s = a.storeMap.getStoreByHash(hdr.BlockHash); s == nil {
+		s = a.storeMap.makeStoreByHash(hdr.BlockHash)
+	}
 
-		lg.WithFields(log.Fields{
-			"step":       ev.State().Step,
-			"round":      ev.State().Round,
-			"aggr_count": count,
-			"quorum":     a.handler.Quorum(hdr.Round),
-			"hash_count": a.storeMap.len(),
-		}).Debug("collected agreement")
+	// Try to add agreement to our cache
+	collected := s.Get(hdr.Step)
+	weight := a.handler.VotesFor(hdr.PubKeyBLS, hdr.Round, hdr.Step)
 
-		if count >= a.handler.Quorum(hdr.Round) {
-			votes := s.Get(hdr.Step)
-			a.CollectedVotesChan <- votes
+	count := s.Insert(ev, weight)
+	if count == len(collected) {
+		lg.Warnln("Agreement was not accumulated since it is a duplicate")
+		return nil, false
+	}
 
-			lg.WithFields(log.Fields{
-				"step":        ev.State().Step,
-				"round":       ev.State().Round,
-				"aggr_count":  count,
-				"quorum":      a.handler.Quorum(hdr.Round),
-				"hash_count":  a.storeMap.len(),
-				"steps_count": s.Len(),
-				"duration":    time.Now().Unix() - s.CreatedAt(),
-			}).Info("quorum reached")
+	lg.WithFields(log.Fields{
+		"step":       ev.State().Step,
+		"round":      ev.State().Round,
+		"aggr_count": count,
+		"quorum":     a.handler.SuperQuorum(hdr.Round),
+		"hash_count": a.storeMap.len(),
+	}).Debug("collected agreement")
 
-			return
-		}
+	if count < a.handler.SuperQuorum(hdr.Round) {
+		return nil, false
 	}
+
+	lg.WithFields(log.Fields{
+		"step":        ev.State().Step,
+		"round":       ev.State().Round,
+		"aggr_count":  count,
+		"quorum":      a.handler.SuperQuorum(hdr.Round),
+		"hash_count":  a.storeMap.len(),
+		"steps_count": s.Len(),
+		"duration":    time.Now().Unix() - s.CreatedAt(),
+	}).Info("quorum reached")
+
+	return s.Get(hdr.Step), true
 }
 
 // CreateWorkers creates an amount of workers that verify Agreement messages
@@ -49,6 +49,10 @@ func (m *MockHandler) Quorum(uint64) int {
 	return m.quorum
 }
 
+func (m *MockHandler) SuperQuorum(uint64) int {
+	return m.quorum
+}
+
 // Verify checks the signature of the set.
 func (m *MockHandler) Verify(ev message.Agreement) error {
 	if !m.verify {
@@ -11,6 +11,7 @@ import (
 	"testing"
 
 	"github.com/dusk-network/bls12_381-sign/go/cgo/bls"
+	"github.com/dusk-network/dusk-blockchain/pkg/config"
 	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus/header"
 	"github.com/dusk-network/dusk-blockchain/pkg/p2p/wire/message"
 	"github.com/dusk-network/dusk-blockchain/pkg/p2p/wire/topics"
@@ -35,6 +36,52 @@ func TestAccumulatorProcessing(t *testing.T) {
 	assert.Equal(t, 7, len(accumulatedAggros))
 }
 
+// Test that, with a super-quorum threshold, the agreements are collected only
+// once they reach it, rather than at quorum.
+func TestAccumulatorSuperQuorum(t *testing.T) {
+	orig := config.Get()
+	defer config.Mock(&orig)
+
+	r := config.Get()
+	r.Consensus.SuperQuorumThreshold = 1
+	config.Mock(&r)
+
+	nr := 10
+	hlp := NewHelper(nr)
+	hash, _ := crypto.RandEntropy(32)
+	handler := NewHandler(hlp.Keys, *hlp.P, []byte{0, 0, 0, 0})
+
+	// The agreements are accumulated synchronously, as if verified
+	accumulator := &Accumulator{handler: handler, storeMap: newStoreMap()}
+
+	var votes, quorumChecks int
+
+	for _, ev := range hlp.Spawn(hash) {
+		hdr := ev.State()
+		votes += handler.VotesFor(hdr.PubKeyBLS, hdr.Round, hdr.Step)
+
+		collected, ok := accumulator.accumulate(ev)
+
+		if votes < handler.SuperQuorum(hdr.Round) {
+			// Not collected, even if the quorum is reached
+			assert.False(t, ok, "%d/%d votes", votes, handler.SuperQuorum(hdr.Round))
+
+			if votes >= handler.Quorum(hdr.Round) {
+				quorumChecks++
+			}
+
+			continue
+		}
+
+		assert.True(t, ok)
+		assert.NotEmpty(t, collected)
+		assert.NotZero(t, quorumChecks)
+		return
+	}
+
+	assert.FailNow(t, "super-quorum not reached")
+}
+
 func TestAccumulatorProcessingAggregation(t *testing.T) {
 	nr := 10
 	hlp := NewHelper(nr)
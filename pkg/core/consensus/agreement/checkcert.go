@@ -34,12 +34,39 @@ func CheckBlockCertificate(provisioners user.Provisioners, blk block.Block, seed
 	stepOneBatchedSig := blk.Header.Certificate.StepOneBatchedSig
 	stepTwoBatchedSig := blk.Header.Certificate.StepTwoBatchedSig
 
+	checkStepOne := func() error {
+		return checkBlockCertificateForStep(stepOneBatchedSig, blk.Header.Certificate.StepOneCommittee, blk.Header.Height, stepOne, provisioners, blk.Header.Hash, seed)
+	}
+
+	checkStepTwo := func() error {
+		return checkBlockCertificateForStep(stepTwoBatchedSig, blk.Header.Certificate.StepTwoCommittee, blk.Header.Height, stepTwo, provisioners, blk.Header.Hash, seed)
+	}
+
 	// Now, check the certificate's correctness for both reduction steps
-	if err := checkBlockCertificateForStep(stepOneBatchedSig, blk.Header.Certificate.StepOneCommittee, blk.Header.Height, stepOne, provisioners, blk.Header.Hash, seed); err != nil {
+	if !config.Get().Consensus.ParallelCertificateCheck {
+		if err := checkStepOne(); err != nil {
+			return err
+		}
+
+		return checkStepTwo()
+	}
+
+	// The two steps are independent, hence they can be verified in parallel.
+	// The sortition works on a copy of the provisioners, so sharing them is
+	// safe.
+	stepOneErr := make(chan error, 1)
+
+	go func() {
+		stepOneErr <- checkStepOne()
+	}()
+
+	stepTwoErr := checkStepTwo()
+
+	if err := <-stepOneErr; err != nil {
 		return err
 	}
 
-	return checkBlockCertificateForStep(stepTwoBatchedSig, blk.Header.Certificate.StepTwoCommittee, blk.Header.Height, stepTwo, provisioners, blk.Header.Hash, seed)
+	return stepTwoErr
 }
 
 func checkBlockCertificateForStep(batchedSig []byte, bitSet uint64, round uint64, step uint8, provisioners user.Provisioners, blockHash, seed []byte) error {
@@ -0,0 +1,97 @@
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT License was not distributed with this
+// file, you can obtain one at https://opensource.org/licenses/MIT.
+//
+// Copyright (c) DUSK NETWORK. All rights reserved.
+
+package agreement
+
+import (
+	"testing"
+
+	"github.com/dusk-network/dusk-blockchain/pkg/config"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus/user"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/data/block"
+	"github.com/dusk-network/dusk-blockchain/pkg/p2p/wire/message"
+	crypto "github.com/dusk-network/dusk-crypto/hash"
+	assert "github.com/stretchr/testify/require"
+)
+
+var certSeed = []byte{0, 0, 0, 0}
+
+// mockCertifiedBlock creates a block with a valid certificate for the given
+// round.
+func mockCertifiedBlock(round uint64, provisioners int) (*user.Provisioners, block.Block) {
+	p, keys := consensus.MockProvisioners(provisioners)
+	hash, _ := crypto.RandEntropy(32)
+	ev := message.MockAgreement(hash, round, 3, keys, p)
+
+	blk := block.Block{
+		Header: &block.Header{
+			Height:      round,
+			Hash:        hash,
+			Certificate: ev.GenerateCertificate(),
+		},
+	}
+
+	return p, blk
+}
+
+// mockParallelCertificateCheck sets the ParallelCertificateCheck config and
+// returns a function restoring the previous config.
+func mockParallelCertificateCheck(parallel bool) func() {
+	orig := config.Get()
+
+	r := config.Registry{}
+	r.Consensus.ParallelCertificateCheck = parallel
+	config.Mock(&r)
+
+	return func() {
+		config.Mock(&orig)
+	}
+}
+
+func TestCheckBlockCertificate(t *testing.T) {
+	for _, parallel := range []bool{false, true} {
+		restore := mockParallelCertificateCheck(parallel)
+
+		p, blk := mockCertifiedBlock(10, 10)
+		assert.NoError(t, CheckBlockCertificate(*p, blk, certSeed))
+
+		// A certificate with a single bad signature fails, whichever the step
+		cert := blk.Header.Certificate
+		stepOneSig, stepTwoSig := cert.StepOneBatchedSig, cert.StepTwoBatchedSig
+
+		cert.StepOneBatchedSig = stepTwoSig
+		assert.Error(t, CheckBlockCertificate(*p, blk, certSeed))
+
+		cert.StepOneBatchedSig = stepOneSig
+		cert.StepTwoBatchedSig = stepOneSig
+		assert.Error(t, CheckBlockCertificate(*p, blk, certSeed))
+
+		restore()
+	}
+}
+
+func benchmarkCheckBlockCertificate(b *testing.B, parallel bool) {
+	defer mockParallelCertificateCheck(parallel)()
+
+	p, blk := mockCertifiedBlock(10, 64)
+
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		if err := CheckBlockCertificate(*p, blk, certSeed); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkCheckBlockCertificate(b *testing.B) {
+	benchmarkCheckBlockCertificate(b, false)
+}
+
+func BenchmarkCheckBlockCertificateParallel(b *testing.B) {
+	benchmarkCheckBlockCertificate(b, true)
+}
@@ -32,6 +32,7 @@ type Handler interface {
 	IsMember([]byte, uint64, uint8) bool
 	Committee(uint64, uint8) user.VotingCommittee
 	Quorum(uint64) int
+	SuperQuorum(uint64) int
 	VotesFor([]byte, uint64, uint8) int
 	Verify(message.Agreement) error
 }
@@ -75,6 +76,26 @@ func (a *handler) Quorum(round uint64) int {
 	return quorum(a.CommitteeSize(round, config.ConsensusMaxCommitteeSize))
 }
 
+// SuperQuorum returns the amount of committee members whose agreements are
+// collected before finalizing a round. It falls back to the quorum if the
+// configured super-quorum threshold does not exceed the quorum threshold.
+func (a *handler) SuperQuorum(round uint64) int {
+	return superQuorum(a.CommitteeSize(round, config.ConsensusMaxCommitteeSize))
+}
+
+func superQuorum(committeeSize int) int {
+	threshold := config.Get().Consensus.SuperQuorumThreshold
+	if threshold <= config.ConsensusQuorumThreshold {
+		return quorum(committeeSize)
+	}
+
+	if threshold > 1 {
+		threshold = 1
+	}
+
+	return int(math.Ceil(float64(committeeSize) * threshold))
+}
+
 func quorum(committeeSize int) int {
 	return int(math.Ceil(float64(committeeSize) * config.ConsensusQuorumThreshold))
 }
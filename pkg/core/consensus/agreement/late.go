@@ -0,0 +1,153 @@
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT License was not distributed with this
+// file, you can obtain one at https://opensource.org/licenses/MIT.
+//
+// Copyright (c) DUSK NETWORK. All rights reserved.
+
+package agreement
+
+import (
+	"bytes"
+	"sync"
+	"time"
+
+	"github.com/dusk-network/dusk-blockchain/pkg/config"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/data/block"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/database"
+	"github.com/dusk-network/dusk-blockchain/pkg/p2p/wire/message"
+	"github.com/dusk-network/dusk-blockchain/pkg/p2p/wire/topics"
+	"github.com/dusk-network/dusk-blockchain/pkg/util"
+	log "github.com/sirupsen/logrus"
+)
+
+// lateRound holds the certificate of the last finalized round. Until the
+// deadline, the agreement votes received late for it may strengthen the
+// certificate with more reduction signatures.
+type lateRound struct {
+	lock sync.Mutex
+
+	handler  *handler
+	round    uint64
+	hash     []byte
+	cert     *block.Certificate
+	deadline time.Time
+}
+
+// newLateRound returns the lateRound of a finalized block, or nil if late
+// agreements are not collected.
+func newLateRound(h *handler, blk block.Block) *lateRound {
+	ms := config.Get().Consensus.LateAgreementMilli
+	if ms <= 0 || len(blk.Header.Hash) == 0 || blk.Header.Certificate == nil {
+		return nil
+	}
+
+	return &lateRound{
+		handler:  h,
+		round:    blk.Header.Height,
+		hash:     blk.Header.Hash,
+		cert:     blk.Header.Certificate.Copy(),
+		deadline: time.Now().Add(time.Duration(ms) * time.Millisecond),
+	}
+}
+
+// accepts returns true if the message is an agreement for the finalized
+// block, received within the grace period.
+func (l *lateRound) accepts(m message.Message) bool {
+	if l == nil || m.Category() != topics.Agreement {
+		return false
+	}
+
+	a := m.Payload().(message.Agreement)
+	hdr := a.State()
+
+	return hdr.Round == l.round &&
+		hdr.Step == l.cert.Step &&
+		bytes.Equal(hdr.BlockHash, l.hash) &&
+		time.Now().Before(l.deadline)
+}
+
+// collect verifies a late agreement and, if it carries more reduction votes
+// than the certificate, stores the strengthened certificate.
+func (l *lateRound) collect(db database.DB, a message.Agreement) {
+	hdr := a.State()
+	if !l.handler.IsMember(hdr.PubKeyBLS, hdr.Round, hdr.Step) {
+		return
+	}
+
+	if err := l.handler.Verify(a); err != nil {
+		lg.WithError(err).Debug("late agreement verification failed")
+		return
+	}
+
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	if !augmentCertificate(l.cert, a.VotesPerStep) {
+		return
+	}
+
+	if err := db.Update(func(t database.Transaction) error {
+		return t.StoreCertificate(l.hash, l.cert.Copy())
+	}); err != nil {
+		lg.WithError(err).Warn("could not store augmented certificate")
+		return
+	}
+
+	lg.WithFields(log.Fields{
+		"round":              l.round,
+		"hash":               util.StringifyBytes(l.hash),
+		"step_one_committee": l.cert.StepOneCommittee,
+		"step_two_committee": l.cert.StepTwoCommittee,
+	}).Info("certificate augmented by late agreement")
+}
+
+// augmentCertificate replaces the votes of each reduction step of the
+// certificate with the given ones, if these are signed by a strict superset
+// of the certificate voters. Signatures of overlapping voter sets can not be
+// merged, as the common voters would be aggregated twice.
+// It returns true if the certificate changed.
+func augmentCertificate(cert *block.Certificate, votes []*message.StepVotes) bool {
+	if len(votes) != 2 {
+		return false
+	}
+
+	var augmented bool
+
+	if isStrictSuperset(votes[0].BitSet, cert.StepOneCommittee) {
+		cert.StepOneCommittee = votes[0].BitSet
+		cert.StepOneBatchedSig = votes[0].Signature
+		augmented = true
+	}
+
+	if isStrictSuperset(votes[1].BitSet, cert.StepTwoCommittee) {
+		cert.StepTwoCommittee = votes[1].BitSet
+		cert.StepTwoBatchedSig = votes[1].Signature
+		augmented = true
+	}
+
+	return augmented
+}
+
+func isStrictSuperset(bits, of uint64) bool {
+	return bits != of && bits&of == of
+}
+
+// setLateRound records the round just finalized, if any.
+func (s *Loop) setLateRound(h *handler, res consensus.Results) {
+	var late *lateRound
+	if res.Err == nil {
+		late = newLateRound(h, res.Blk)
+	}
+
+	s.lock.Lock()
+	s.late = late
+	s.lock.Unlock()
+}
+
+func (s *Loop) lateRound() *lateRound {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	return s.late
+}
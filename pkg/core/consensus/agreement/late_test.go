@@ -0,0 +1,135 @@
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT License was not distributed with this
+// file, you can obtain one at https://opensource.org/licenses/MIT.
+//
+// Copyright (c) DUSK NETWORK. All rights reserved.
+
+package agreement
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/dusk-network/bls12_381-sign/go/cgo/bls"
+	"github.com/dusk-network/dusk-blockchain/pkg/config"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus/header"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/data/block"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/database"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/database/lite"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/tests/helper"
+	"github.com/dusk-network/dusk-blockchain/pkg/p2p/wire/message"
+	"github.com/dusk-network/dusk-blockchain/pkg/p2p/wire/topics"
+	"github.com/dusk-network/dusk-blockchain/pkg/util/nativeutils/sortedset"
+	"github.com/stretchr/testify/assert"
+)
+
+// Test that a late agreement received during the next round strengthens the
+// stored certificate of the finalized block.
+func TestLateAgreement(t *testing.T) {
+	orig := config.Get()
+	defer config.Mock(&orig)
+
+	r := config.Get()
+	r.Consensus.LateAgreementMilli = 10000
+	config.Mock(&r)
+
+	seed := []byte{0, 0, 0, 0}
+	hlp := NewHelper(10)
+	hlp.Round = 2
+	blk := helper.RandomBlock(hlp.Round, 1)
+
+	// The block is finalized without the vote of the last member of each
+	// reduction committee
+	stepOne := partialVotes(t, hlp, blk.Header.Hash, 2)
+	stepTwo := partialVotes(t, hlp, blk.Header.Hash, 3)
+	blk.Header.Certificate = &block.Certificate{
+		StepOneBatchedSig: stepOne.Signature,
+		StepTwoBatchedSig: stepTwo.Signature,
+		Step:              3,
+		StepOneCommittee:  stepOne.BitSet,
+		StepTwoCommittee:  stepTwo.BitSet,
+	}
+
+	_, db := lite.CreateDBConnection()
+	assert.NoError(t, db.Update(func(t database.Transaction) error {
+		return t.StoreBlock(blk, false)
+	}))
+
+	loop := New(hlp.Emitter, db, nil)
+	loop.setLateRound(NewHandler(hlp.Keys, *hlp.P, seed), consensus.Results{Blk: *blk})
+
+	// The agreement of the whole committee is received in the next round
+	late := message.MockAgreement(blk.Header.Hash, hlp.Round, 3, hlp.ProvisionersKeys, hlp.P)
+	agreementChan := make(chan message.Message, 1)
+	agreementChan <- message.New(topics.Agreement, late)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	ru := consensus.RoundUpdate{Round: hlp.Round + 1, P: *hlp.P, Seed: seed, Hash: blk.Header.Hash}
+	go loop.Run(ctx, consensus.NewQueue(), agreementChan, make(chan message.Message), ru)
+
+	cert := late.GenerateCertificate()
+	assert.Eventually(t, func() bool {
+		var hdr *block.Header
+
+		_ = db.View(func(t database.Transaction) error {
+			var err error
+			hdr, err = t.FetchBlockHeader(blk.Header.Hash)
+			return err
+		})
+
+		return hdr != nil && hdr.Certificate.Equals(cert)
+	}, 5*time.Second, 10*time.Millisecond)
+
+	blk.Header.Certificate = cert
+	assert.NoError(t, CheckBlockCertificate(*hlp.P, *blk, seed))
+}
+
+func TestAugmentCertificate(t *testing.T) {
+	cert := &block.Certificate{StepOneCommittee: 0b0110, StepTwoCommittee: 0b0110}
+
+	// Overlapping voter sets can not be merged
+	votes := []*message.StepVotes{{BitSet: 0b0011}, {BitSet: 0b0110}}
+	assert.False(t, augmentCertificate(cert, votes))
+
+	votes = []*message.StepVotes{{BitSet: 0b0111, Signature: []byte{1}}, {BitSet: 0b0110}}
+	assert.True(t, augmentCertificate(cert, votes))
+	assert.Equal(t, uint64(0b0111), cert.StepOneCommittee)
+	assert.Equal(t, []byte{1}, cert.StepOneBatchedSig)
+	assert.Equal(t, uint64(0b0110), cert.StepTwoCommittee)
+}
+
+// partialVotes returns the votes of a reduction step, signed by its whole
+// committee but the last member.
+func partialVotes(t *testing.T, hlp *Helper, hash []byte, step uint8) *message.StepVotes {
+	comm := hlp.P.CreateVotingCommittee([]byte{0, 0, 0, 0}, hlp.Round, step, len(hlp.ProvisionersKeys))
+	members := comm.MemberKeys()
+
+	sv := message.NewStepVotes()
+	set := sortedset.New()
+
+	for _, pk := range members[:len(members)-1] {
+		for _, k := range hlp.ProvisionersKeys {
+			if !bytes.Equal(pk, k.BLSPubKey) {
+				continue
+			}
+
+			buf := new(bytes.Buffer)
+			hdr := header.Header{Round: hlp.Round, Step: step, BlockHash: hash, PubKeyBLS: pk}
+			assert.NoError(t, header.MarshalSignableVote(buf, hdr))
+
+			sig, err := bls.Sign(k.BLSSecretKey, k.BLSPubKey, buf.Bytes())
+			assert.NoError(t, err)
+			assert.NoError(t, sv.Add(sig))
+
+			set.Insert(pk)
+		}
+	}
+
+	sv.BitSet = comm.Bits(set)
+	return sv
+}
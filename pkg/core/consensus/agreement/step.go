@@ -10,6 +10,7 @@ import (
 	"bytes"
 	"context"
 	"fmt"
+	"sync"
 	"time"
 
 	"github.com/dusk-network/bls12_381-sign/go/cgo/bls"
@@ -37,6 +38,10 @@ type Loop struct {
 	*consensus.Emitter
 	db        database.DB
 	requestor *candidate.Requestor
+
+	// late is the last finalized round, still collecting late agreements.
+	lock sync.Mutex
+	late *lateRound
 }
 
 // New creates a round-specific agreement step.
@@ -55,11 +60,18 @@ func (s *Loop) GetControlFn() consensus.ControlFn {
 }
 
 // Run the agreement step loop.
-func (s *Loop) Run(ctx context.Context, roundQueue *consensus.Queue, agreementChan <-chan message.Message, aggrAgreementChan <-chan message.Message, r consensus.RoundUpdate) consensus.Results {
+func (s *Loop) Run(ctx context.Context, roundQueue *consensus.Queue, agreementChan <-chan message.Message, aggrAgreementChan <-chan message.Message, r consensus.RoundUpdate) (results consensus.Results) {
 	// creating accumulator and handler
 	handler := NewHandler(s.Keys, r.P, r.Seed)
 	acc := newAccumulator(handler, WorkerAmount)
 
+	// agreements of the previous round are collected until this one is
+	// over, or their grace period expires
+	late := s.lateRound()
+	defer func() {
+		s.setLateRound(handler, results)
+	}()
+
 	// deferring queue cleanup at the end of the execution of this round
 	defer func() {
 		roundQueue.Clear(r.Round)
@@ -135,7 +147,9 @@ func (s *Loop) Run(ctx context.Context, roundQueue *consensus.Queue, agreementCh
 				return consensus.Results{Blk: block.Block{}, Err: context.Canceled}
 			// 2 - AgreementChan: Collect agreement messages
 			case m := <-agreementChan:
-				if s.shouldCollectNow(m, r.Round, roundQueue) {
+				if late.accepts(m) {
+					go late.collect(s.db, m.Payload().(message.Agreement))
+				} else if s.shouldCollectNow(m, r.Round, roundQueue) {
 					go collectAgreement(handler, acc, m, s.Emitter)
 				}
 				break low_priority // Prevents us from getting stuck in the low priority select
@@ -299,8 +313,12 @@ func (s *Loop) processCollectedVotes(ctx context.Context, handler *handler, evs
 			Infoln("aggragreement republished")
 	}
 
-	// Create a block and return
+	// Create a block and return. The certificate carries the reduction votes
+	// of the widest voter sets among the collected agreements
 	cert := evs[0].GenerateCertificate()
+	for _, a := range evs[1:] {
+		augmentCertificate(cert, a.VotesPerStep)
+	}
 
 	blk, err := s.createWinningBlock(ctx, evs[0].State().BlockHash, cert)
 	if err != nil {
@@ -10,9 +10,9 @@ import (
 	"bytes"
 	"context"
 	"errors"
+	"sync/atomic"
 	"time"
 
-	"github.com/dusk-network/bls12_381-sign/go/cgo/bls"
 	"github.com/dusk-network/dusk-blockchain/pkg/config"
 	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus"
 	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus/header"
@@ -29,6 +29,14 @@ var lg = log.WithField("process", "consensus").WithField("actor", "candidate_gen
 
 var errEmptyStateHash = errors.New("empty state hash")
 
+// ErrGenerationTimeout is returned when the generation of a candidate block
+// takes longer than config.Consensus.MaxGenerationTime.
+var ErrGenerationTimeout = errors.New("candidate generation timed out")
+
+// ErrTooManyGenerations is returned when config.Consensus.MaxConcurrentGenerations
+// candidate blocks are already being generated.
+var ErrTooManyGenerations = errors.New("too many candidate generations running")
+
 // Generator is responsible for generating candidate blocks, and propagating them
 // alongside received Scores. It is triggered by the ScoreEvent, sent by the score generator.
 type Generator interface {
@@ -40,6 +48,9 @@ type generator struct {
 
 	callTimeout time.Duration
 	executeFn   consensus.ExecuteTxsFunc
+
+	// number of generations running.
+	inflight int32
 }
 
 // New creates a new block generator.
@@ -64,7 +75,7 @@ func (bg *generator) GenerateCandidateMessage(ctx context.Context, r consensus.R
 		WithField("round", r.Round).
 		WithField("step", step)
 
-	seed, err := bg.sign(r.Seed)
+	seed, err := block.NextSeed(r.Seed, bg.Keys)
 	if err != nil {
 		return nil, err
 	}
@@ -98,9 +109,68 @@ func (bg *generator) GenerateCandidateMessage(ctx context.Context, r consensus.R
 	return scr, nil
 }
 
-// Generate a Block.
+// Generate a Block. If config.Consensus.MaxGenerationTime is set, the
+// generation is aborted with ErrGenerationTimeout when it runs longer, so
+// that the generator can take part in the rest of the round.
+//
+// At most config.Consensus.MaxConcurrentGenerations blocks are generated at
+// once, further generations fail with ErrTooManyGenerations.
 func (bg *generator) Generate(ctx context.Context, seed []byte, r consensus.RoundUpdate) (*block.Block, error) {
-	return bg.GenerateBlock(ctx, r.Round, seed, r.Hash, r.Timestamp)
+	if !bg.acquire() {
+		return nil, ErrTooManyGenerations
+	}
+
+	maxTime := config.Get().Consensus.MaxGenerationTime
+	if maxTime <= 0 {
+		defer bg.release()
+		return bg.GenerateBlock(ctx, r.Round, seed, r.Hash, r.Timestamp)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, time.Duration(maxTime)*time.Millisecond)
+	defer cancel()
+
+	type result struct {
+		blk *block.Block
+		err error
+	}
+
+	// The result channel is buffered, so that a late generation does not
+	// leak the goroutine.
+	resChan := make(chan result, 1)
+
+	// An aborted generation holds its slot until it actually returns.
+	go func() {
+		defer bg.release()
+
+		blk, err := bg.GenerateBlock(ctx, r.Round, seed, r.Hash, r.Timestamp)
+		resChan <- result{blk, err}
+	}()
+
+	select {
+	case res := <-resChan:
+		return res.blk, res.err
+	case <-ctx.Done():
+		if ctx.Err() == context.DeadlineExceeded {
+			return nil, ErrGenerationTimeout
+		}
+
+		return nil, ctx.Err()
+	}
+}
+
+// acquire a generation slot. It returns false if none is available.
+func (bg *generator) acquire() bool {
+	max := config.Get().Consensus.MaxConcurrentGenerations
+	if atomic.AddInt32(&bg.inflight, 1) > max && max > 0 {
+		atomic.AddInt32(&bg.inflight, -1)
+		return false
+	}
+
+	return true
+}
+
+func (bg *generator) release() {
+	atomic.AddInt32(&bg.inflight, -1)
 }
 
 func (bg *generator) execute(ctx context.Context, txs []transactions.ContractCall, round uint64, gasLimit uint64) ([]transactions.ContractCall, []byte, error) {
@@ -165,9 +235,22 @@ func (bg *generator) GenerateBlock(ctx context.Context, round uint64, seed, prev
 	timestamp := time.Now().Unix()
 	maxTimestamp := prevBlockTimestamp + config.MaxBlockTime
 
+	// blocks should not be closer than config.Consensus.MinBlockInterval,
+	// within the bound accepted by the verifiers
+	minTimestamp := prevBlockTimestamp + 1
+	if interval := config.Get().Consensus.MinBlockInterval; interval > 1 {
+		minTimestamp = prevBlockTimestamp + interval
+	}
+
+	if minTimestamp > maxTimestamp {
+		minTimestamp = maxTimestamp
+	}
+
 	if round > 1 && prevBlockTimestamp > 0 {
-		if timestamp < prevBlockTimestamp {
-			timestamp = prevBlockTimestamp
+		if timestamp < minTimestamp {
+			// previous block might be slightly ahead of local time (clock
+			// skew between generators). Keep block timestamps monotonic.
+			timestamp = minTimestamp
 		} else if timestamp > maxTimestamp {
 			// block time should not exceed config.MaxBlockTime
 			timestamp = maxTimestamp
@@ -219,7 +302,3 @@ func (bg *generator) FetchMempoolTxs() ([]transactions.ContractCall, error) {
 
 	return resp.([]transactions.ContractCall), nil
 }
-
-func (bg *generator) sign(seed []byte) ([]byte, error) {
-	return bls.Sign(bg.Keys.BLSSecretKey, bg.Keys.BLSPubKey, seed)
-}
@@ -12,9 +12,12 @@ import (
 	"testing"
 	"time"
 
+	"github.com/dusk-network/dusk-blockchain/pkg/config"
 	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus"
 	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus/blockgenerator/candidate"
 	"github.com/dusk-network/dusk-blockchain/pkg/core/data/ipc/transactions"
+	"github.com/dusk-network/dusk-blockchain/pkg/p2p/wire/topics"
+	"github.com/dusk-network/dusk-blockchain/pkg/util/nativeutils/rpcbus"
 	"github.com/stretchr/testify/require"
 )
 
@@ -37,3 +40,158 @@ func TestGenerate(t *testing.T) {
 	_, err := gen.GenerateCandidateMessage(ctx, ru, uint8(1))
 	require.NoError(t, err)
 }
+
+func TestGenerateTimestampAfterFuturePrevBlock(t *testing.T) {
+	hlp := candidate.NewHelper(10, time.Second)
+
+	fn := func(ctx context.Context, txs []transactions.ContractCall, h uint64, gaslimit uint64, generator []byte) ([]transactions.ContractCall, []byte, error) {
+		return []transactions.ContractCall{transactions.RandTx()}, make([]byte, 32), nil
+	}
+
+	gen := candidate.New(hlp.Emitter, fn)
+
+	// Previous block timestamp is slightly ahead of local time
+	ru := consensus.MockRoundUpdate(uint64(2), hlp.P)
+	ru.Timestamp = time.Now().Unix() + 5
+
+	msg, err := gen.GenerateCandidateMessage(context.Background(), ru, uint8(1))
+	require.NoError(t, err)
+
+	require.Equal(t, ru.Timestamp+1, msg.Candidate.Header.Timestamp)
+}
+
+func TestGenerateMinBlockInterval(t *testing.T) {
+	orig := config.Get()
+	defer config.Mock(&orig)
+
+	r := config.Get()
+	r.Consensus.MinBlockInterval = 30
+	config.Mock(&r)
+
+	hlp := candidate.NewHelper(10, time.Second)
+
+	fn := func(ctx context.Context, txs []transactions.ContractCall, h uint64, gaslimit uint64, generator []byte) ([]transactions.ContractCall, []byte, error) {
+		return []transactions.ContractCall{transactions.RandTx()}, make([]byte, 32), nil
+	}
+
+	gen := candidate.New(hlp.Emitter, fn)
+
+	// Previous block accepted just now, the candidate is pushed to the
+	// minimum interval
+	ru := consensus.MockRoundUpdate(uint64(2), hlp.P)
+	ru.Timestamp = time.Now().Unix()
+
+	msg, err := gen.GenerateCandidateMessage(context.Background(), ru, uint8(1))
+	require.NoError(t, err)
+	require.Equal(t, ru.Timestamp+30, msg.Candidate.Header.Timestamp)
+
+	// Previous block older than the minimum interval, the local time is kept
+	ru.Timestamp = time.Now().Unix() - 60
+
+	msg, err = gen.GenerateCandidateMessage(context.Background(), ru, uint8(1))
+	require.NoError(t, err)
+	require.GreaterOrEqual(t, msg.Candidate.Header.Timestamp, ru.Timestamp+60)
+
+	// An interval beyond the max block time is capped to it
+	r.Consensus.MinBlockInterval = config.MaxBlockTime + 10
+	config.Mock(&r)
+
+	ru.Timestamp = time.Now().Unix()
+
+	msg, err = gen.GenerateCandidateMessage(context.Background(), ru, uint8(1))
+	require.NoError(t, err)
+	require.Equal(t, ru.Timestamp+config.MaxBlockTime, msg.Candidate.Header.Timestamp)
+}
+
+func TestGenerateAbortsAtDeadline(t *testing.T) {
+	orig := config.Get()
+	defer config.Mock(&orig)
+
+	r := config.Registry{}
+	r.Consensus.MaxGenerationTime = 200
+	config.Mock(&r)
+
+	p, keys := consensus.MockProvisioners(10)
+	e := consensus.MockEmitter(time.Second)
+	e.Keys = keys[0]
+
+	// Mempool which takes way longer than the generation deadline to respond
+	reqChan := make(chan rpcbus.Request, 1)
+	require.NoError(t, e.RPCBus.Register(topics.GetMempoolTxsBySize, reqChan))
+
+	go func() {
+		r := <-reqChan
+		time.Sleep(2 * time.Second)
+		r.RespChan <- rpcbus.NewResponse([]transactions.ContractCall{}, nil)
+	}()
+
+	fn := func(ctx context.Context, txs []transactions.ContractCall, h uint64, gaslimit uint64, generator []byte) ([]transactions.ContractCall, []byte, error) {
+		return txs, make([]byte, 32), nil
+	}
+
+	gen := candidate.New(e, fn)
+
+	start := time.Now()
+	_, err := gen.GenerateCandidateMessage(context.Background(), consensus.MockRoundUpdate(uint64(2), p), uint8(1))
+	require.Equal(t, candidate.ErrGenerationTimeout, err)
+	require.Less(t, int64(time.Since(start)), int64(time.Second))
+}
+
+func TestMaxConcurrentGenerations(t *testing.T) {
+	orig := config.Get()
+	defer config.Mock(&orig)
+
+	r := config.Get()
+	r.Consensus.MaxConcurrentGenerations = 1
+	config.Mock(&r)
+
+	hlp := candidate.NewHelper(10, time.Second)
+
+	started := make(chan struct{}, 3)
+	release := make(chan struct{})
+
+	fn := func(ctx context.Context, txs []transactions.ContractCall, h uint64, gaslimit uint64, generator []byte) ([]transactions.ContractCall, []byte, error) {
+		started <- struct{}{}
+		<-release
+		return []transactions.ContractCall{transactions.RandTx()}, make([]byte, 32), nil
+	}
+
+	gen := candidate.New(hlp.Emitter, fn)
+	ru := consensus.MockRoundUpdate(uint64(2), hlp.P)
+
+	type result struct {
+		msg interface{}
+		err error
+	}
+
+	results := make(chan result, 3)
+
+	generate := func() {
+		msg, err := gen.GenerateCandidateMessage(context.Background(), ru, uint8(1))
+		results <- result{msg, err}
+	}
+
+	// The first generation holds the only slot
+	go generate()
+	<-started
+
+	// Redundant generations of the round are dropped
+	go generate()
+	go generate()
+
+	for i := 0; i < 2; i++ {
+		res := <-results
+		require.ErrorIs(t, res.err, candidate.ErrTooManyGenerations)
+	}
+
+	close(release)
+
+	res := <-results
+	require.NoError(t, res.err)
+	require.NotNil(t, res.msg)
+	require.Empty(t, started)
+
+	// The slot is released once the generation completes
+	_, err := gen.GenerateCandidateMessage(context.Background(), ru, uint8(1))
+	require.NoError(t, err)
+}
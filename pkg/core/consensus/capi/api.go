@@ -7,17 +7,27 @@
 package capi
 
 import (
+	"bytes"
 	"encoding/json"
+	"errors"
 	"net/http"
 	"strconv"
+	"time"
 
 	"github.com/asdine/storm/v3/q"
 
+	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/database"
+	"github.com/dusk-network/dusk-blockchain/pkg/p2p/wire/encoding"
+	"github.com/dusk-network/dusk-blockchain/pkg/p2p/wire/topics"
 	"github.com/dusk-network/dusk-blockchain/pkg/util/nativeutils/eventbus"
 	"github.com/dusk-network/dusk-blockchain/pkg/util/nativeutils/rpcbus"
 	"github.com/sirupsen/logrus"
 )
 
+// rpcCallTimeout is the time to wait for the chain to answer a query.
+const rpcCallTimeout = time.Second
+
 var (
 	eventBus *eventbus.EventBus
 	rpcBus   *rpcbus.RPCBus
@@ -233,3 +243,112 @@ func GetP2PCountHandler(res http.ResponseWriter, req *http.Request) {
 
 	_, _ = res.Write(b)
 }
+
+// GetSyncStatusHandler will return SyncStatus json, as provided by the chain.
+func GetSyncStatusHandler(res http.ResponseWriter, req *http.Request) {
+	if rpcBus == nil {
+		res.WriteHeader(http.StatusServiceUnavailable)
+		return
+	}
+
+	resp, err := rpcBus.Call(topics.GetSyncProgress, rpcbus.EmptyRequest(), rpcCallTimeout)
+	if err != nil {
+		log.WithError(err).Debug("failed to get sync status")
+		res.WriteHeader(http.StatusServiceUnavailable)
+		return
+	}
+
+	b, err := json.Marshal(resp.(SyncStatus))
+	if err != nil {
+		res.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	_, _ = res.Write(b)
+}
+
+// GetBlockAtHeightHandler will return the accepted block at the height given
+// by the height query parameter, encoded with message.MarshalBlock. It answers
+// NotFound if the chain has no block at that height, e.g. above the tip.
+func GetBlockAtHeightHandler(res http.ResponseWriter, req *http.Request) {
+	if rpcBus == nil {
+		res.WriteHeader(http.StatusServiceUnavailable)
+		return
+	}
+
+	height, err := strconv.ParseUint(req.URL.Query().Get("height"), 10, 64)
+	if err != nil {
+		res.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	params := new(bytes.Buffer)
+	if err = encoding.WriteUint64LE(params, height); err != nil {
+		res.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	resp, err := rpcBus.Call(topics.GetBlockAtHeight, rpcbus.NewRequest(*params), rpcCallTimeout)
+	if errors.Is(err, database.ErrBlockNotFound) {
+		res.WriteHeader(http.StatusNotFound)
+		return
+	}
+
+	if err != nil {
+		log.WithError(err).Debug("failed to get block")
+		res.WriteHeader(http.StatusServiceUnavailable)
+		return
+	}
+
+	buf := resp.(bytes.Buffer)
+
+	res.Header().Set("Content-Type", "application/octet-stream")
+	_, _ = res.Write(buf.Bytes())
+}
+
+// GetConsensusStateHandler will return the round, step and phase the
+// consensus is running, as json.
+func GetConsensusStateHandler(res http.ResponseWriter, req *http.Request) {
+	if rpcBus == nil {
+		res.WriteHeader(http.StatusServiceUnavailable)
+		return
+	}
+
+	resp, err := rpcBus.Call(topics.GetConsensusState, rpcbus.EmptyRequest(), rpcCallTimeout)
+	if err != nil {
+		log.WithError(err).Debug("failed to get consensus state")
+		res.WriteHeader(http.StatusServiceUnavailable)
+		return
+	}
+
+	b, err := json.Marshal(resp)
+	if err != nil {
+		res.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	_, _ = res.Write(b)
+}
+
+// StepTimingsJSON is the response of GetStepTimingsHandler.
+type StepTimingsJSON struct {
+	// Buckets are the upper bounds, in milliseconds, of the histogram
+	// buckets.
+	Buckets []int64                        `json:"buckets"`
+	Steps   map[string]consensus.Histogram `json:"steps"`
+}
+
+// GetStepTimingsHandler returns the histograms of the consensus step
+// durations, by step name.
+func GetStepTimingsHandler(res http.ResponseWriter, req *http.Request) {
+	b, err := json.Marshal(StepTimingsJSON{
+		Buckets: consensus.StepBuckets,
+		Steps:   consensus.StepTimings(),
+	})
+	if err != nil {
+		res.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	_, _ = res.Write(b)
+}
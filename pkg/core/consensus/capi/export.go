@@ -0,0 +1,178 @@
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT License was not distributed with this
+// file, you can obtain one at https://opensource.org/licenses/MIT.
+//
+// Copyright (c) DUSK NETWORK. All rights reserved.
+
+package capi
+
+import (
+	"encoding/csv"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+)
+
+// Export formats supported by ExportProvisionersHandler.
+const (
+	FormatCSV  = "csv"
+	FormatJSON = "json"
+)
+
+// ProvisionerRow summarizes the stakes of a provisioner, for offline analysis
+// of the stake distribution.
+type ProvisionerRow struct {
+	PublicKeyBLS        string `json:"bls_key"`
+	TotalStake          uint64 `json:"total_stake"`
+	StakeCount          int    `json:"stake_count"`
+	EarliestEligibility uint64 `json:"earliest_eligibility"`
+	LatestEligibility   uint64 `json:"latest_eligibility"`
+}
+
+var provisionerCSVHeader = []string{"bls_key", "total_stake", "stake_count", "earliest_eligibility", "latest_eligibility"}
+
+// Rows returns a ProvisionerRow per member of the provisioner set, in the
+// order of the set.
+func (p ProvisionerJSON) Rows() []ProvisionerRow {
+	rows := make([]ProvisionerRow, 0, len(p.Members))
+
+	for _, m := range p.Members {
+		row := ProvisionerRow{
+			PublicKeyBLS: hex.EncodeToString(m.PublicKeyBLS),
+			StakeCount:   len(m.Stakes),
+		}
+
+		for i, s := range m.Stakes {
+			row.TotalStake += s.Value
+
+			if i == 0 || s.Eligibility < row.EarliestEligibility {
+				row.EarliestEligibility = s.Eligibility
+			}
+
+			if s.Eligibility > row.LatestEligibility {
+				row.LatestEligibility = s.Eligibility
+			}
+		}
+
+		rows = append(rows, row)
+	}
+
+	return rows
+}
+
+// WriteProvisionersCSV writes the rows as CSV, preceded by a header line.
+func WriteProvisionersCSV(w io.Writer, rows []ProvisionerRow) error {
+	cw := csv.NewWriter(w)
+
+	if err := cw.Write(provisionerCSVHeader); err != nil {
+		return err
+	}
+
+	for _, r := range rows {
+		record := []string{
+			r.PublicKeyBLS,
+			strconv.FormatUint(r.TotalStake, 10),
+			strconv.Itoa(r.StakeCount),
+			strconv.FormatUint(r.EarliestEligibility, 10),
+			strconv.FormatUint(r.LatestEligibility, 10),
+		}
+
+		if err := cw.Write(record); err != nil {
+			return err
+		}
+	}
+
+	cw.Flush()
+	return cw.Error()
+}
+
+// Response headers describing the exported snapshot.
+const (
+	HeaderSnapshotHeight        = "X-Snapshot-Height"
+	HeaderProvisionersTotal     = "X-Provisioners-Total"
+	HeaderProvisionersTruncated = "X-Provisioners-Truncated"
+)
+
+// ExportProvisionersHandler exports a provisioner set snapshot as CSV (the
+// default) or JSON, according to the format parameter. The snapshot at the
+// given height is exported, or the latest one if height is omitted.
+//
+// Snapshots are not the live provisioner set: they are written every
+// API.ProvisionersSnapshotInterval blocks, and hold at most
+// API.MaxSnapshotProvisioners members. The height of the snapshot, the size
+// of the whole set and whether the export is truncated are reported in the
+// X-Snapshot-Height, X-Provisioners-Total and X-Provisioners-Truncated
+// headers.
+func ExportProvisionersHandler(res http.ResponseWriter, req *http.Request) {
+	format := req.URL.Query().Get("format")
+	if format == "" {
+		format = FormatCSV
+	}
+
+	if format != FormatCSV && format != FormatJSON {
+		res.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	var (
+		provisioner ProvisionerJSON
+		err         error
+	)
+
+	if heightStr := req.URL.Query().Get("height"); heightStr != "" {
+		height, perr := strconv.ParseUint(heightStr, 10, 64)
+		if perr != nil {
+			res.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		err = GetStormDBInstance().Find("ID", height, &provisioner)
+	} else {
+		err = GetStormDBInstance().DB.Select().OrderBy("ID").Reverse().First(&provisioner)
+	}
+
+	if err != nil {
+		res.WriteHeader(http.StatusNotFound)
+		return
+	}
+
+	// Snapshots written before the set size was recorded
+	total := provisioner.Total
+	if total < len(provisioner.Members) {
+		total = len(provisioner.Members)
+	}
+
+	log.WithField("height", provisioner.ID).
+		WithField("format", format).
+		WithField("total", total).
+		WithField("truncated", provisioner.Truncated()).
+		Debug("ExportProvisionersHandler")
+
+	rows := provisioner.Rows()
+
+	res.Header().Set(HeaderSnapshotHeight, strconv.FormatUint(provisioner.ID, 10))
+	res.Header().Set(HeaderProvisionersTotal, strconv.Itoa(total))
+	res.Header().Set(HeaderProvisionersTruncated, strconv.FormatBool(provisioner.Truncated()))
+
+	if format == FormatJSON {
+		b, err := json.Marshal(rows)
+		if err != nil {
+			res.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		res.Header().Set("Content-Type", "application/json")
+		_, _ = res.Write(b)
+		return
+	}
+
+	res.Header().Set("Content-Type", "text/csv")
+	res.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=provisioners-%d.csv", provisioner.ID))
+
+	if err := WriteProvisionersCSV(res, rows); err != nil {
+		log.WithError(err).Warn("could not export provisioners")
+	}
+}
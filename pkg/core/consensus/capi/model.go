@@ -72,4 +72,18 @@ type ProvisionerJSON struct {
 	ID      uint64        `storm:"id" json:"id"`
 	Set     sortedset.Set `json:"set"`
 	Members []*Member     `json:"members"`
+	// Total is the size of the provisioner set at height ID, which is larger
+	// than the number of Members if the snapshot was truncated.
+	Total int `json:"total"`
+}
+
+// Truncated tells if the snapshot holds only part of the provisioner set.
+func (p ProvisionerJSON) Truncated() bool {
+	return p.Total > len(p.Members)
+}
+
+// SyncStatus is the struct used to return the sync status of the node.
+type SyncStatus struct {
+	Phase    string  `json:"phase"`
+	Progress float64 `json:"progress"`
 }
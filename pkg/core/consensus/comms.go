@@ -102,7 +102,9 @@ type (
 func InitAcceptedBlockUpdate(subscriber eventbus.Subscriber) (chan block.Block, uint32) {
 	acceptedBlockChan := make(chan block.Block, cfg.MaxInvBlocks)
 	collector := &acceptedBlockCollector{acceptedBlockChan}
-	collectListener := eventbus.NewSafeCallbackListener(collector.Collect)
+	// Blocks are accepted in sequence, hence they must be collected in the
+	// same order
+	collectListener := eventbus.NewSafeOrderedCallbackListener(collector.Collect)
 	id := subscriber.Subscribe(topics.AcceptedBlock, collectListener)
 
 	return acceptedBlockChan, id
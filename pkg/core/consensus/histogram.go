@@ -0,0 +1,93 @@
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT License was not distributed with this
+// file, you can obtain one at https://opensource.org/licenses/MIT.
+//
+// Copyright (c) DUSK NETWORK. All rights reserved.
+
+package consensus
+
+import (
+	"sync"
+	"time"
+
+	"github.com/dusk-network/dusk-blockchain/pkg/config"
+)
+
+// StepBuckets are the upper bounds, in milliseconds, of the buckets of the
+// step duration histograms. Durations above the last one fall in an extra
+// overflow bucket.
+var StepBuckets = []int64{10, 25, 50, 100, 250, 500, 1000, 2500, 5000, 10000}
+
+// Histogram is the distribution of the durations of a consensus step.
+type Histogram struct {
+	// Counts holds the number of samples per bucket of StepBuckets, followed
+	// by the overflow bucket.
+	Counts []uint64 `json:"counts"`
+	// Count is the total number of samples.
+	Count uint64 `json:"count"`
+	// SumMilli is the sum of the samples, in milliseconds.
+	SumMilli int64 `json:"sum_ms"`
+}
+
+func (h *Histogram) observe(d time.Duration) {
+	ms := d.Milliseconds()
+
+	i := 0
+	for i < len(StepBuckets) && ms > StepBuckets[i] {
+		i++
+	}
+
+	h.Counts[i]++
+	h.Count++
+	h.SumMilli += ms
+}
+
+// stepTimings holds the step duration histograms of the node, by step name,
+// since startup.
+var stepTimings = struct {
+	sync.Mutex
+	h map[string]*Histogram
+}{h: make(map[string]*Histogram)}
+
+// ObserveStep records the duration of a consensus step into the histogram of
+// the step, if Consensus.StepTimings is enabled.
+func ObserveStep(name string, d time.Duration) {
+	if !config.Get().Consensus.StepTimings {
+		return
+	}
+
+	stepTimings.Lock()
+	defer stepTimings.Unlock()
+
+	h, ok := stepTimings.h[name]
+	if !ok {
+		h = &Histogram{Counts: make([]uint64, len(StepBuckets)+1)}
+		stepTimings.h[name] = h
+	}
+
+	h.observe(d)
+}
+
+// StepTimings returns a copy of the step duration histograms, by step name.
+func StepTimings() map[string]Histogram {
+	stepTimings.Lock()
+	defer stepTimings.Unlock()
+
+	timings := make(map[string]Histogram, len(stepTimings.h))
+
+	for name, h := range stepTimings.h {
+		c := *h
+		c.Counts = append([]uint64(nil), h.Counts...)
+		timings[name] = c
+	}
+
+	return timings
+}
+
+// ResetStepTimings drops all the recorded step durations.
+func ResetStepTimings() {
+	stepTimings.Lock()
+	defer stepTimings.Unlock()
+
+	stepTimings.h = make(map[string]*Histogram)
+}
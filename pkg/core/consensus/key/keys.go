@@ -41,6 +41,11 @@ func NewRandKeys() Keys {
 	}
 }
 
+// Sign a message with the BLS secret key.
+func (w Keys) Sign(msg []byte) ([]byte, error) {
+	return bls.Sign(w.BLSSecretKey, w.BLSPubKey, msg)
+}
+
 // KeysJSON is a struct used to marshal / unmarshal fields to a encrypted file.
 type KeysJSON struct {
 	SecretKeyBLS []byte `json:"secret_key_bls"`
@@ -106,7 +106,8 @@ func (p *Phase) Run(ctx context.Context, queue *consensus.Queue, _, reductionCha
 	}
 
 	// Process queued reduction messages
-	timeoutChan := time.After(p.TimeOut)
+	timeout := p.StepTimeout(p.handler.Committee(r.Round, step).Set.Len())
+	timeoutChan := time.After(timeout)
 	p.aggregator = reduction.NewAggregator(p.handler)
 
 	for _, ev := range queue.GetEvents(r.Round, step) {
@@ -152,7 +153,7 @@ func (p *Phase) Run(ctx context.Context, queue *consensus.Queue, _, reductionCha
 			}
 
 		case <-timeoutChan:
-			l := lg.WithField("event", "timeout").WithField("duration", p.TimeOut.String())
+			l := lg.WithField("event", "timeout").WithField("duration", timeout.String())
 			p.aggregator.Log(l, r.Round, step)
 
 			// in case of timeout we proceed in the consensus with an empty hash
@@ -76,6 +76,28 @@ func (r *Reduction) IncreaseTimeout(round uint64) {
 	}
 }
 
+// StepTimeout returns the timeout of a reduction step run by a committee of
+// committeeSize members. The base timeout is increased by
+// Consensus.TimeoutPerMemberMilli for each member, without exceeding
+// Consensus.MaxTimeoutMilli unless the base timeout already does.
+func (r *Reduction) StepTimeout(committeeSize int) time.Duration {
+	cfg := config.Get().Consensus
+
+	timeout := r.TimeOut + time.Duration(cfg.TimeoutPerMemberMilli*int64(committeeSize))*time.Millisecond
+
+	if cfg.MaxTimeoutMilli > 0 {
+		if maxTimeout := time.Duration(cfg.MaxTimeoutMilli) * time.Millisecond; timeout > maxTimeout {
+			timeout = maxTimeout
+		}
+	}
+
+	if timeout < r.TimeOut {
+		return r.TimeOut
+	}
+
+	return timeout
+}
+
 // verifyWithDelay calls verifyFn upon the candidate block but also incorporates a
 // delay on success verification.
 // vHash param is hash of block that has been already verified.
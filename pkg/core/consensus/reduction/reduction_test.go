@@ -0,0 +1,45 @@
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT License was not distributed with this
+// file, you can obtain one at https://opensource.org/licenses/MIT.
+//
+// Copyright (c) DUSK NETWORK. All rights reserved.
+
+package reduction
+
+import (
+	"testing"
+	"time"
+
+	"github.com/dusk-network/dusk-blockchain/pkg/config"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStepTimeout(t *testing.T) {
+	assert := require.New(t)
+
+	orig := config.Get()
+	defer config.Mock(&orig)
+
+	r := config.Registry{}
+	r.Consensus.TimeoutPerMemberMilli = 10
+	r.Consensus.MaxTimeoutMilli = 5500
+	config.Mock(&r)
+
+	red := &Reduction{TimeOut: 5 * time.Second}
+
+	// The timeout grows with the committee size
+	assert.Equal(5*time.Second, red.StepTimeout(0))
+	assert.Equal(5100*time.Millisecond, red.StepTimeout(10))
+	assert.Equal(5400*time.Millisecond, red.StepTimeout(40))
+
+	// Up to the clamp
+	assert.Equal(5500*time.Millisecond, red.StepTimeout(64))
+
+	// A base timeout already above the clamp is kept
+	red.TimeOut = 10 * time.Second
+	assert.Equal(10*time.Second, red.StepTimeout(64))
+
+	// No scaling if not configured
+	config.Mock(&config.Registry{})
+	assert.Equal(10*time.Second, red.StepTimeout(64))
+}
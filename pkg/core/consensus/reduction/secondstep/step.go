@@ -103,7 +103,8 @@ func (p *Phase) Run(ctx context.Context, queue *consensus.Queue, _, reductionCha
 		defer cancel()
 	}
 
-	timeoutChan := time.After(p.TimeOut)
+	timeout := p.StepTimeout(p.handler.Committee(r.Round, step).Set.Len())
+	timeoutChan := time.After(timeout)
 	p.aggregator = reduction.NewAggregator(p.handler)
 
 	for _, ev := range queue.GetEvents(r.Round, step) {
@@ -143,7 +144,7 @@ func (p *Phase) Run(ctx context.Context, queue *consensus.Queue, _, reductionCha
 					continue
 				}
 
-				l := lg.WithField("event", "timeout").WithField("duration", p.TimeOut.String())
+				l := lg.WithField("event", "timeout").WithField("duration", timeout.String())
 				p.aggregator.Log(l, r.Round, step)
 
 				go func() { // preventing timeout leakage
@@ -158,7 +159,7 @@ func (p *Phase) Run(ctx context.Context, queue *consensus.Queue, _, reductionCha
 			}
 
 		case <-timeoutChan:
-			l := lg.WithField("event", "timeout").WithField("duration", p.TimeOut.String())
+			l := lg.WithField("event", "timeout").WithField("duration", timeout.String())
 			p.aggregator.Log(l, r.Round, step)
 
 			// in case of timeout we increase the timeout and that's it
@@ -8,9 +8,11 @@ package selection_test
 
 import (
 	"context"
+	"errors"
 	"testing"
 	"time"
 
+	"github.com/dusk-network/dusk-blockchain/pkg/config"
 	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus"
 	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus/blockgenerator"
 	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus/selection"
@@ -67,3 +69,45 @@ func TestSelection(t *testing.T) {
 		})
 	}
 }
+
+// failingGenerator never provides a candidate block.
+type failingGenerator struct{}
+
+func (failingGenerator) GenerateCandidateMessage(context.Context, consensus.RoundUpdate, uint8) (*message.NewBlock, error) {
+	return nil, errors.New("no candidate")
+}
+
+func TestSelectionTimeout(t *testing.T) {
+	assert := require.New(t)
+
+	orig := config.Get()
+	defer config.Mock(&orig)
+
+	r := config.Registry{}
+	r.Consensus.SelectionTimeout = 200
+	config.Mock(&r)
+
+	hlp := selection.NewHelper(10)
+	_, db := lite.CreateDBConnection()
+
+	ttestCB := func(require *require.Assertions, p consensus.InternalPacket, _ *eventbus.GossipStreamer, _ chan message.Message) {
+		// No candidate was selected
+		require.Equal(message.EmptyNewBlock(), p)
+	}
+
+	testPhase := consensus.NewTestPhase(t, ttestCB, nil, nil)
+	sel := selection.New(testPhase, failingGenerator{}, hlp.Emitter, selection.Timeout(5*time.Second), db)
+	selFn := sel.Initialize(nil)
+
+	msgChan := make(chan message.Message, 1)
+	start := time.Now()
+
+	next := selFn.Run(context.Background(), consensus.NewQueue(), msgChan, msgChan, hlp.RoundUpdate(), hlp.Step)
+	elapsed := time.Since(start)
+
+	// The selection ends on the configured timeout, not the consensus one
+	assert.GreaterOrEqual(elapsed, 200*time.Millisecond)
+	assert.Less(elapsed, 5*time.Second)
+
+	_ = next.Run(context.Background(), nil, nil, nil, hlp.RoundUpdate(), hlp.Step+1)
+}
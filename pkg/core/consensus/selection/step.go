@@ -15,9 +15,11 @@ import (
 	"strconv"
 	"time"
 
+	"github.com/dusk-network/dusk-blockchain/pkg/config"
 	"github.com/dusk-network/dusk-blockchain/pkg/core/candidate"
 	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus/blockgenerator"
 	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus/header"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/data/block"
 	"github.com/dusk-network/dusk-blockchain/pkg/core/database"
 	"github.com/dusk-network/dusk-blockchain/pkg/util"
 
@@ -84,6 +86,16 @@ func New(next consensus.Phase, g blockgenerator.BlockGenerator, e *consensus.Emi
 	return selector
 }
 
+// Timeout returns the selection step timeout set with
+// config.Consensus.SelectionTimeout, or consensusTimeOut if it is not set.
+func Timeout(consensusTimeOut time.Duration) time.Duration {
+	if ms := config.Get().Consensus.SelectionTimeout; ms > 0 {
+		return time.Duration(ms) * time.Millisecond
+	}
+
+	return consensusTimeOut
+}
+
 // Initialize returns the Phase state function for the next phase, and initializes it
 // with the result from this phase.
 func (p *Phase) Initialize(_ consensus.InternalPacket) consensus.PhaseFn {
@@ -116,7 +128,10 @@ func (p *Phase) Run(parentCtx context.Context, queue *consensus.Queue, newBlockC
 	}
 
 	if isMember {
+		span := consensus.StartSpan(r.Round, step, "generation")
 		scr, err := p.g.GenerateCandidateMessage(ctx, r, step)
+		span.End()
+
 		if err != nil {
 			lg.WithError(err).Errorln("candidate block generation failed")
 		} else {
@@ -198,6 +213,11 @@ func (p *Phase) verifyNewBlock(msg message.NewBlock) error {
 		return err
 	}
 
+	// Ensure the candidate seed derives from the previous one
+	if err := block.VerifySeed(p.handler.Seed(), msg.Candidate.Header.Seed, msg.State().PubKeyBLS); err != nil {
+		return err
+	}
+
 	// Ensure candidate block hash is equal to the BlockHash of the msg.header
 	hash, err := msg.Candidate.CalculateHash()
 	if err != nil {
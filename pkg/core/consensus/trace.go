@@ -0,0 +1,50 @@
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT License was not distributed with this
+// file, you can obtain one at https://opensource.org/licenses/MIT.
+//
+// Copyright (c) DUSK NETWORK. All rights reserved.
+
+package consensus
+
+import (
+	"time"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// Span measures the execution of a consensus step. Spans are logged with the
+// round and step they belong to, so that the timeline of a round can be
+// reconstructed out of the logs.
+type Span struct {
+	Round uint64
+	Step  uint8
+	Name  string
+	Start time.Time
+}
+
+// StartSpan starts measuring the named step of a round.
+func StartSpan(round uint64, step uint8, name string) Span {
+	return Span{
+		Round: round,
+		Step:  step,
+		Name:  name,
+		Start: time.Now(),
+	}
+}
+
+// End logs the span at debug level, along with its duration, and records the
+// duration into the histogram of the step.
+func (s Span) End() {
+	end := time.Now()
+	ObserveStep(s.Name, end.Sub(s.Start))
+
+	log.WithField("process", "consensus").
+		WithField("event", "span").
+		WithField("round", s.Round).
+		WithField("step", s.Step).
+		WithField("name", s.Name).
+		WithField("start", s.Start.UnixNano()).
+		WithField("end", end.UnixNano()).
+		WithField("duration", end.Sub(s.Start)).
+		Debug("step span")
+}
@@ -9,6 +9,7 @@ package block
 import (
 	"testing"
 
+	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus/key"
 	"github.com/dusk-network/dusk-blockchain/pkg/core/data/ipc/transactions"
 	"github.com/stretchr/testify/assert"
 )
@@ -35,3 +36,18 @@ func TestTxFromBlock(t *testing.T) {
 		assert.NotNil(tx)
 	}
 }
+
+func TestNextSeed(t *testing.T) {
+	assert := assert.New(t)
+
+	keys := key.NewRandKeys()
+	prevSeed := []byte{1, 2, 3, 4}
+
+	seed, err := NextSeed(prevSeed, keys)
+	assert.NoError(err)
+	assert.NoError(VerifySeed(prevSeed, seed, keys.BLSPubKey))
+
+	// The seed is bound to both the previous seed and the generator
+	assert.Equal(ErrInvalidSeed, VerifySeed([]byte{4, 3, 2, 1}, seed, keys.BLSPubKey))
+	assert.Equal(ErrInvalidSeed, VerifySeed(prevSeed, seed, key.NewRandKeys().BLSPubKey))
+}
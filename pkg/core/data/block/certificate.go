@@ -25,10 +25,12 @@ func (c *Certificate) Copy() *Certificate {
 	cert := EmptyCertificate()
 
 	if c.StepOneBatchedSig != nil {
+		cert.StepOneBatchedSig = make([]byte, len(c.StepOneBatchedSig))
 		copy(cert.StepOneBatchedSig, c.StepOneBatchedSig)
 	}
 
 	if c.StepTwoBatchedSig != nil {
+		cert.StepTwoBatchedSig = make([]byte, len(c.StepTwoBatchedSig))
 		copy(cert.StepTwoBatchedSig, c.StepTwoBatchedSig)
 	}
 
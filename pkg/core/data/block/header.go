@@ -20,6 +20,20 @@ const (
 	HeightSize = 8
 )
 
+// Range of block format versions this node can decode. A peer advertises the
+// format it uses with the version byte of the block headers it sends.
+const (
+	// MinSupportedVersion oldest supported block format version.
+	MinSupportedVersion uint8 = 0
+	// MaxSupportedVersion newest supported block format version.
+	MaxSupportedVersion uint8 = 0
+)
+
+// IsSupportedVersion tells if a block of format version v can be decoded.
+func IsSupportedVersion(v uint8) bool {
+	return v >= MinSupportedVersion && v <= MaxSupportedVersion
+}
+
 // EmptyHash ...
 var EmptyHash [32]byte
 
@@ -0,0 +1,43 @@
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT License was not distributed with this
+// file, you can obtain one at https://opensource.org/licenses/MIT.
+//
+// Copyright (c) DUSK NETWORK. All rights reserved.
+
+package block
+
+import (
+	"errors"
+
+	"github.com/dusk-network/bls12_381-sign/go/cgo/bls"
+)
+
+// ErrInvalidSeed is returned when a block seed is not the signature of the
+// previous seed by the block generator.
+var ErrInvalidSeed = errors.New("invalid block seed")
+
+// BLSSigner signs a message with a BLS secret key.
+type BLSSigner interface {
+	Sign(msg []byte) ([]byte, error)
+}
+
+// NextSeed returns the seed of the block following the one with prevSeed. The
+// seed is the BLS signature of the previous seed by the block generator.
+func NextSeed(prevSeed []byte, signer BLSSigner) ([]byte, error) {
+	return signer.Sign(prevSeed)
+}
+
+// VerifySeed checks that seed is the seed following prevSeed, as produced by
+// the generator owning pubKeyBLS.
+func VerifySeed(prevSeed, seed, pubKeyBLS []byte) error {
+	apk, err := bls.CreateApk(pubKeyBLS)
+	if err != nil {
+		return err
+	}
+
+	if err := bls.Verify(apk, seed, prevSeed); err != nil {
+		return ErrInvalidSeed
+	}
+
+	return nil
+}
@@ -17,6 +17,7 @@ import (
 
 	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus/key"
 	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus/user"
+	"github.com/dusk-network/dusk-blockchain/pkg/p2p/wire/encoding"
 	"github.com/dusk-network/dusk-protobuf/autogen/go/rusk"
 )
 
@@ -192,6 +193,35 @@ func MockTx() *Transaction {
 	return RandTx()
 }
 
+// MockStakeTx mocks a transaction calling the stake method of a contract.
+func MockStakeTx() *Transaction {
+	tx := RandTx()
+
+	// Replace the empty call of the payload with a stake call
+	data := tx.Payload.Data[:len(tx.Payload.Data)-8]
+	buf := bytes.NewBuffer(append([]byte{}, data...))
+
+	_ = encoding.WriteUint64LE(buf, 1)
+	_ = encoding.Write256(buf, Rand32Bytes())
+	_, _ = buf.Write([]byte{txStake, 0, 0, 0})
+
+	tx.Payload = &TransactionPayload{Data: buf.Bytes()}
+
+	decoded, err := tx.Decode()
+	if err != nil {
+		panic(err)
+	}
+
+	hash, err := decoded.Hash(tx.TxType)
+	if err != nil {
+		panic(err)
+	}
+
+	copy(tx.Hash[:], hash)
+
+	return tx
+}
+
 // EmptyTx creates an zero-ed transaction.
 func EmptyTx() *Transaction {
 	tx := &Transaction{
@@ -206,6 +236,58 @@ func EmptyTx() *Transaction {
 	return tx
 }
 
+// MockTxWithAddresses mocks a transfer transaction refunding the gas change
+// to the first address and creating an output note for each of the others.
+func MockTxWithAddresses(addresses ...[]byte) *Transaction {
+	buf := new(bytes.Buffer)
+
+	// A single random nullifier
+	_ = encoding.WriteUint64LE(buf, 1)
+	_ = encoding.Write256(buf, Rand32Bytes())
+
+	_ = encoding.WriteUint64LE(buf, uint64(len(addresses)-1))
+
+	for _, addr := range addresses[1:] {
+		note := NewNote()
+		note.StealthAddress = addr
+
+		if err := MarshalNote(buf, note); err != nil {
+			panic(err)
+		}
+	}
+
+	// Anchor
+	_ = encoding.Write256(buf, make([]byte, 32))
+
+	_ = MarshalFee(buf, MockFee(false))
+	_ = encoding.Write512(buf, addresses[0])
+
+	// Spend proof, followed by no crossover and no call
+	_, _ = buf.Write(make([]byte, 1488))
+	_ = encoding.WriteUint64LE(buf, 0)
+	_ = encoding.WriteUint64LE(buf, 0)
+
+	tx := &Transaction{
+		Payload: &TransactionPayload{Data: buf.Bytes()},
+		TxType:  Transfer,
+		Version: 2,
+	}
+
+	decoded, err := tx.Decode()
+	if err != nil {
+		panic(err)
+	}
+
+	hash, err := decoded.Hash(tx.TxType)
+	if err != nil {
+		panic(err)
+	}
+
+	copy(tx.Hash[:], hash)
+
+	return tx
+}
+
 // MockTxWithParams mocks a transactions with specified params.
 func MockTxWithParams(txtype TxType, gasSpent uint64) ContractCall {
 	t := RandTx()
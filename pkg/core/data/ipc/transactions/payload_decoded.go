@@ -194,6 +194,32 @@ func UnmarshalTransactionPayloadDecoded(r *bytes.Buffer, f *TransactionPayloadDe
 	return nil
 }
 
+// Addresses returns the stealth addresses the transaction involves, that is
+// the ones of its output notes and the one the gas change is refunded to.
+func (p *TransactionPayloadDecoded) Addresses() [][]byte {
+	addresses := make([][]byte, 0, len(p.Notes)+1)
+
+	add := func(addr []byte) {
+		for _, a := range addresses {
+			if bytes.Equal(a, addr) {
+				return
+			}
+		}
+
+		addresses = append(addresses, addr)
+	}
+
+	for _, note := range p.Notes {
+		add(note.StealthAddress)
+	}
+
+	if p.Fee != nil {
+		add(p.Fee.StealthAddr)
+	}
+
+	return addresses
+}
+
 // EstimatedGasSpent return the estimated amount of Gas that a transaction will
 // spend. For now it uses `t.Call` to determine if it's a normal transfer or it
 // is an intercontract call.
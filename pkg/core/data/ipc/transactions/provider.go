@@ -225,27 +225,40 @@ func shouldUpdateProvisioners(blockHeight uint64, txs []ContractCall) bool {
 		return true
 	}
 
-	const TX_STAKE = byte(0x00)
-	const TX_UNSTAKE = byte(0x01)
-
 	for _, tx := range txs {
 		if tx.TxError() != nil {
 			continue
 		}
 
-		if payload, err := tx.Decode(); err == nil && payload.Call != nil {
-			switch payload.Call.CallData[0] {
-			case TX_STAKE, TX_UNSTAKE:
-				{
-					return true
-				}
-			}
+		if IsConsensusTx(tx) {
+			return true
 		}
 	}
 
 	return false
 }
 
+const (
+	txStake   = byte(0x00)
+	txUnstake = byte(0x01)
+)
+
+// IsConsensusTx returns true if the transaction is a stake or unstake call,
+// that is a transaction updating the provisioners.
+func IsConsensusTx(tx ContractCall) bool {
+	payload, err := tx.Decode()
+	if err != nil || payload.Call == nil || len(payload.Call.CallData) == 0 {
+		return false
+	}
+
+	switch payload.Call.CallData[0] {
+	case txStake, txUnstake:
+		return true
+	}
+
+	return false
+}
+
 // Accept proxy call performs both Accept and GetProvisioners grpc calls.
 func (e *executor) Accept(ctx context.Context, calls []ContractCall, stateRoot []byte, height, blockGasLimit uint64, generator []byte, prevProvisioners *user.Provisioners) ([]ContractCall, user.Provisioners, []byte, error) {
 	vstr := new(rusk.StateTransitionRequest)
@@ -13,6 +13,7 @@ import (
 	"github.com/dusk-network/dusk-blockchain/pkg/core/database"
 	"github.com/syndtr/goleveldb/leveldb"
 	"github.com/syndtr/goleveldb/leveldb/errors"
+	"github.com/syndtr/goleveldb/leveldb/opt"
 )
 
 var (
@@ -188,6 +189,17 @@ func (db DB) Close() error {
 	return nil
 }
 
+// Sync flushes the updates committed so far to disk. LevelDB has no explicit
+// flush, but a synchronous write syncs its journal, which holds all previous
+// writes. The write is the deletion of a key never stored.
+func (db DB) Sync() error {
+	if !db.isOpen() {
+		return errors.New("database is not open")
+	}
+
+	return db.storage.Delete(SyncPrefix, &opt.WriteOptions{Sync: true})
+}
+
 // GetSnapshot returns current storage snapshot. To be used only by
 // database/testing pkg.
 func (db DB) GetSnapshot() (*leveldb.Snapshot, error) {
@@ -8,6 +8,7 @@ package heavy
 
 import (
 	"bytes"
+	"encoding/binary"
 	"errors"
 	"fmt"
 	"math"
@@ -58,6 +59,26 @@ var (
 	PersistedPrefix = []byte{0x06}
 	// CandidatePrefix is the prefix to identify Candidate messages.
 	CandidatePrefix = []byte{0x07}
+	// CommitteePrefix is the prefix to identify the committee of a round.
+	CommitteePrefix = []byte{0x08}
+	// TxHistoryPrefix is the prefix to identify the txs involving an address.
+	TxHistoryPrefix = []byte{0x09}
+	// ProvisionersPrefix is the prefix to identify the provisioners of a round.
+	ProvisionersPrefix = []byte{0x0A}
+	// SyncPrefix is the key deleted on a synchronous write to flush the
+	// previous writes to disk. It is never stored.
+	SyncPrefix = []byte{0x0B}
+	// BlockMetadataPrefix is the prefix to identify the metadata of a block.
+	BlockMetadataPrefix = []byte{0x0C}
+	// ChainHashPrefix is the prefix to identify the cumulative chain hash
+	// at a height.
+	ChainHashPrefix = []byte{0x0D}
+	// HighestSeenPrefix is the prefix to identify the highest block height
+	// advertised by the network.
+	HighestSeenPrefix = []byte{0x0E}
+	// TxHistoryHeightPrefix is the prefix to identify the height of the
+	// last block whose txs are indexed.
+	TxHistoryHeightPrefix = []byte{0x0F}
 )
 
 type transaction struct {
@@ -76,7 +97,12 @@ type transaction struct {
 
 // DeleteBlock deletes all records associated with a specified block.
 func (t transaction) DeleteBlock(b *block.Block) error {
-	return t.modify(optypeDelete, b)
+	if err := t.modify(optypeDelete, b); err != nil {
+		return err
+	}
+
+	t.op(optypeDelete, blockMetadataKey(b.Header.Hash), nil)
+	return nil
 }
 
 // StoreBlock stores the entire block data into storage. No validations are
@@ -114,6 +140,25 @@ func (t transaction) StoreBlock(b *block.Block, persisted bool) error {
 	return nil
 }
 
+// StoreCertificate replaces the certificate of a stored block header. The
+// certificate is not part of the block hash, hence no other record changes.
+func (t transaction) StoreCertificate(hash []byte, cert *block.Certificate) error {
+	header, err := t.FetchBlockHeader(hash)
+	if err != nil {
+		return err
+	}
+
+	header.Certificate = cert
+
+	buf := new(bytes.Buffer)
+	if err := message.MarshalHeader(buf, header); err != nil {
+		return err
+	}
+
+	t.put(append(HeaderPrefix, hash...), buf.Bytes())
+	return nil
+}
+
 func (t *transaction) modify(optype int, b *block.Block) error {
 	if t.batch == nil {
 		// t.batch is initialized only on a open, read-write transaction
@@ -574,6 +619,221 @@ func (t transaction) ClearCandidateMessages() error {
 	return iter.Error()
 }
 
+// StoreCommittee stores the committee which finalized the block at round.
+func (t transaction) StoreCommittee(round uint64, committee []byte) error {
+	key, err := committeeKey(round)
+	if err != nil {
+		return err
+	}
+
+	t.put(key, committee)
+	return nil
+}
+
+// FetchCommittee returns the committee stored for round.
+func (t transaction) FetchCommittee(round uint64) ([]byte, error) {
+	key, err := committeeKey(round)
+	if err != nil {
+		return nil, err
+	}
+
+	value, err := t.snapshot.Get(key, nil)
+	if err == leveldb.ErrNotFound {
+		return nil, database.ErrCommitteeNotFound
+	}
+
+	return value, err
+}
+
+// DeleteCommittee removes the committee stored for round.
+func (t transaction) DeleteCommittee(round uint64) error {
+	key, err := committeeKey(round)
+	if err != nil {
+		return err
+	}
+
+	t.op(optypeDelete, key, nil)
+	return nil
+}
+
+// Key = CommitteePrefix + round.
+func committeeKey(round uint64) ([]byte, error) {
+	roundBuf := new(bytes.Buffer)
+	if err := utils.WriteUint64(roundBuf, round); err != nil {
+		return nil, err
+	}
+
+	return append(CommitteePrefix, roundBuf.Bytes()...), nil
+}
+
+// StoreProvisioners stores the provisioners the certificate of the block at
+// round is verified with.
+func (t transaction) StoreProvisioners(round uint64, provisioners []byte) error {
+	key, err := provisionersKey(round)
+	if err != nil {
+		return err
+	}
+
+	t.put(key, provisioners)
+	return nil
+}
+
+// FetchProvisioners returns the provisioners stored for round.
+func (t transaction) FetchProvisioners(round uint64) ([]byte, error) {
+	key, err := provisionersKey(round)
+	if err != nil {
+		return nil, err
+	}
+
+	value, err := t.snapshot.Get(key, nil)
+	if err == leveldb.ErrNotFound {
+		return nil, database.ErrProvisionersNotFound
+	}
+
+	return value, err
+}
+
+// DeleteProvisioners removes the provisioners stored for round.
+func (t transaction) DeleteProvisioners(round uint64) error {
+	key, err := provisionersKey(round)
+	if err != nil {
+		return err
+	}
+
+	t.op(optypeDelete, key, nil)
+	return nil
+}
+
+// Key = ProvisionersPrefix + round.
+func provisionersKey(round uint64) ([]byte, error) {
+	roundBuf := new(bytes.Buffer)
+	if err := utils.WriteUint64(roundBuf, round); err != nil {
+		return nil, err
+	}
+
+	return append(ProvisionersPrefix, roundBuf.Bytes()...), nil
+}
+
+// StoreChainHash stores the cumulative chain hash at height.
+func (t transaction) StoreChainHash(height uint64, hash []byte) error {
+	key, err := chainHashKey(height)
+	if err != nil {
+		return err
+	}
+
+	t.put(key, hash)
+	return nil
+}
+
+// FetchChainHash returns the cumulative chain hash stored for height.
+func (t transaction) FetchChainHash(height uint64) ([]byte, error) {
+	key, err := chainHashKey(height)
+	if err != nil {
+		return nil, err
+	}
+
+	value, err := t.snapshot.Get(key, nil)
+	if err == leveldb.ErrNotFound {
+		return nil, database.ErrChainHashNotFound
+	}
+
+	return value, err
+}
+
+// Key = ChainHashPrefix + height.
+func chainHashKey(height uint64) ([]byte, error) {
+	heightBuf := new(bytes.Buffer)
+	if err := utils.WriteUint64(heightBuf, height); err != nil {
+		return nil, err
+	}
+
+	return append(ChainHashPrefix, heightBuf.Bytes()...), nil
+}
+
+// StoreBlockMetadata stores the metadata of a block.
+func (t transaction) StoreBlockMetadata(hash []byte, m database.BlockMetadata) error {
+	value, err := utils.EncodeBlockMetadata(m)
+	if err != nil {
+		return err
+	}
+
+	t.put(blockMetadataKey(hash), value)
+	return nil
+}
+
+// FetchBlockMetadata returns the metadata of a block.
+func (t transaction) FetchBlockMetadata(hash []byte) (database.BlockMetadata, error) {
+	value, err := t.snapshot.Get(blockMetadataKey(hash), nil)
+	if err == leveldb.ErrNotFound {
+		return database.BlockMetadata{}, database.ErrBlockMetadataNotFound
+	}
+
+	if err != nil {
+		return database.BlockMetadata{}, err
+	}
+
+	return utils.DecodeBlockMetadata(value)
+}
+
+// Key = BlockMetadataPrefix + hash.
+func blockMetadataKey(hash []byte) []byte {
+	return append(append([]byte{}, BlockMetadataPrefix...), hash...)
+}
+
+// StoreTxHistory indexes a tx under address.
+func (t transaction) StoreTxHistory(address []byte, height uint64, txIndex uint32, txID []byte) error {
+	t.put(txHistoryKey(address, height, txIndex), txID)
+	return nil
+}
+
+// FetchTxHistory iterates the txs indexed under address from the highest
+// block down.
+func (t transaction) FetchTxHistory(address []byte, offset, limit uint64) ([]database.TxHistoryEntry, error) {
+	prefix := append(append([]byte{}, TxHistoryPrefix...), address...)
+
+	iter := t.snapshot.NewIterator(util.BytesPrefix(prefix), nil)
+	defer iter.Release()
+
+	entries := make([]database.TxHistoryEntry, 0)
+
+	for ok := iter.Last(); ok && uint64(len(entries)) < limit; ok = iter.Prev() {
+		// Only keys of this exact address have the expected size.
+		key := iter.Key()
+		if len(key) != len(prefix)+12 {
+			continue
+		}
+
+		if offset > 0 {
+			offset--
+			continue
+		}
+
+		entries = append(entries, database.TxHistoryEntry{
+			TxID:    append([]byte{}, iter.Value()...),
+			Height:  binary.BigEndian.Uint64(key[len(prefix):]),
+			TxIndex: binary.BigEndian.Uint32(key[len(prefix)+8:]),
+		})
+	}
+
+	return entries, iter.Error()
+}
+
+// Key = TxHistoryPrefix + address + height + txIndex.
+//
+// Height and index are big endian so that the keys of an address are sorted
+// by block and position in the block.
+func txHistoryKey(address []byte, height uint64, txIndex uint32) []byte {
+	key := make([]byte, 0, len(TxHistoryPrefix)+len(address)+12)
+	key = append(key, TxHistoryPrefix...)
+	key = append(key, address...)
+
+	var pos [12]byte
+	binary.BigEndian.PutUint64(pos[:8], height)
+	binary.BigEndian.PutUint32(pos[8:], txIndex)
+
+	return append(key, pos[:]...)
+}
+
 // ClearDatabase will wipe all of the data currently in the database.
 func (t transaction) ClearDatabase() error {
 	iter := t.snapshot.NewIterator(nil, nil)
@@ -585,3 +845,66 @@ func (t transaction) ClearDatabase() error {
 
 	return iter.Error()
 }
+
+// StoreHighestSeen stores the highest block height advertised by the network.
+func (t transaction) StoreHighestSeen(height uint64) error {
+	buf := new(bytes.Buffer)
+	if err := utils.WriteUint64(buf, height); err != nil {
+		return err
+	}
+
+	t.put(HighestSeenPrefix, buf.Bytes())
+	return nil
+}
+
+// FetchHighestSeen returns the highest block height stored, or zero if none is
+// stored.
+func (t transaction) FetchHighestSeen() (uint64, error) {
+	value, err := t.snapshot.Get(HighestSeenPrefix, nil)
+	if err == leveldb.ErrNotFound {
+		return 0, nil
+	}
+
+	if err != nil {
+		return 0, err
+	}
+
+	var height uint64
+	if err := utils.ReadUint64(bytes.NewBuffer(value), &height); err != nil {
+		return 0, err
+	}
+
+	return height, nil
+}
+
+// StoreTxHistoryHeight stores the height of the last block whose txs are
+// indexed.
+func (t transaction) StoreTxHistoryHeight(height uint64) error {
+	buf := new(bytes.Buffer)
+	if err := utils.WriteUint64(buf, height); err != nil {
+		return err
+	}
+
+	t.put(TxHistoryHeightPrefix, buf.Bytes())
+	return nil
+}
+
+// FetchTxHistoryHeight returns the height of the last block whose txs are
+// indexed, or zero if none is stored.
+func (t transaction) FetchTxHistoryHeight() (uint64, error) {
+	value, err := t.snapshot.Get(TxHistoryHeightPrefix, nil)
+	if err == leveldb.ErrNotFound {
+		return 0, nil
+	}
+
+	if err != nil {
+		return 0, err
+	}
+
+	var height uint64
+	if err := utils.ReadUint64(bytes.NewBuffer(value), &height); err != nil {
+		return 0, err
+	}
+
+	return height, nil
+}
@@ -28,6 +28,14 @@ var (
 	ErrOutputNotFound = errors.New("database: output not found")
 	// ErrStateHashNotFound returned on state hash not linked to any block.
 	ErrStateHashNotFound = errors.New("database: state hash was not found")
+	// ErrCommitteeNotFound returned on a committee lookup by round.
+	ErrCommitteeNotFound = errors.New("database: committee not found")
+	// ErrProvisionersNotFound returned on a provisioners lookup by round.
+	ErrProvisionersNotFound = errors.New("database: provisioners not found")
+	// ErrBlockMetadataNotFound returned on a block metadata lookup by hash.
+	ErrBlockMetadataNotFound = errors.New("database: block metadata not found")
+	// ErrChainHashNotFound returned on a chain hash lookup by height.
+	ErrChainHashNotFound = errors.New("database: chain hash not found")
 
 	// AnyTxType is used as a filter value on FetchBlockTxByHash.
 	AnyTxType = transactions.TxType(math.MaxUint8)
@@ -76,6 +84,15 @@ type Transaction interface {
 	// Not to be called concurrently, as it updates chain tip.
 	StoreBlock(block *block.Block, persisted bool) error
 
+	// StoreCertificate replaces the certificate of a stored block.
+	StoreCertificate(hash []byte, cert *block.Certificate) error
+
+	// StoreBlockMetadata stores the metadata of the block with the given hash.
+	StoreBlockMetadata(hash []byte, m BlockMetadata) error
+
+	// FetchBlockMetadata returns the metadata of the block with the given hash.
+	FetchBlockMetadata(hash []byte) (BlockMetadata, error)
+
 	// DeleteBlock deletes all records associated with a specified block.
 	DeleteBlock(b *block.Block) error
 
@@ -97,6 +114,57 @@ type Transaction interface {
 
 	ClearCandidateMessages() error
 
+	// StoreCommittee stores the encoded committee which finalized the
+	// block at the given round.
+	StoreCommittee(round uint64, committee []byte) error
+
+	// FetchCommittee returns the encoded committee stored for a round.
+	FetchCommittee(round uint64) ([]byte, error)
+
+	// DeleteCommittee removes the committee stored for a round, if any.
+	DeleteCommittee(round uint64) error
+
+	// StoreProvisioners stores the encoded provisioners the certificate of
+	// the block at the given round is verified with.
+	StoreProvisioners(round uint64, provisioners []byte) error
+
+	// FetchProvisioners returns the encoded provisioners stored for a round.
+	FetchProvisioners(round uint64) ([]byte, error)
+
+	// DeleteProvisioners removes the provisioners stored for a round, if any.
+	DeleteProvisioners(round uint64) error
+
+	// StoreChainHash stores the cumulative hash of the chain up to the
+	// block at the given height, replacing any previous one.
+	StoreChainHash(height uint64, hash []byte) error
+
+	// FetchChainHash returns the cumulative chain hash stored for a height.
+	FetchChainHash(height uint64) ([]byte, error)
+
+	// StoreHighestSeen stores the highest block height advertised by the
+	// network, replacing any previous one.
+	StoreHighestSeen(height uint64) error
+
+	// FetchHighestSeen returns the highest block height stored, or zero if
+	// none is stored.
+	FetchHighestSeen() (uint64, error)
+
+	// StoreTxHistory indexes the tx at txIndex in the block at height under
+	// an address it involves.
+	StoreTxHistory(address []byte, height uint64, txIndex uint32, txID []byte) error
+
+	// FetchTxHistory returns the txs indexed under an address, newest first.
+	// The first offset entries are skipped, and at most limit are returned.
+	FetchTxHistory(address []byte, offset, limit uint64) ([]TxHistoryEntry, error)
+
+	// StoreTxHistoryHeight stores the height of the last block whose txs
+	// are indexed, replacing any previous one.
+	StoreTxHistoryHeight(height uint64) error
+
+	// FetchTxHistoryHeight returns the height of the last block whose txs
+	// are indexed, or zero if none is stored.
+	FetchTxHistoryHeight() (uint64, error)
+
 	// ClearDatabase will remove all information from the database.
 	ClearDatabase() error
 
@@ -120,6 +188,9 @@ type DB interface {
 	// and no panic is raised on `fn` execution.
 	Update(fn func(t Transaction) error) error
 
+	// Sync flushes the updates committed so far to durable storage.
+	Sync() error
+
 	Close() error
 }
 
@@ -128,3 +199,23 @@ type Registry struct {
 	TipHash       []byte
 	PersistedHash []byte
 }
+
+// BlockMetadata summarizes an accepted block, so that indexers do not need to
+// fetch and decode its txs.
+type BlockMetadata struct {
+	TxCount uint32
+	// TotalFees is the sum of the gas spent by each tx times its gas price.
+	TotalFees uint64
+	// CoinbaseAmount is the reward of the block generator, that is the
+	// emission at the block height plus the total fees.
+	CoinbaseAmount uint64
+	// Size is the encoded size of the block in bytes.
+	Size uint64
+}
+
+// TxHistoryEntry locates a confirmed tx in the history of an address.
+type TxHistoryEntry struct {
+	TxID    []byte
+	Height  uint64
+	TxIndex uint32
+}
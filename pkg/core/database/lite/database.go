@@ -27,10 +27,19 @@ const (
 	stateInd
 	candidateInd
 	persistedInd
+	committeeInd
+	txHistoryInd
+	provisionersInd
+	blockMetadataInd
+	chainHashInd
 	maxInd
 )
 
-var stateKey = []byte{1}
+var (
+	stateKey           = []byte{1}
+	highestSeenKey     = []byte{2}
+	txHistoryHeightKey = []byte{3}
+)
 
 // DB represents the db struct.
 type DB struct {
@@ -59,17 +68,18 @@ func NewDatabase(path string, readonly bool) (database.DB, error) {
 
 // Begin builds read-only or read-write Transaction.
 func (db *DB) Begin(writable bool) (database.Transaction, error) {
-	var batch memdb
+	var batch, deleted memdb
 
 	if writable && !db.readOnly {
 		for i := range batch {
 			batch[i] = make(table)
+			deleted[i] = make(table)
 		}
 	}
 
 	t := &transaction{
 		writable: writable,
-		db:       db, batch: batch,
+		db:       db, batch: batch, deleted: deleted,
 	}
 
 	return t, nil
@@ -116,6 +126,11 @@ func (db *DB) View(fn func(database.Transaction) error) error {
 	return fn(t)
 }
 
+// Sync is a dummy method on a lite driver, which is in-memory.
+func (db *DB) Sync() error {
+	return nil
+}
+
 // Close is actually a dummy method on a lite driver.
 func (db *DB) Close() error {
 	return nil
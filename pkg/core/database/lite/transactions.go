@@ -16,6 +16,7 @@ import (
 	"github.com/dusk-network/dusk-blockchain/pkg/core/data/ipc/transactions"
 	"github.com/dusk-network/dusk-blockchain/pkg/core/database"
 	"github.com/dusk-network/dusk-blockchain/pkg/core/database/utils"
+	"github.com/dusk-network/dusk-blockchain/pkg/p2p/wire/encoding"
 	"github.com/dusk-network/dusk-blockchain/pkg/p2p/wire/message"
 )
 
@@ -23,9 +24,43 @@ type transaction struct {
 	writable bool
 	db       *DB
 	batch    memdb
+	// deleted holds the keys deleted, which Commit removes before writing
+	// the batch.
+	deleted memdb
 }
 
+// delete removes a key from the table at ind on Commit, unless it is stored
+// again in the batch afterwards.
+func (t *transaction) delete(ind int, k key) {
+	delete(t.batch[ind], k)
+	t.deleted[ind][k] = nil
+}
+
+// DeleteBlock deletes all records associated with a specified block.
 func (t *transaction) DeleteBlock(b *block.Block) error {
+	if !t.writable {
+		return errors.New("read-only transaction")
+	}
+
+	for _, tx := range b.Txs {
+		txID, err := tx.CalculateHash()
+		if err != nil {
+			return err
+		}
+
+		t.delete(txsInd, toKey(txID))
+		t.delete(txHashInd, toKey(txID))
+	}
+
+	heightBuf := new(bytes.Buffer)
+	if err := utils.WriteUint64(heightBuf, b.Header.Height); err != nil {
+		return err
+	}
+
+	t.delete(heightInd, toKey(heightBuf.Bytes()))
+	t.delete(blocksInd, toKey(b.Header.Hash))
+	t.delete(blockMetadataInd, toKey(b.Header.Hash))
+
 	return nil
 }
 
@@ -100,6 +135,10 @@ func (t *transaction) Commit() error {
 
 	/// commit changes
 	for i := range t.db.storage {
+		for k := range t.deleted[i] {
+			delete(t.db.storage[i], k)
+		}
+
 		for k, v := range t.batch[i] {
 			t.db.storage[i][k] = v
 		}
@@ -115,6 +154,32 @@ func (t transaction) FetchBlockExists(hash []byte) (bool, error) {
 	return true, nil
 }
 
+func (t *transaction) StoreCertificate(hash []byte, cert *block.Certificate) error {
+	if !t.writable {
+		return errors.New("read-only transaction")
+	}
+
+	data, exists := t.db.storage[blocksInd][toKey(hash)]
+	if !exists {
+		return database.ErrBlockNotFound
+	}
+
+	b := block.NewBlock()
+	if err := message.UnmarshalBlock(bytes.NewBuffer(data), b); err != nil {
+		return err
+	}
+
+	b.Header.Certificate = cert
+
+	buf := new(bytes.Buffer)
+	if err := message.MarshalBlock(buf, b); err != nil {
+		return err
+	}
+
+	t.batch[blocksInd][toKey(hash)] = buf.Bytes()
+	return nil
+}
+
 func (t transaction) FetchBlockHeader(hash []byte) (*block.Header, error) {
 	var data []byte
 	var exists bool
@@ -369,6 +434,225 @@ func (t *transaction) ClearCandidateMessages() error {
 	return nil
 }
 
+func (t *transaction) StoreBlockMetadata(hash []byte, m database.BlockMetadata) error {
+	if !t.writable {
+		return errors.New("read-only transaction")
+	}
+
+	value, err := utils.EncodeBlockMetadata(m)
+	if err != nil {
+		return err
+	}
+
+	t.batch[blockMetadataInd][toKey(hash)] = value
+	return nil
+}
+
+func (t *transaction) FetchBlockMetadata(hash []byte) (database.BlockMetadata, error) {
+	value, ok := t.db.storage[blockMetadataInd][toKey(hash)]
+	if !ok {
+		return database.BlockMetadata{}, database.ErrBlockMetadataNotFound
+	}
+
+	return utils.DecodeBlockMetadata(value)
+}
+
+func (t *transaction) StoreCommittee(round uint64, committee []byte) error {
+	roundBuf := new(bytes.Buffer)
+	if err := utils.WriteUint64(roundBuf, round); err != nil {
+		return err
+	}
+
+	t.db.storage[committeeInd][toKey(roundBuf.Bytes())] = committee
+	return nil
+}
+
+func (t *transaction) FetchCommittee(round uint64) ([]byte, error) {
+	roundBuf := new(bytes.Buffer)
+	if err := utils.WriteUint64(roundBuf, round); err != nil {
+		return nil, err
+	}
+
+	committee, ok := t.db.storage[committeeInd][toKey(roundBuf.Bytes())]
+	if !ok {
+		return nil, database.ErrCommitteeNotFound
+	}
+
+	return committee, nil
+}
+
+func (t *transaction) DeleteCommittee(round uint64) error {
+	roundBuf := new(bytes.Buffer)
+	if err := utils.WriteUint64(roundBuf, round); err != nil {
+		return err
+	}
+
+	delete(t.db.storage[committeeInd], toKey(roundBuf.Bytes()))
+	return nil
+}
+
+func (t *transaction) StoreProvisioners(round uint64, provisioners []byte) error {
+	roundBuf := new(bytes.Buffer)
+	if err := utils.WriteUint64(roundBuf, round); err != nil {
+		return err
+	}
+
+	t.db.storage[provisionersInd][toKey(roundBuf.Bytes())] = provisioners
+	return nil
+}
+
+func (t *transaction) FetchProvisioners(round uint64) ([]byte, error) {
+	roundBuf := new(bytes.Buffer)
+	if err := utils.WriteUint64(roundBuf, round); err != nil {
+		return nil, err
+	}
+
+	provisioners, ok := t.db.storage[provisionersInd][toKey(roundBuf.Bytes())]
+	if !ok {
+		return nil, database.ErrProvisionersNotFound
+	}
+
+	return provisioners, nil
+}
+
+func (t *transaction) DeleteProvisioners(round uint64) error {
+	roundBuf := new(bytes.Buffer)
+	if err := utils.WriteUint64(roundBuf, round); err != nil {
+		return err
+	}
+
+	delete(t.db.storage[provisionersInd], toKey(roundBuf.Bytes()))
+	return nil
+}
+
+func (t *transaction) StoreChainHash(height uint64, hash []byte) error {
+	heightBuf := new(bytes.Buffer)
+	if err := utils.WriteUint64(heightBuf, height); err != nil {
+		return err
+	}
+
+	t.db.storage[chainHashInd][toKey(heightBuf.Bytes())] = hash
+	return nil
+}
+
+func (t *transaction) FetchChainHash(height uint64) ([]byte, error) {
+	heightBuf := new(bytes.Buffer)
+	if err := utils.WriteUint64(heightBuf, height); err != nil {
+		return nil, err
+	}
+
+	hash, ok := t.db.storage[chainHashInd][toKey(heightBuf.Bytes())]
+	if !ok {
+		return nil, database.ErrChainHashNotFound
+	}
+
+	return hash, nil
+}
+
+func (t *transaction) StoreHighestSeen(height uint64) error {
+	buf := new(bytes.Buffer)
+	if err := utils.WriteUint64(buf, height); err != nil {
+		return err
+	}
+
+	t.db.storage[stateInd][toKey(highestSeenKey)] = buf.Bytes()
+	return nil
+}
+
+func (t *transaction) FetchHighestSeen() (uint64, error) {
+	value, ok := t.db.storage[stateInd][toKey(highestSeenKey)]
+	if !ok {
+		return 0, nil
+	}
+
+	var height uint64
+	if err := utils.ReadUint64(bytes.NewBuffer(value), &height); err != nil {
+		return 0, err
+	}
+
+	return height, nil
+}
+
+// StoreTxHistory appends an entry to the tx history of address. Entries are
+// expected to be stored in chain order.
+func (t *transaction) StoreTxHistory(address []byte, height uint64, txIndex uint32, txID []byte) error {
+	buf := bytes.NewBuffer(t.db.storage[txHistoryInd][toKey(address)])
+
+	if err := utils.WriteUint64(buf, height); err != nil {
+		return err
+	}
+
+	if err := utils.WriteUint32(buf, txIndex); err != nil {
+		return err
+	}
+
+	if err := encoding.WriteVarBytes(buf, txID); err != nil {
+		return err
+	}
+
+	t.db.storage[txHistoryInd][toKey(address)] = buf.Bytes()
+	return nil
+}
+
+func (t *transaction) FetchTxHistory(address []byte, offset, limit uint64) ([]database.TxHistoryEntry, error) {
+	all := make([]database.TxHistoryEntry, 0)
+
+	buf := bytes.NewBuffer(t.db.storage[txHistoryInd][toKey(address)])
+	for buf.Len() > 0 {
+		var e database.TxHistoryEntry
+		if err := utils.ReadUint64(buf, &e.Height); err != nil {
+			return nil, err
+		}
+
+		if err := utils.ReadUint32(buf, &e.TxIndex); err != nil {
+			return nil, err
+		}
+
+		if err := encoding.ReadVarBytes(buf, &e.TxID); err != nil {
+			return nil, err
+		}
+
+		all = append(all, e)
+	}
+
+	entries := make([]database.TxHistoryEntry, 0)
+
+	for i := len(all) - 1; i >= 0 && uint64(len(entries)) < limit; i-- {
+		if offset > 0 {
+			offset--
+			continue
+		}
+
+		entries = append(entries, all[i])
+	}
+
+	return entries, nil
+}
+
+func (t *transaction) StoreTxHistoryHeight(height uint64) error {
+	buf := new(bytes.Buffer)
+	if err := utils.WriteUint64(buf, height); err != nil {
+		return err
+	}
+
+	t.batch[stateInd][toKey(txHistoryHeightKey)] = buf.Bytes()
+	return nil
+}
+
+func (t *transaction) FetchTxHistoryHeight() (uint64, error) {
+	value, ok := t.db.storage[stateInd][toKey(txHistoryHeightKey)]
+	if !ok {
+		return 0, nil
+	}
+
+	var height uint64
+	if err := utils.ReadUint64(bytes.NewBuffer(value), &height); err != nil {
+		return 0, err
+	}
+
+	return height, nil
+}
+
 func (t transaction) ClearDatabase() error {
 	for key := range t.db.storage {
 		t.db.storage[key] = make(table)
@@ -678,6 +678,65 @@ func TestClearDatabase(test *testing.T) {
 	}
 }
 
+func TestTxHistory(test *testing.T) {
+	address := bytes.Repeat([]byte{0xa}, 64)
+	other := bytes.Repeat([]byte{0xb}, 64)
+
+	err := db.Update(func(t database.Transaction) error {
+		for height := uint64(1); height <= 3; height++ {
+			for idx := uint32(0); idx < 2; idx++ {
+				txID := []byte{byte(height), byte(idx)}
+				if err := t.StoreTxHistory(address, height, idx, txID); err != nil {
+					return err
+				}
+			}
+		}
+
+		return t.StoreTxHistory(other, 2, 0, []byte{0xb})
+	})
+	require.Nil(test, err)
+
+	err = db.View(func(t database.Transaction) error {
+		entries, err := t.FetchTxHistory(address, 1, 3)
+		if err != nil {
+			return err
+		}
+
+		// Newest first, skipping the very last one
+		require.Equal(test, []database.TxHistoryEntry{
+			{TxID: []byte{3, 0}, Height: 3, TxIndex: 0},
+			{TxID: []byte{2, 1}, Height: 2, TxIndex: 1},
+			{TxID: []byte{2, 0}, Height: 2, TxIndex: 0},
+		}, entries)
+
+		entries, err = t.FetchTxHistory(other, 0, 10)
+		if err != nil {
+			return err
+		}
+
+		require.Equal(test, []database.TxHistoryEntry{{TxID: []byte{0xb}, Height: 2, TxIndex: 0}}, entries)
+		return nil
+	})
+	require.Nil(test, err)
+
+	// The height of the last block indexed
+	err = db.Update(func(t database.Transaction) error {
+		return t.StoreTxHistoryHeight(3)
+	})
+	require.Nil(test, err)
+
+	err = db.View(func(t database.Transaction) error {
+		height, err := t.FetchTxHistoryHeight()
+		if err != nil {
+			return err
+		}
+
+		require.Equal(test, uint64(3), height)
+		return nil
+	})
+	require.Nil(test, err)
+}
+
 // _TestPersistence tries to ensure if driver provides persistence storage.
 // The procedure is simply based on:
 // 1. Close the driver
@@ -10,6 +10,7 @@ import (
 	"fmt"
 	"sync/atomic"
 
+	"github.com/dusk-network/dusk-blockchain/pkg/config/genesis"
 	"github.com/dusk-network/dusk-blockchain/pkg/core/data/block"
 	"github.com/dusk-network/dusk-blockchain/pkg/core/database"
 	"github.com/dusk-network/dusk-blockchain/pkg/core/tests/helper"
@@ -54,7 +55,7 @@ func generateChainBlocks(blocksCount int) ([]*block.Block, error) {
 	for i := 0; i < blocksCount; i++ {
 		b := helper.RandomBlock(atomic.AddUint64(&heightCounter, 1), sampleTxsBatchCount)
 		// assume consensus time is 10sec
-		b.Header.Timestamp = int64(10 * b.Header.Height)
+		b.Header.Timestamp = genesis.Timestamp + int64(10*b.Header.Height)
 
 		for _, tx := range b.Txs {
 			if _, err := tx.CalculateHash(); err != nil {
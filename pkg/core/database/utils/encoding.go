@@ -127,6 +127,49 @@ func DecodeBlockTx(data []byte, typeFilter transactions.TxType) (transactions.Co
 	return cc, txIndex, err
 }
 
+// blockMetadataSize is the encoded size of a database.BlockMetadata.
+const blockMetadataSize = 4 + 8 + 8 + 8
+
+// EncodeBlockMetadata serializes the fields of a database.BlockMetadata.
+func EncodeBlockMetadata(m database.BlockMetadata) ([]byte, error) {
+	buf := new(bytes.Buffer)
+
+	if err := WriteUint32(buf, m.TxCount); err != nil {
+		return nil, err
+	}
+
+	for _, v := range []uint64{m.TotalFees, m.CoinbaseAmount, m.Size} {
+		if err := WriteUint64(buf, v); err != nil {
+			return nil, err
+		}
+	}
+
+	return buf.Bytes(), nil
+}
+
+// DecodeBlockMetadata deserializes a database.BlockMetadata encoded with
+// EncodeBlockMetadata.
+func DecodeBlockMetadata(data []byte) (database.BlockMetadata, error) {
+	var m database.BlockMetadata
+
+	if len(data) != blockMetadataSize {
+		return m, fmt.Errorf("invalid block metadata size %d, expected %d", len(data), blockMetadataSize)
+	}
+
+	r := bytes.NewReader(data)
+	if err := ReadUint32(r, &m.TxCount); err != nil {
+		return m, err
+	}
+
+	for _, v := range []*uint64{&m.TotalFees, &m.CoinbaseAmount, &m.Size} {
+		if err := ReadUint64(r, v); err != nil {
+			return m, err
+		}
+	}
+
+	return m, nil
+}
+
 // WriteUint32 Tx utility to use a Tx byteOrder on internal encoding.
 func WriteUint32(w io.Writer, value uint32) error {
 	var b [4]byte
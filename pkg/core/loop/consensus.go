@@ -9,6 +9,7 @@ package loop
 import (
 	"context"
 	"errors"
+	"sync"
 	"time"
 
 	"github.com/dusk-network/dusk-blockchain/pkg/config"
@@ -56,6 +57,32 @@ type Consensus struct {
 	reductionChan     chan message.Message
 
 	listeners []eventbus.Listener
+
+	lock  sync.RWMutex
+	state State
+}
+
+// State is the round, step and phase the consensus is running.
+type State struct {
+	Round uint64 `json:"round"`
+	Step  uint8  `json:"step"`
+	Phase string `json:"phase"`
+}
+
+// State returns the round, step and phase of the latest step run by the
+// consensus. It is safe to call it concurrently with Spin.
+func (c *Consensus) State() State {
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+
+	return c.state
+}
+
+func (c *Consensus) setState(round uint64, step uint8, phase string) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	c.state = State{Round: round, Step: step, Phase: phase}
 }
 
 // CreateStateMachine creates and link the steps in the consensus. It is kept separated from
@@ -72,7 +99,7 @@ func CreateStateMachine(e *consensus.Emitter, db database.DB, consensusTimeOut t
 func CreateInitialStep(e *consensus.Emitter, consensusTimeOut time.Duration, bg blockgenerator.BlockGenerator, verifyFn consensus.CandidateVerificationFunc, db database.DB, requestor *candidate.Requestor) consensus.Phase {
 	redu2 := secondstep.New(e, verifyFn, consensusTimeOut)
 	redu1 := firststep.New(redu2, e, verifyFn, consensusTimeOut, db, requestor)
-	selectionStep := selection.New(redu1, bg, e, consensusTimeOut, db)
+	selectionStep := selection.New(redu1, bg, e, selection.Timeout(consensusTimeOut), db)
 
 	redu2.SetNext(selectionStep)
 	return selectionStep
@@ -165,9 +192,16 @@ func (c *Consensus) Spin(ctx context.Context, scr consensus.Phase, ag consensus.
 		// because the parent canceled or because a consensus has been reached)
 		defer finalizeStep()
 
+		start := time.Now()
 		agreementLoop := ag.GetControlFn()
 		results := agreementLoop(agrCtx, c.roundQueue, c.agreementChan, c.aggrAgreementChan, round)
 
+		// The agreement spans the whole round. Only the rounds it concludes
+		// are measured.
+		if results.Err == nil {
+			consensus.ObserveStep("agreement", time.Since(start))
+		}
+
 		resultsChan <- results
 	}()
 
@@ -176,7 +210,12 @@ func (c *Consensus) Spin(ctx context.Context, scr consensus.Phase, ag consensus.
 	// synchronous consensus loop keeps running until the agreement invokes
 	// context.Done or the context is canceled some other way
 	for step := uint8(1); ; step++ {
+		c.setState(round.Round, step, phaseFunction.String())
+
+		span := consensus.StartSpan(round.Round, step, phaseFunction.String())
 		phaseFunction = phaseFunction.Run(stepCtx, c.eventQueue, c.newBlockChan, c.reductionChan, round, step)
+		span.End()
+
 		// if result is nil, this round is over
 		if phaseFunction == nil {
 			lg.
@@ -13,12 +13,15 @@ import (
 	"testing"
 	"time"
 
+	"github.com/dusk-network/dusk-blockchain/pkg/config"
 	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus"
 	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus/agreement"
 	"github.com/dusk-network/dusk-blockchain/pkg/core/data/block"
 	"github.com/dusk-network/dusk-blockchain/pkg/core/database/lite"
 	"github.com/dusk-network/dusk-blockchain/pkg/p2p/wire/message"
 	"github.com/dusk-network/dusk-blockchain/pkg/p2p/wire/topics"
+	log "github.com/sirupsen/logrus"
+	"github.com/sirupsen/logrus/hooks/test"
 	"github.com/stretchr/testify/require"
 )
 
@@ -190,3 +193,171 @@ func TestClearQueues(t *testing.T) {
 
 	require.Empty(t, l.eventQueue.Flush(1))
 }
+
+// finiteStep is used by TestSpans to run a fixed amount of steps.
+type finiteStep struct {
+	steps uint8
+}
+
+func (f *finiteStep) String() string {
+	return "finite"
+}
+
+func (f *finiteStep) Run(_ context.Context, _ *consensus.Queue, _, _ chan message.Message, _ consensus.RoundUpdate, step uint8) consensus.PhaseFn {
+	if step >= f.steps {
+		return nil
+	}
+
+	return f
+}
+
+func (f *finiteStep) Initialize(_ consensus.InternalPacket) consensus.PhaseFn {
+	return f
+}
+
+// TestSpans tests that a span is logged for each step of a round.
+func TestSpans(t *testing.T) {
+	hook := test.NewGlobal()
+	defer hook.Reset()
+
+	level := log.GetLevel()
+	defer log.SetLevel(level)
+
+	log.SetLevel(log.DebugLevel)
+
+	e := consensus.MockEmitter(time.Second)
+	l := New(e)
+
+	_ = l.Spin(context.Background(), &finiteStep{3}, &unsuccesfulAgreement{}, consensus.RoundUpdate{Round: uint64(7)})
+
+	var step uint8
+
+	for _, entry := range hook.AllEntries() {
+		if entry.Data["event"] != "span" {
+			continue
+		}
+
+		step++
+
+		require.Equal(t, uint64(7), entry.Data["round"])
+		require.Equal(t, step, entry.Data["step"])
+		require.Equal(t, "finite", entry.Data["name"])
+		require.GreaterOrEqual(t, entry.Data["end"], entry.Data["start"])
+	}
+
+	require.Equal(t, uint8(3), step)
+}
+
+// stateStep is used by TestState to record the consensus state reported
+// while each step runs.
+type stateStep struct {
+	finiteStep
+	l      *Consensus
+	states []State
+}
+
+func (s *stateStep) String() string {
+	return "state"
+}
+
+func (s *stateStep) Run(ctx context.Context, q *consensus.Queue, c1, c2 chan message.Message, r consensus.RoundUpdate, step uint8) consensus.PhaseFn {
+	s.states = append(s.states, s.l.State())
+
+	if s.finiteStep.Run(ctx, q, c1, c2, r, step) == nil {
+		return nil
+	}
+
+	return s
+}
+
+func (s *stateStep) Initialize(_ consensus.InternalPacket) consensus.PhaseFn {
+	return s
+}
+
+// TestState tests that the reported state follows the round and steps run by
+// the consensus.
+func TestState(t *testing.T) {
+	e := consensus.MockEmitter(time.Second)
+	l := New(e)
+	s := &stateStep{finiteStep: finiteStep{3}, l: l}
+
+	_ = l.Spin(context.Background(), s, &unsuccesfulAgreement{}, consensus.RoundUpdate{Round: uint64(7)})
+
+	require.Equal(t, []State{
+		{Round: 7, Step: 1, Phase: "state"},
+		{Round: 7, Step: 2, Phase: "state"},
+		{Round: 7, Step: 3, Phase: "state"},
+	}, s.states)
+
+	require.Equal(t, State{Round: 7, Step: 3, Phase: "state"}, l.State())
+}
+
+// concludingStep is used by TestStepTimings to run a fixed amount of steps,
+// the last one lasting until the agreement concludes the round.
+type concludingStep struct {
+	finiteStep
+}
+
+func (s *concludingStep) Run(ctx context.Context, q *consensus.Queue, c1, c2 chan message.Message, r consensus.RoundUpdate, step uint8) consensus.PhaseFn {
+	if s.finiteStep.Run(ctx, q, c1, c2, r, step) == nil {
+		<-ctx.Done()
+		return nil
+	}
+
+	return s
+}
+
+func (s *concludingStep) Initialize(_ consensus.InternalPacket) consensus.PhaseFn {
+	return s
+}
+
+// concludingAgreement reaches consensus right away.
+type concludingAgreement struct{}
+
+func (c *concludingAgreement) GetControlFn() consensus.ControlFn {
+	return func(_ context.Context, _ *consensus.Queue, _ <-chan message.Message, _ <-chan message.Message, _ consensus.RoundUpdate) consensus.Results {
+		return consensus.Results{Blk: *block.NewBlock()}
+	}
+}
+
+// TestStepTimings tests that the duration of the steps of each round is
+// recorded, only if enabled.
+func TestStepTimings(t *testing.T) {
+	orig := config.Get()
+	defer config.Mock(&orig)
+
+	consensus.ResetStepTimings()
+	defer consensus.ResetStepTimings()
+
+	e := consensus.MockEmitter(time.Second)
+	l := New(e)
+
+	spin := func(rounds uint64) {
+		for round := uint64(1); round <= rounds; round++ {
+			results := l.Spin(context.Background(), &concludingStep{finiteStep{2}}, &concludingAgreement{}, consensus.RoundUpdate{Round: round})
+			require.NoError(t, results.Err)
+		}
+	}
+
+	// Disabled
+	spin(1)
+	require.Empty(t, consensus.StepTimings())
+
+	r := config.Get()
+	r.Consensus.StepTimings = true
+	config.Mock(&r)
+
+	spin(3)
+
+	timings := consensus.StepTimings()
+	require.Len(t, timings, 2)
+	require.Equal(t, uint64(6), timings["finite"].Count)
+	require.Equal(t, uint64(3), timings["agreement"].Count)
+
+	var samples uint64
+	for _, n := range timings["finite"].Counts {
+		samples += n
+	}
+
+	require.Equal(t, uint64(6), samples)
+}
@@ -31,6 +31,28 @@ type TxDesc struct {
 	kadHeight byte
 }
 
+// TxState is the confirmation state of a transaction.
+type TxState uint8
+
+const (
+	// TxUnknown transaction is neither in the mempool nor in the blockchain.
+	TxUnknown TxState = iota
+	// TxPending transaction is in the mempool, waiting to be included in a block.
+	TxPending
+	// TxConfirmed transaction is included in a block.
+	TxConfirmed
+)
+
+// TxStatus is the response to a topics.GetTxStatus request.
+type TxStatus struct {
+	State TxState
+
+	// Height of the block including the transaction, if confirmed.
+	Height uint64
+	// Confirmations is the number of blocks accepted on top of Height.
+	Confirmations uint64
+}
+
 // Pool represents a transaction pool of the verified txs only.
 type Pool interface {
 	// Create instantiates the underlying data storage.
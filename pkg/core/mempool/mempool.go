@@ -14,6 +14,7 @@ import (
 	"fmt"
 	"math"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"golang.org/x/time/rate"
@@ -29,6 +30,8 @@ import (
 	"github.com/dusk-network/dusk-blockchain/pkg/util/nativeutils/eventbus"
 	"github.com/dusk-network/dusk-blockchain/pkg/util/nativeutils/rpcbus"
 	logger "github.com/sirupsen/logrus"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 var log = logger.WithFields(logger.Fields{"process": "mempool"})
@@ -37,6 +40,10 @@ const (
 	idleTime        = 20 * time.Second
 	backendHashmap  = "hashmap"
 	backendDiskpool = "diskpool"
+
+	// updatesWait is how long the mempool waits for the first tx from the
+	// peers asked for their txs, before being ready anyway.
+	updatesWait = 5 * time.Second
 )
 
 var (
@@ -54,6 +61,17 @@ type Mempool struct {
 	getMempoolTxsChan       <-chan rpcbus.Request
 	getMempoolTxsBySizeChan <-chan rpcbus.Request
 	sendTxChan              <-chan rpcbus.Request
+	getTxStatusChan         <-chan rpcbus.Request
+	reconcileChan           <-chan rpcbus.Request
+	getReadyChan            <-chan rpcbus.Request
+
+	// ready is set once the first tx is received from the network peers
+	// after requesting their txs, or updatesWait after the request.
+	ready int32
+	// updatesRequested is set once the txs of the network peers have been
+	// requested.
+	updatesRequested int32
+	updatesWait      time.Duration
 
 	// verified txs to be included in next block.
 	verified Pool
@@ -63,6 +81,9 @@ type Mempool struct {
 	// the collector to listen for new accepted blocks.
 	acceptedBlockChan <-chan block.Block
 
+	// the listener for chain reorganizations.
+	reorgChan <-chan message.Message
+
 	eventBus *eventbus.EventBus
 
 	// the magic function that knows best what is valid chain Tx.
@@ -95,8 +116,25 @@ func NewMempool(db database.DB, eventBus *eventbus.EventBus, rpcBus *rpcbus.RPCB
 		log.WithError(err).Error("failed to register topics.SendMempoolTx")
 	}
 
+	getTxStatusChan := make(chan rpcbus.Request, 1)
+	if err := rpcBus.Register(topics.GetTxStatus, getTxStatusChan); err != nil {
+		log.WithError(err).Error("failed to register topics.GetTxStatus")
+	}
+
+	reconcileChan := make(chan rpcbus.Request, 1)
+	if err := rpcBus.Register(topics.ReconcileMempool, reconcileChan); err != nil {
+		log.WithError(err).Error("failed to register topics.ReconcileMempool")
+	}
+
+	getReadyChan := make(chan rpcbus.Request, 1)
+	if err := rpcBus.Register(topics.GetMempoolReady, getReadyChan); err != nil {
+		log.WithError(err).Error("failed to register topics.GetMempoolReady")
+	}
+
 	acceptedBlockChan, _ := consensus.InitAcceptedBlockUpdate(eventBus)
 
+	reorgChan, _ := eventBus.SubscribeChan(topics.ChainReorg, 10)
+
 	// Enable rate limiter from config
 	cfg := config.Get().Mempool
 
@@ -122,13 +160,18 @@ func NewMempool(db database.DB, eventBus *eventbus.EventBus, rpcBus *rpcbus.RPCB
 	m := &Mempool{
 		eventBus:                eventBus,
 		acceptedBlockChan:       acceptedBlockChan,
+		reorgChan:               reorgChan,
 		getMempoolTxsChan:       getMempoolTxsChan,
 		getMempoolTxsBySizeChan: getMempoolTxsBySizeChan,
 		sendTxChan:              sendTxChan,
+		getTxStatusChan:         getTxStatusChan,
+		reconcileChan:           reconcileChan,
+		getReadyChan:            getReadyChan,
 		verifier:                verifier,
 		limiter:                 limiter,
 		pendingPropagation:      make(chan TxDesc, 1000),
 		db:                      db,
+		updatesWait:             updatesWait,
 	}
 
 	// Setting the pool where to cache verified transactions.
@@ -140,8 +183,15 @@ func NewMempool(db database.DB, eventBus *eventbus.EventBus, rpcBus *rpcbus.RPCB
 	return m
 }
 
-// Run spawns the mempool lifecycle routines.
+// Run spawns the mempool lifecycle routines. Transactions persisted on a
+// previous shutdown are restored first, if so configured.
 func (m *Mempool) Run(ctx context.Context) {
+	if path := config.Get().Mempool.PersistFile; len(path) > 0 {
+		if err := m.Restore(path); err != nil {
+			log.WithError(err).Error("failed to restore mempool")
+		}
+	}
+
 	// Main Loop
 	go m.Loop(ctx)
 
@@ -160,8 +210,16 @@ func (m *Mempool) Loop(ctx context.Context) {
 			handleRequest(r, m.processGetMempoolTxsRequest, "GetMempoolTxs")
 		case r := <-m.getMempoolTxsBySizeChan:
 			handleRequest(r, m.processGetMempoolTxsBySizeRequest, "GetMempoolTxsBySize")
+		case r := <-m.getTxStatusChan:
+			handleRequest(r, m.processGetTxStatusRequest, "GetTxStatus")
+		case r := <-m.reconcileChan:
+			handleRequest(r, m.processReconcileRequest, "ReconcileMempool")
+		case r := <-m.getReadyChan:
+			handleRequest(r, m.processGetReadyRequest, "GetMempoolReady")
 		case b := <-m.acceptedBlockChan:
 			m.onBlock(b)
+		case msg := <-m.reorgChan:
+			m.onReorg(msg.Payload().(message.ChainReorg))
 		case <-ticker.C:
 			m.onIdle()
 		case <-ctx.Done():
@@ -231,6 +289,12 @@ func (m *Mempool) ProcessTx(srcPeerID string, msg message.Message) ([]bytes.Buff
 		kadHeight: h,
 	}
 
+	// Any tx received after requesting the txs of the peers means that they
+	// are responding.
+	if atomic.LoadInt32(&m.updatesRequested) == 1 {
+		m.setReady()
+	}
+
 	start := time.Now()
 	txid, err := m.processTx(t)
 	elapsed := time.Since(start)
@@ -333,6 +397,103 @@ func (m *Mempool) onBlock(b block.Block) {
 		Info("processing block completed")
 }
 
+// onReorg returns the transactions evicted by a chain reorganization back to
+// the mempool, and re-propagates them. Evicted transactions which are part of,
+// or conflict with, the new branch are discarded.
+func (m *Mempool) onReorg(r message.ChainReorg) {
+	accepted := make(map[txHash]struct{}, len(r.Accepted))
+	nullifiers := make(map[string]struct{})
+
+	for _, tx := range r.Accepted {
+		hash, err := tx.CalculateHash()
+		if err != nil {
+			log.WithError(err).Warn("could not calculate tx hash")
+			continue
+		}
+
+		var k txHash
+		copy(k[:], hash)
+		accepted[k] = struct{}{}
+
+		decoded, err := tx.Decode()
+		if err != nil {
+			continue
+		}
+
+		for _, n := range decoded.Nullifiers {
+			nullifiers[string(n)] = struct{}{}
+		}
+	}
+
+	var resubmitted int
+
+	for _, tx := range r.Evicted {
+		hash, err := tx.CalculateHash()
+		if err != nil {
+			log.WithError(err).Warn("could not calculate tx hash")
+			continue
+		}
+
+		var k txHash
+		copy(k[:], hash)
+
+		if _, ok := accepted[k]; ok {
+			// Included in the new branch as well
+			continue
+		}
+
+		if conflictsWith(tx, nullifiers) {
+			log.WithField("txid", toHex(hash)).Debug("discard tx conflicting with new branch")
+			continue
+		}
+
+		buf := new(bytes.Buffer)
+		if err := transactions.Marshal(buf, tx); err != nil {
+			log.WithError(err).Warn("could not marshal tx")
+			continue
+		}
+
+		t := TxDesc{
+			tx:        tx,
+			received:  time.Now(),
+			size:      uint(buf.Len()),
+			kadHeight: math.MaxUint8,
+		}
+
+		// processTx re-verifies the tx and queues it for propagation
+		if _, err := m.processTx(t); err != nil {
+			log.WithError(err).WithField("txid", toHex(hash)).Debug("discard evicted tx")
+			continue
+		}
+
+		resubmitted++
+	}
+
+	log.WithField("evicted", len(r.Evicted)).
+		WithField("resubmitted", resubmitted).
+		Info("processing chain reorg completed")
+}
+
+// conflictsWith returns true if tx spends any of the given nullifiers.
+func conflictsWith(tx transactions.ContractCall, nullifiers map[string]struct{}) bool {
+	if len(nullifiers) == 0 {
+		return false
+	}
+
+	decoded, err := tx.Decode()
+	if err != nil {
+		return false
+	}
+
+	for _, n := range decoded.Nullifiers {
+		if _, ok := nullifiers[string(n)]; ok {
+			return true
+		}
+	}
+
+	return false
+}
+
 // discardAcceptedTxs to clean up all txs from the mempool that have been already
 // added to the chain.
 //
@@ -498,6 +659,150 @@ func (m Mempool) processGetMempoolTxsBySizeRequest(r rpcbus.Request) (interface{
 	return txs, err
 }
 
+// processGetTxStatusRequest reports whether a transaction is still pending in
+// the mempool or already included in a block. In the latter case, the block
+// height and the number of confirmations are reported as well.
+func (m Mempool) processGetTxStatusRequest(r rpcbus.Request) (interface{}, error) {
+	params := r.Params.(bytes.Buffer)
+	txid := params.Bytes()
+
+	if len(txid) != 32 {
+		return TxStatus{}, errors.New("invalid txid")
+	}
+
+	if m.verified.Contain(txid) {
+		return TxStatus{State: TxPending}, nil
+	}
+
+	var status TxStatus
+
+	err := m.db.View(func(t database.Transaction) error {
+		_, _, blockHash, err := t.FetchBlockTxByHash(txid)
+		if err != nil {
+			return err
+		}
+
+		header, err := t.FetchBlockHeader(blockHash)
+		if err != nil {
+			return err
+		}
+
+		tip, err := t.FetchCurrentHeight()
+		if err != nil {
+			return err
+		}
+
+		status = TxStatus{
+			State:         TxConfirmed,
+			Height:        header.Height,
+			Confirmations: tip - header.Height,
+		}
+
+		return nil
+	})
+
+	switch err {
+	case nil:
+		return status, nil
+	case database.ErrTxNotFound:
+		return TxStatus{State: TxUnknown}, nil
+	default:
+		return TxStatus{}, err
+	}
+}
+
+// processReconcileRequest cross-references all the pending txs against the
+// current chain state. Txs already included in a block, or rejected by the
+// verifier, are dropped. The number of dropped txs is returned.
+//
+// The txs are verified again within RPC.Rusk.ContractTimeout overall, if
+// set. The txs which could not be verified in time, or on an error of the
+// verifier itself, are kept.
+func (m *Mempool) processReconcileRequest(r rpcbus.Request) (interface{}, error) {
+	stale := make([][]byte, 0)
+
+	ctx := context.Background()
+
+	if timeout := config.Get().RPC.Rusk.ContractTimeout; timeout > 0 {
+		var cancel context.CancelFunc
+
+		ctx, cancel = context.WithTimeout(ctx, time.Duration(timeout)*time.Millisecond)
+		defer cancel()
+	}
+
+	err := m.verified.Range(func(k txHash, t TxDesc) error {
+		txid := make([]byte, len(k))
+		copy(txid, k[:])
+
+		err := m.db.View(func(t database.Transaction) error {
+			_, _, _, err := t.FetchBlockTxByHash(txid)
+			return err
+		})
+
+		switch err {
+		case nil:
+			log.WithField("txid", toHex(txid)).Debug("drop confirmed tx")
+			stale = append(stale, txid)
+			return nil
+		case database.ErrTxNotFound:
+		default:
+			return err
+		}
+
+		if ctx.Err() != nil {
+			return nil
+		}
+
+		if _, _, err := m.verifier.Preverify(ctx, t.tx); err != nil {
+			if !isRejection(err) {
+				log.WithError(err).WithField("txid", toHex(txid)).Debug("could not verify tx")
+				return nil
+			}
+
+			log.WithError(err).WithField("txid", toHex(txid)).Debug("drop invalid tx")
+			stale = append(stale, txid)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	// The pool can not be updated while ranging over it
+	for _, txid := range stale {
+		_ = m.verified.Delete(txid)
+	}
+
+	log.WithField("dropped", len(stale)).
+		WithField("mem_txs_len", m.verified.Len()).
+		Info("mempool reconciled")
+
+	return len(stale), nil
+}
+
+// isRejection tells whether a Preverify error is a definite rejection of the
+// tx, rather than a failure to reach or to get an answer from the verifier.
+func isRejection(err error) bool {
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+
+	s, ok := status.FromError(err)
+	if !ok {
+		// Not a gRPC error, i.e. the tx was rejected locally
+		return true
+	}
+
+	switch s.Code() {
+	case codes.Canceled, codes.DeadlineExceeded, codes.Unavailable,
+		codes.ResourceExhausted, codes.Aborted, codes.Internal, codes.Unknown:
+		return false
+	default:
+		return true
+	}
+}
+
 // kadcastTx (re)propagates transaction in kadcast network.
 func (m *Mempool) kadcastTx(t TxDesc) error {
 	/// repropagate
@@ -517,10 +822,13 @@ func (m *Mempool) kadcastTx(t TxDesc) error {
 	return nil
 }
 
-// RequestUpdates sends topics.MemPool to N Kadcast Network nodes.
+// RequestUpdates sends topics.MemPool to N Kadcast Network nodes. The mempool
+// is ready once the first tx is received afterwards, or after updatesWait if
+// no peer responds.
 func (m *Mempool) RequestUpdates() {
 	if config.Get().Mempool.Updates.Disabled {
 		log.Warn("mempool state updates disabled")
+		m.setReady()
 		return
 	}
 
@@ -539,14 +847,37 @@ func (m *Mempool) RequestUpdates() {
 		panic(err)
 	}
 
+	atomic.StoreInt32(&m.updatesRequested, 1)
+	time.AfterFunc(m.updatesWait, m.setReady)
+
 	metadata := message.Metadata{NumNodes: numNodes}
 	msg := message.NewWithMetadata(topics.MemPool, buf, &metadata)
 	m.eventBus.Publish(topics.KadcastSendToMany, msg)
 }
 
+// setReady marks the mempool as ready to provide txs.
+func (m *Mempool) setReady() {
+	if atomic.CompareAndSwapInt32(&m.ready, 0, 1) {
+		log.Info("mempool ready")
+	}
+}
+
+// processGetReadyRequest tells whether the mempool is ready to provide txs,
+// i.e. the network peers have started sending theirs, or did not respond in
+// time.
+func (m *Mempool) processGetReadyRequest(rpcbus.Request) (interface{}, error) {
+	return atomic.LoadInt32(&m.ready) == 1, nil
+}
+
 // OnClose performs mempool cleanup procedure. It's called on canceling mempool
 // context.
 func (m *Mempool) OnClose() {
+	if path := config.Get().Mempool.PersistFile; len(path) > 0 {
+		if err := m.Persist(path); err != nil {
+			log.WithError(err).Error("failed to persist mempool")
+		}
+	}
+
 	// Closing diskpool backend commits changes to file and close it.
 	m.verified.Close()
 }
@@ -564,9 +895,14 @@ func handleRequest(r rpcbus.Request, handler func(r rpcbus.Request) (interface{}
 		log.
 			WithError(err).
 			WithField("name", name).Errorf("mempool failed to process request")
-		r.RespChan <- rpcbus.Response{Err: err}
-		return
+
+		result = nil
 	}
 
-	r.RespChan <- rpcbus.Response{Resp: result, Err: nil}
+	timeout := time.Duration(config.Get().Timeout.TimeoutSendResponse) * time.Millisecond
+	if err := r.Respond(rpcbus.Response{Resp: result, Err: err}, timeout); err != nil {
+		log.
+			WithError(err).
+			WithField("name", name).Warn("mempool failed to send response")
+	}
 }
@@ -11,6 +11,7 @@ import (
 	"context"
 	"math"
 	"os"
+	"path/filepath"
 	"sync"
 	"testing"
 	"time"
@@ -19,6 +20,7 @@ import (
 	"github.com/sirupsen/logrus"
 
 	"github.com/dusk-network/dusk-blockchain/pkg/core/data/ipc/transactions"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/database"
 	"github.com/dusk-network/dusk-blockchain/pkg/core/database/lite"
 	"github.com/dusk-network/dusk-blockchain/pkg/core/tests/helper"
 	"github.com/dusk-network/dusk-blockchain/pkg/p2p/wire/message"
@@ -26,6 +28,8 @@ import (
 	"github.com/dusk-network/dusk-blockchain/pkg/util/nativeutils/eventbus"
 	"github.com/dusk-network/dusk-blockchain/pkg/util/nativeutils/rpcbus"
 	assert "github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 func TestMain(m *testing.M) {
@@ -217,6 +221,270 @@ func TestRemoveAccepted(t *testing.T) {
 	}
 }
 
+func TestResubmitEvictedTxs(t *testing.T) {
+	assert := assert.New(t)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	m, bus, _, streamer := startMempoolTest(ctx)
+
+	// Simulate a reorg evicting three txs, one of which is also part of the
+	// new branch
+	txs := transactions.RandContractCalls(3, 0, false)
+	reorg := message.ChainReorg{
+		Evicted:  txs,
+		Accepted: []transactions.ContractCall{txs[2]},
+	}
+
+	errList := bus.Publish(topics.ChainReorg, message.New(topics.ChainReorg, reorg))
+	assert.Empty(errList)
+
+	// The resubmitted txs are propagated once stored in the mempool
+	for i := 0; i < 2; i++ {
+		_, err := streamer.Read()
+		assert.NoError(err)
+	}
+
+	assert.Equal(2, m.verified.Len())
+
+	for i, tx := range txs {
+		hash, err := tx.CalculateHash()
+		assert.NoError(err)
+		assert.Equal(i < 2, m.verified.Contain(hash))
+	}
+}
+
+func TestGetTxStatus(t *testing.T) {
+	assert := assert.New(t)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	m, _, rb, _ := startMempoolTest(ctx)
+
+	txs := transactions.RandContractCalls(3, 0, false)
+
+	// Pending tx
+	_, err := m.ProcessTx("", message.New(topics.Tx, txs[0]))
+	assert.NoError(err)
+
+	// Confirmed tx, with two blocks accepted on top of it
+	b := helper.RandomBlock(5, 0)
+	b.Txs = []transactions.ContractCall{txs[1]}
+	tip := helper.RandomBlock(7, 0)
+
+	assert.NoError(m.db.Update(func(t database.Transaction) error {
+		if err := t.StoreBlock(b, false); err != nil {
+			return err
+		}
+
+		return t.StoreBlock(tip, true)
+	}))
+
+	getStatus := func(tx transactions.ContractCall) TxStatus {
+		txid, err := tx.CalculateHash()
+		assert.NoError(err)
+
+		resp, err := rb.Call(topics.GetTxStatus, rpcbus.NewRequest(*bytes.NewBuffer(txid)), 1*time.Second)
+		assert.NoError(err)
+
+		return resp.(TxStatus)
+	}
+
+	assert.Equal(TxStatus{State: TxPending}, getStatus(txs[0]))
+	assert.Equal(TxStatus{State: TxConfirmed, Height: 5, Confirmations: 2}, getStatus(txs[1]))
+	assert.Equal(TxStatus{State: TxUnknown}, getStatus(txs[2]))
+}
+
+func TestReconcileMempool(t *testing.T) {
+	assert := assert.New(t)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	m, _, rb, _ := startMempoolTest(ctx)
+
+	txs := transactions.RandContractCalls(2, 0, false)
+	for _, tx := range txs {
+		_, err := m.ProcessTx("", message.New(topics.Tx, tx))
+		assert.NoError(err)
+	}
+
+	// The first tx gets confirmed without the mempool being notified
+	b := helper.RandomBlock(5, 0)
+	b.Txs = []transactions.ContractCall{txs[0]}
+
+	assert.NoError(m.db.Update(func(t database.Transaction) error {
+		return t.StoreBlock(b, true)
+	}))
+
+	resp, err := rb.Call(topics.ReconcileMempool, rpcbus.NewRequest(bytes.Buffer{}), 1*time.Second)
+	assert.NoError(err)
+	assert.Equal(1, resp.(int))
+
+	assert.Equal(1, m.verified.Len())
+
+	for i, tx := range txs {
+		hash, err := tx.CalculateHash()
+		assert.NoError(err)
+		assert.Equal(i == 1, m.verified.Contain(hash))
+	}
+}
+
+// failingProber fails the verification of some txs with the given error.
+type failingProber struct {
+	errs map[string]error
+}
+
+func (p failingProber) Preverify(_ context.Context, tx transactions.ContractCall) ([]byte, transactions.Fee, error) {
+	hash, _ := tx.CalculateHash()
+	return hash, transactions.Fee{}, p.errs[string(hash)]
+}
+
+func TestReconcileMempoolVerifierFailure(t *testing.T) {
+	assert := assert.New(t)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	m, _, rb, _ := startMempoolTest(ctx)
+
+	txs := transactions.RandContractCalls(3, 0, false)
+	hashes := make([][]byte, len(txs))
+
+	for i, tx := range txs {
+		_, err := m.ProcessTx("", message.New(topics.Tx, tx))
+		assert.NoError(err)
+
+		hashes[i], _ = tx.CalculateHash()
+	}
+
+	// Only the tx rejected by the verifier is dropped, not the one which
+	// could not be verified
+	m.verifier = failingProber{errs: map[string]error{
+		string(hashes[0]): status.Error(codes.InvalidArgument, "invalid tx"),
+		string(hashes[1]): status.Error(codes.Unavailable, "connection refused"),
+	}}
+
+	resp, err := rb.Call(topics.ReconcileMempool, rpcbus.NewRequest(bytes.Buffer{}), 1*time.Second)
+	assert.NoError(err)
+	assert.Equal(1, resp.(int))
+
+	for i, hash := range hashes {
+		assert.Equal(i != 0, m.verified.Contain(hash))
+	}
+}
+
+func TestMempoolReady(t *testing.T) {
+	assert := assert.New(t)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	m, _, rb, _ := startMempoolTest(ctx)
+
+	resp, err := rb.Call(topics.GetMempoolReady, rpcbus.EmptyRequest(), 1*time.Second)
+	assert.NoError(err)
+	assert.False(resp.(bool))
+
+	ready := func() bool {
+		resp, err := rb.Call(topics.GetMempoolReady, rpcbus.EmptyRequest(), 1*time.Second)
+		assert.NoError(err)
+
+		return resp.(bool)
+	}
+
+	// The mempool is ready once a tx is received from the peers asked for
+	// theirs
+	m.updatesWait = time.Hour
+	m.RequestUpdates()
+	assert.False(ready())
+
+	_, err = m.ProcessTx("peer", message.New(topics.Tx, transactions.RandContractCall()))
+	assert.NoError(err)
+	assert.True(ready())
+}
+
+func TestMempoolReadyWithoutUpdates(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	m, _, rb, _ := startMempoolTest(ctx)
+
+	// The mempool is ready after a while if no peer responds
+	m.updatesWait = 10 * time.Millisecond
+	m.RequestUpdates()
+
+	assert.Eventually(t, func() bool {
+		resp, err := rb.Call(topics.GetMempoolReady, rpcbus.EmptyRequest(), 1*time.Second)
+		return err == nil && resp.(bool)
+	}, 5*time.Second, 10*time.Millisecond)
+}
+
+func TestHandleAbandonedRequest(t *testing.T) {
+	handler := func(r rpcbus.Request) (interface{}, error) {
+		return nil, nil
+	}
+
+	// The requester does not read the response
+	r := rpcbus.Request{Params: bytes.Buffer{}, RespChan: make(chan rpcbus.Response)}
+
+	done := make(chan struct{})
+
+	go func() {
+		handleRequest(r, handler, "test")
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("handler blocked on an abandoned request")
+	}
+}
+
+func TestPersistRestore(t *testing.T) {
+	assert := assert.New(t)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	m, _, _, _ := startMempoolTest(ctx)
+
+	txs := transactions.RandContractCalls(3, 0, false)
+	for _, tx := range txs {
+		_, err := m.ProcessTx("", message.New(topics.Tx, tx))
+		assert.NoError(err)
+	}
+
+	path := filepath.Join(t.TempDir(), "mempool.dat")
+	assert.NoError(m.Persist(path))
+
+	// Restore into a fresh mempool, whose chain already includes one of the
+	// persisted txs
+	restored, _, _, _ := startMempoolTest(ctx)
+
+	b := helper.RandomBlock(1, 0)
+	b.Txs = []transactions.ContractCall{txs[2]}
+
+	assert.NoError(restored.db.Update(func(t database.Transaction) error {
+		return t.StoreBlock(b, true)
+	}))
+
+	assert.NoError(restored.Restore(path))
+	assert.Equal(2, restored.verified.Len())
+
+	for i, tx := range txs {
+		hash, err := tx.CalculateHash()
+		assert.NoError(err)
+		assert.Equal(i < 2, restored.verified.Contain(hash))
+	}
+
+	// A missing file is not an error
+	assert.NoError(restored.Restore(filepath.Join(t.TempDir(), "missing.dat")))
+}
+
 func BenchmarkProcessTx_0(b *testing.B) {
 	// Recent result
 	// BenchmarkProcessTx_0-8             50475             33671 ns/op
@@ -0,0 +1,113 @@
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT License was not distributed with this
+// file, you can obtain one at https://opensource.org/licenses/MIT.
+//
+// Copyright (c) DUSK NETWORK. All rights reserved.
+
+package mempool
+
+import (
+	"bytes"
+	"io/ioutil"
+	"math"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/dusk-network/dusk-blockchain/pkg/core/data/ipc/transactions"
+	"github.com/dusk-network/dusk-blockchain/pkg/p2p/wire/encoding"
+)
+
+// Persist writes all pending transactions to the file at path, so that they
+// can be reloaded with Restore after a restart. The file is written
+// atomically.
+func (m *Mempool) Persist(path string) error {
+	buf := new(bytes.Buffer)
+
+	if err := encoding.WriteUint32LE(buf, uint32(m.verified.Len())); err != nil {
+		return err
+	}
+
+	err := m.verified.Range(func(k txHash, t TxDesc) error {
+		txBuf := new(bytes.Buffer)
+		if err := transactions.Marshal(txBuf, t.tx); err != nil {
+			return err
+		}
+
+		return encoding.WriteVarBytes(buf, txBuf.Bytes())
+	})
+	if err != nil {
+		return err
+	}
+
+	tmpPath := path + ".tmp"
+	if err := ioutil.WriteFile(tmpPath, buf.Bytes(), 0o600); err != nil {
+		return err
+	}
+
+	if err := os.Rename(tmpPath, path); err != nil {
+		return err
+	}
+
+	log.WithField("path", path).
+		WithField("txs_count", m.verified.Len()).
+		Info("mempool persisted")
+
+	return nil
+}
+
+// Restore loads the transactions persisted at path into the mempool. Each
+// transaction is verified again, and dropped if no longer valid. A missing
+// file is not an error.
+func (m *Mempool) Restore(path string) error {
+	data, err := ioutil.ReadFile(filepath.Clean(path))
+	if os.IsNotExist(err) {
+		return nil
+	}
+
+	if err != nil {
+		return err
+	}
+
+	buf := bytes.NewBuffer(data)
+
+	var count uint32
+	if err := encoding.ReadUint32LE(buf, &count); err != nil {
+		return err
+	}
+
+	var restored int
+
+	for i := uint32(0); i < count; i++ {
+		var txBytes []byte
+		if err := encoding.ReadVarBytes(buf, &txBytes); err != nil {
+			return err
+		}
+
+		tx := transactions.NewTransaction()
+		if err := transactions.Unmarshal(bytes.NewBuffer(txBytes), tx); err != nil {
+			return err
+		}
+
+		t := TxDesc{
+			tx:        tx,
+			received:  time.Now(),
+			size:      uint(len(txBytes)),
+			kadHeight: math.MaxUint8,
+		}
+
+		if txid, err := m.processTx(t); err != nil {
+			log.WithError(err).WithField("txid", toHex(txid)).Debug("discard persisted tx")
+			continue
+		}
+
+		restored++
+	}
+
+	log.WithField("path", path).
+		WithField("persisted", count).
+		WithField("restored", restored).
+		Info("mempool restored")
+
+	return nil
+}
@@ -9,6 +9,7 @@ package verifiers
 import (
 	"bytes"
 	"errors"
+	"fmt"
 
 	"github.com/dusk-network/dusk-blockchain/pkg/config"
 	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus/agreement"
@@ -16,6 +17,8 @@ import (
 	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus/user"
 
 	"github.com/dusk-network/dusk-blockchain/pkg/core/data/block"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/data/ipc/transactions"
+	"github.com/dusk-network/dusk-blockchain/pkg/util"
 )
 
 // ErrPrevBlockHash previous block hash does not equal the previous hash in the current block.
@@ -24,6 +27,82 @@ var ErrPrevBlockHash = errors.New("previous block hash does not equal the previo
 // ErrInvalidBlockHash hashed set of block header fields is not equal to block.header.hash.
 var ErrInvalidBlockHash = errors.New("invalid block hash")
 
+// ErrNotGenesisBlock block height is not the genesis height.
+var ErrNotGenesisBlock = errors.New("not a genesis block")
+
+// ErrUnsupportedVersion block format version is not supported by this node.
+var ErrUnsupportedVersion = errors.New("unsupported block version")
+
+// ErrFutureTimestamp block timestamp is too far ahead of the previous block one.
+var ErrFutureTimestamp = errors.New("current timestamp is bigger than the prev timestamp + maxblocktime")
+
+// BlockError is the failure of a block to pass its verification. It only
+// depends on the block and its predecessor, hence it is not transient.
+type BlockError struct {
+	Hash []byte
+	Err  error
+}
+
+// NewBlockError returns a *BlockError of blk, wrapping err.
+func NewBlockError(blk block.Block, err error) error {
+	return &BlockError{Hash: blk.Header.Hash, Err: err}
+}
+
+func (e *BlockError) Error() string {
+	return fmt.Sprintf("invalid block %s: %v", util.StringifyBytes(e.Hash), e.Err)
+}
+
+// Unwrap returns the verification error.
+func (e *BlockError) Unwrap() error {
+	return e.Err
+}
+
+// IsBlockError returns true if err is a verification failure of the block
+// with the given hash.
+func IsBlockError(err error, hash []byte) bool {
+	var bErr *BlockError
+	return errors.As(err, &bErr) && bytes.Equal(bErr.Hash, hash)
+}
+
+// ErrBlockGasExceeded gas spent by the block transactions exceeds the block gas limit.
+var ErrBlockGasExceeded = errors.New("block gas limit exceeded")
+
+// CheckBlockGas ensures that the gas spent by the transactions of a block,
+// as reported by their execution, does not exceed the block gas limit.
+// Transactions which spend no gas (e.g coinbase) do not count towards the
+// limit.
+func CheckBlockGas(gasLimit uint64, txs []transactions.ContractCall) error {
+	var spent uint64
+
+	for i, tx := range txs {
+		gas := tx.GasSpent()
+
+		// spent <= gasLimit, hence the check below can not overflow
+		if gas > gasLimit-spent {
+			return fmt.Errorf("%w: tx %d spends %d, with %d already spent out of %d", ErrBlockGasExceeded, i, gas, spent, gasLimit)
+		}
+
+		spent += gas
+	}
+
+	return nil
+}
+
+// ErrTooManyStakes a provisioner holds more stakes than allowed.
+var ErrTooManyStakes = errors.New("too many stakes per provisioner")
+
+// CheckProvisionerStakes ensures that no provisioner holds more than
+// config.MaxStakesPerProvisioner stakes.
+func CheckProvisionerStakes(p user.Provisioners) error {
+	for _, m := range p.Members {
+		if n := len(m.Stakes); n > config.MaxStakesPerProvisioner {
+			return fmt.Errorf("%w: %d stakes, %d allowed, provisioner %s", ErrTooManyStakes, n, config.MaxStakesPerProvisioner, util.StringifyBytes(m.PublicKeyBLS))
+		}
+	}
+
+	return nil
+}
+
 // CheckBlockCertificate ensures that the block certificate is valid.
 func CheckBlockCertificate(provisioners user.Provisioners, blk block.Block, seed []byte) error {
 	// TODO: this should be set back to 1, once we fix this issue:
@@ -73,15 +152,32 @@ func committeeSize(memberAmount int) int {
 // These are stateless and stateful checks.
 // Returns nil, if all checks pass.
 func CheckBlockHeader(prevBlock block.Block, blk block.Block) error {
-	// Version
-	if blk.Header.Version > 0 {
-		return errors.New("unsupported block version")
+	if err := checkHeaderStructure(blk); err != nil {
+		return err
 	}
 
-	if err := CheckHash(&blk); err != nil {
+	if err := CheckBlockLinkage(prevBlock, blk); err != nil {
 		return err
 	}
 
+	// blk.Timestamp > prevTimestamp
+	if blk.Header.Timestamp < prevBlock.Header.Timestamp {
+		return errors.New("current timestamp is less than the previous timestamp")
+	}
+
+	if blk.Header.Height > 1 {
+		if blk.Header.Timestamp > prevBlock.Header.Timestamp+config.MaxBlockTime {
+			return ErrFutureTimestamp
+		}
+
+	}
+
+	return nil
+}
+
+// CheckBlockLinkage checks that a block directly follows prevBlock, by height
+// and by hash. It does not check the block itself.
+func CheckBlockLinkage(prevBlock block.Block, blk block.Block) error {
 	// blk.Headerheight = prevHeaderHeight +1
 	if blk.Header.Height != prevBlock.Header.Height+1 {
 		return errors.New("invalid block height")
@@ -92,15 +188,29 @@ func CheckBlockHeader(prevBlock block.Block, blk block.Block) error {
 		return ErrPrevBlockHash
 	}
 
-	// blk.Timestamp > prevTimestamp
-	if blk.Header.Timestamp < prevBlock.Header.Timestamp {
-		return errors.New("current timestamp is less than the previous timestamp")
+	return nil
+}
+
+// CheckGenesisBlockHeader performs the structural checks of CheckBlockHeader
+// on a genesis block. As there is no previous block, the height and prev-hash
+// links are not checked.
+func CheckGenesisBlockHeader(blk block.Block) error {
+	if blk.Header.Height != 0 {
+		return ErrNotGenesisBlock
 	}
 
-	if blk.Header.Height > 1 {
-		if blk.Header.Timestamp > prevBlock.Header.Timestamp+config.MaxBlockTime {
-			return errors.New("current timestamp is bigger than the prev timestamp + maxblocktime")
-		}
+	return checkHeaderStructure(blk)
+}
+
+// checkHeaderStructure performs the stateless checks on a block header.
+func checkHeaderStructure(blk block.Block) error {
+	// Version
+	if !block.IsSupportedVersion(blk.Header.Version) {
+		return ErrUnsupportedVersion
+	}
+
+	if err := CheckHash(&blk); err != nil {
+		return err
 	}
 
 	if len(blk.Header.StateHash) != 32 {
@@ -7,9 +7,13 @@
 package verifiers
 
 import (
+	"fmt"
+	"math"
 	"testing"
 
 	"github.com/dusk-network/dusk-blockchain/pkg/config"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus/key"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus/user"
 	"github.com/dusk-network/dusk-blockchain/pkg/core/data/block"
 	"github.com/dusk-network/dusk-blockchain/pkg/core/data/ipc/transactions"
 	"github.com/dusk-network/dusk-blockchain/pkg/core/tests/helper"
@@ -58,3 +62,59 @@ func TestTimestamp(t *testing.T) {
 	pb, b = twoLinkedBlocks(t, -10000)
 	a.NotNil(CheckBlockHeader(*pb, *b))
 }
+
+func TestBlockGas(t *testing.T) {
+	a := assert.New(t)
+
+	txs := []transactions.ContractCall{
+		// zero gas tx (e.g coinbase)
+		transactions.MockTxWithParams(transactions.Transfer, 0),
+		transactions.MockTxWithParams(transactions.Transfer, 300),
+		transactions.MockTxWithParams(transactions.Transfer, 700),
+	}
+
+	// Exactly at the limit
+	a.NoError(CheckBlockGas(1000, txs))
+
+	// Over the limit
+	a.ErrorIs(CheckBlockGas(999, txs), ErrBlockGasExceeded)
+
+	// Sum overflowing uint64
+	txs = append(txs, transactions.MockTxWithParams(transactions.Transfer, math.MaxUint64))
+	a.ErrorIs(CheckBlockGas(math.MaxUint64, txs), ErrBlockGasExceeded)
+
+	a.NoError(CheckBlockGas(0, nil))
+}
+
+func TestIsBlockError(t *testing.T) {
+	a := assert.New(t)
+
+	blk := helper.RandomBlock(1, 0)
+	err := fmt.Errorf("accept failed: %w", NewBlockError(*blk, ErrInvalidBlockHash))
+
+	a.True(IsBlockError(err, blk.Header.Hash))
+	a.ErrorIs(err, ErrInvalidBlockHash)
+
+	// Another block, or an error which is not a verification failure
+	a.False(IsBlockError(err, helper.RandomBlock(1, 0).Header.Hash))
+	a.False(IsBlockError(ErrInvalidBlockHash, blk.Header.Hash))
+	a.False(IsBlockError(nil, blk.Header.Hash))
+}
+
+func TestProvisionerStakes(t *testing.T) {
+	a := assert.New(t)
+
+	k := key.NewRandKeys().BLSPubKey
+	p := user.NewProvisioners()
+	a.NoError(p.Add(k, 1, 1, 1, 0))
+
+	m := p.Members[string(k)]
+	for len(m.Stakes) < config.MaxStakesPerProvisioner {
+		m.AddStake(user.Stake{Value: 1, Reward: 1, Counter: 1})
+	}
+
+	a.NoError(CheckProvisionerStakes(*p))
+
+	m.AddStake(user.Stake{Value: 1, Reward: 1, Counter: 1})
+	a.ErrorIs(CheckProvisionerStakes(*p), ErrTooManyStakes)
+}
@@ -8,6 +8,7 @@ package gql
 
 import (
 	"context"
+	"errors"
 	"net"
 	"net/http"
 	"os"
@@ -32,6 +33,8 @@ import (
 
 var log = logger.WithFields(logger.Fields{"process": "gql"})
 
+var errTooManyQueries = errors.New("too many concurrent queries, retry later")
+
 const (
 	endpointWS  = "/ws"
 	endpointWSS = "/wss"
@@ -45,6 +48,9 @@ type Server struct {
 	httpServer *http.Server
 	lmt        *limiter.Limiter
 
+	// Semaphore of the queries being executed. Nil if unlimited.
+	queries chan struct{}
+
 	// Graphql utility.
 	schema *graphql.Schema
 
@@ -59,7 +65,8 @@ type Server struct {
 
 // NewHTTPServer instantiates a new NewHTTPServer to handle GraphQL queries.
 func NewHTTPServer(eventBus *eventbus.EventBus, rpcBus *rpcbus.RPCBus) (*Server, error) {
-	max := float64(cfg.Get().Gql.MaxRequestLimit)
+	conf := cfg.Get().Gql
+	max := float64(conf.MaxRequestLimit)
 
 	srv := Server{
 		eventBus: eventBus,
@@ -67,6 +74,10 @@ func NewHTTPServer(eventBus *eventbus.EventBus, rpcBus *rpcbus.RPCBus) (*Server,
 		lmt:      tollbooth.NewLimiter(max, nil),
 	}
 
+	if conf.MaxConcurrentQueries > 0 {
+		srv.queries = make(chan struct{}, conf.MaxConcurrentQueries)
+	}
+
 	return &srv, nil
 }
 
@@ -146,7 +157,7 @@ func (s *Server) EnableGraphQL(serverMux *http.ServeMux) error {
 		handleQuery(s.schema, w, r, s.db)
 	}
 
-	middleware := tollbooth.LimitFuncHandler(s.lmt, gqlHandler)
+	middleware := tollbooth.LimitFuncHandler(s.lmt, s.limitQueries(gqlHandler))
 	serverMux.Handle(endpointGQL, middleware)
 
 	//  Setup graphQL
@@ -164,6 +175,27 @@ func (s *Server) EnableGraphQL(serverMux *http.ServeMux) error {
 	return nil
 }
 
+// limitQueries rejects the queries exceeding Gql.MaxConcurrentQueries, so
+// that the resolvers do not overwhelm the node components they query.
+func (s *Server) limitQueries(next http.HandlerFunc) http.HandlerFunc {
+	if s.queries == nil {
+		return next
+	}
+
+	return func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case s.queries <- struct{}{}:
+			defer func() { <-s.queries }()
+		default:
+			log.WithField("max", cap(s.queries)).Warn("too many concurrent queries")
+			http.Error(w, errTooManyQueries.Error(), http.StatusServiceUnavailable)
+			return
+		}
+
+		next(w, r)
+	}
+}
+
 // EnableNotifications uses the configured amount of brokers and clients (per
 // broker) to push graphql notifications over websocket.
 func (s *Server) EnableNotifications(serverMux *http.ServeMux) error {
@@ -9,7 +9,11 @@ package gql
 import (
 	"context"
 	"crypto/tls"
+	"net/http"
+	"net/http/httptest"
 	"net/url"
+	"sync"
+	"sync/atomic"
 	"syscall"
 	"testing"
 	"time"
@@ -100,6 +104,76 @@ func TestMultiClient(t *testing.T) {
 	}
 }
 
+func TestMaxConcurrentQueries(t *testing.T) {
+	assert := assert.New(t)
+
+	orig := config.Get()
+	defer config.Mock(&orig)
+
+	r := config.Registry{}
+	r.Gql.MaxConcurrentQueries = 2
+	config.Mock(&r)
+
+	s, err := NewHTTPServer(eventbus.New(), rpcbus.New())
+	assert.NoError(err)
+
+	// Queries block until released
+	var running, maxRunning int32
+
+	release := make(chan struct{})
+	handler := s.limitQueries(func(w http.ResponseWriter, _ *http.Request) {
+		n := atomic.AddInt32(&running, 1)
+		defer atomic.AddInt32(&running, -1)
+
+		for {
+			m := atomic.LoadInt32(&maxRunning)
+			if n <= m || atomic.CompareAndSwapInt32(&maxRunning, m, n) {
+				break
+			}
+		}
+
+		<-release
+	})
+
+	const queries = 5
+
+	codes := make(chan int, queries)
+
+	var wg sync.WaitGroup
+
+	for i := 0; i < queries; i++ {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			rec := httptest.NewRecorder()
+			handler(rec, httptest.NewRequest(http.MethodPost, endpointGQL, nil))
+			codes <- rec.Code
+		}()
+	}
+
+	// The queries above the limit are rejected straight away
+	for i := 0; i < queries-2; i++ {
+		select {
+		case code := <-codes:
+			assert.Equal(http.StatusServiceUnavailable, code)
+		case <-time.After(5 * time.Second):
+			assert.FailNow("queries above the limit not rejected")
+		}
+	}
+
+	close(release)
+	wg.Wait()
+	close(codes)
+
+	for code := range codes {
+		assert.Equal(http.StatusOK, code)
+	}
+
+	assert.Equal(int32(2), atomic.LoadInt32(&maxRunning))
+}
+
 func createClient(addr string, resp chan string, enableTLS bool) error {
 	dialCtx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
 	defer cancel()
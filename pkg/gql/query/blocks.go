@@ -51,6 +51,84 @@ type (
 	}
 )
 
+type (
+	// decodedBlock is a block with all its fields decoded, for block
+	// explorers.
+	decodedBlock struct {
+		Header       decodedHeader
+		Certificate  *block.Certificate
+		Transactions []decodedTx
+	}
+
+	decodedHeader struct {
+		Version            uint8
+		Height             uint64
+		Timestamp          string // RFC3339
+		GasLimit           uint64
+		Hash               []byte
+		PrevBlockHash      []byte
+		Seed               []byte
+		GeneratorBlsPubkey []byte
+		StateHash          []byte
+	}
+
+	decodedTx struct {
+		TxID         []byte
+		TxType       core.TxType
+		GasLimit     uint64
+		GasPrice     uint64
+		GasSpent     uint64
+		Fee          uint64
+		TxError      string
+		ContractInfo *contractInfo
+		Outputs      []*core.Note
+	}
+)
+
+func newDecodedBlock(b *block.Block) (*decodedBlock, error) {
+	d := &decodedBlock{
+		Header: decodedHeader{
+			Version:            b.Header.Version,
+			Height:             b.Header.Height,
+			Timestamp:          time.Unix(b.Header.Timestamp, 0).UTC().Format(time.RFC3339),
+			GasLimit:           b.Header.GasLimit,
+			Hash:               b.Header.Hash,
+			PrevBlockHash:      b.Header.PrevBlockHash,
+			Seed:               b.Header.Seed,
+			GeneratorBlsPubkey: b.Header.GeneratorBlsPubkey,
+			StateHash:          b.Header.StateHash,
+		},
+		Certificate:  b.Header.Certificate,
+		Transactions: make([]decodedTx, 0, len(b.Txs)),
+	}
+
+	for _, tx := range b.Txs {
+		qd, err := newQueryTx(tx, b.Header.Hash, b.Header.Timestamp, b.Header.Height)
+		if err != nil {
+			return nil, err
+		}
+
+		decoded, err := tx.Decode()
+		if err != nil {
+			return nil, err
+		}
+
+		d.Transactions = append(d.Transactions, decodedTx{
+			TxID:         qd.TxID,
+			TxType:       qd.TxType,
+			GasLimit:     qd.GasLimit,
+			GasPrice:     qd.GasPrice,
+			GasSpent:     qd.GasSpent,
+			Fee:          qd.GasSpent * qd.GasPrice,
+			TxError:      qd.TxError,
+			ContractInfo: qd.ContractInfo,
+			Outputs:      decoded.Notes,
+		})
+	}
+
+	return d, nil
+}
+
 func newQueryBlock(b *block.Block) queryBlock {
 	qb := queryBlock{}
 	qb.Txs = b.Txs
@@ -160,6 +238,110 @@ func (b blocks) resolve(p graphql.ResolveParams) (interface{}, error) {
 	return nil, nil
 }
 
+func (b blocks) getMetadataQuery() *graphql.Field {
+	return &graphql.Field{
+		Type: BlockMetadata,
+		Args: graphql.FieldConfigArgument{
+			blockHeightArg: &graphql.ArgumentConfig{
+				Type: graphql.NewNonNull(graphql.Int),
+			},
+		},
+		Resolve: b.resolveMetadata,
+	}
+}
+
+func (b blocks) resolveMetadata(p graphql.ResolveParams) (interface{}, error) {
+	// Retrieve DB conn from context
+	db, ok := p.Context.Value("database").(database.DB)
+	if !ok {
+		return nil, errors.New("context does not store database conn")
+	}
+
+	height, ok := p.Args[blockHeightArg].(int)
+	if !ok || height < 0 {
+		return nil, errors.New("invalid ``" + blockHeightArg + "`` argument")
+	}
+
+	return b.fetchBlockMetadata(db, uint64(height))
+}
+
+func (b blocks) getDecodedQuery() *graphql.Field {
+	return &graphql.Field{
+		Type: DecodedBlock,
+		Args: graphql.FieldConfigArgument{
+			blockHashArg: &graphql.ArgumentConfig{
+				Type: graphql.String,
+			},
+			blockHeightArg: &graphql.ArgumentConfig{
+				Type: graphql.Int,
+			},
+		},
+		Resolve: b.resolveDecoded,
+	}
+}
+
+func (b blocks) resolveDecoded(p graphql.ResolveParams) (interface{}, error) {
+	// Retrieve DB conn from context
+	db, ok := p.Context.Value("database").(database.DB)
+	if !ok {
+		return nil, errors.New("context does not store database conn")
+	}
+
+	var hash []byte
+
+	if h, ok := p.Args[blockHashArg].(string); ok {
+		decoded, err := hex.DecodeString(h)
+		if err != nil {
+			return nil, errors.New("invalid ``" + blockHashArg + "`` argument")
+		}
+
+		hash = decoded
+	} else if height, ok := p.Args[blockHeightArg].(int); !ok || height < 0 {
+		return nil, errors.New("either ``" + blockHashArg + "`` or ``" + blockHeightArg + "`` argument expected")
+	}
+
+	var blk *block.Block
+
+	err := db.View(func(t database.Transaction) error {
+		var err error
+
+		if hash == nil {
+			hash, err = t.FetchBlockHashByHeight(uint64(p.Args[blockHeightArg].(int)))
+			if err != nil {
+				return err
+			}
+		}
+
+		blk, err = t.FetchBlock(hash)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return newDecodedBlock(blk)
+}
+
+// Fetch the metadata stored on accepting the block at a height.
+func (b blocks) fetchBlockMetadata(db database.DB, height uint64) (*database.BlockMetadata, error) {
+	var m database.BlockMetadata
+
+	err := db.View(func(t database.Transaction) error {
+		hash, err := t.FetchBlockHashByHeight(height)
+		if err != nil {
+			return err
+		}
+
+		m, err = t.FetchBlockMetadata(hash)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &m, nil
+}
+
 func resolveTxs(p graphql.ResolveParams) (interface{}, error) {
 	txs := make([]queryTx, 0)
 
@@ -192,31 +374,6 @@ func resolveTxs(p graphql.ResolveParams) (interface{}, error) {
 	return nil, errors.New("invalid source block")
 }
 
-/// This implements the emission schedule described in the economic paper.
-func emissionAmount(blockHeight uint64) uint64 {
-	switch {
-	case blockHeight == 0: // genesis block
-		return uint64(0)
-	case blockHeight <= 12_500_000: // first 12_500_000 blocks emit 16 DUSK
-		return uint64(16 * 1_000_000_000)
-	case blockHeight <= 18_750_000: // from 12_500_001 to 18_750_000 blocks emit 12.8 DUSK
-		return uint64(12.8 * 1_000_000_000)
-	case blockHeight <= 25_000_000: // from 18_750_001 to 25_000_000 blocks emit 9.6 DUSK
-		return uint64(9.6 * 1_000_000_000)
-	case blockHeight <= 31_250_000: // from 25_000_001 to 31_250_000 blocks emit 8 DUSK
-		return uint64(8 * 1_000_000_000)
-	case blockHeight <= 37_500_000: // from 31_250_001 to 37_500_000 blocks emit 6.4 DUSK
-		return uint64(6.4 * 1_000_000_000)
-	case blockHeight <= 43_750_000: // from 37_500_001 to 43_750_000 blocks emit 4.8 DUSK
-		return uint64(4.8 * 1_000_000_000)
-	case blockHeight <= 50_000_000: // from 43_750_001 to 50_000_000 blocks emit 3.2 DUSK
-		return uint64(3.2 * 1_000_000_000)
-	case blockHeight <= 62_500_000: // from 50_000_001 to 62_500_000 blocks emit 1.6 DUSK
-		return uint64(1.6 * 1_000_000_000)
-	}
-	return uint64(0) // after 62_500_000 blocks emit 0 DUSK
-}
-
 func resolveReward(p graphql.ResolveParams) (interface{}, error) {
 	b, ok := p.Source.(*queryHeader)
 	if ok {
@@ -226,7 +383,7 @@ func resolveReward(p graphql.ResolveParams) (interface{}, error) {
 			return nil, errors.New("context does not store database conn")
 		}
 
-		reward := emissionAmount(b.Height)
+		reward := block.Emission(b.Height)
 		err := db.View(func(t database.Transaction) error {
 			fetched, err := t.FetchBlockTxs(b.Hash)
 			if err != nil {
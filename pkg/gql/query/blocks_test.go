@@ -7,8 +7,14 @@
 package query
 
 import (
+	"encoding/hex"
+	"encoding/json"
 	"fmt"
 	"testing"
+
+	"github.com/dusk-network/dusk-blockchain/pkg/core/data/block"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/database"
+	assert "github.com/stretchr/testify/require"
 )
 
 func TestBlocksByHeight(t *testing.T) {
@@ -259,7 +265,7 @@ func TestBlocksTxsQuery(t *testing.T) {
 func TestBlocksByDate(t *testing.T) {
 	query := `
 	{
-	   blocks (since:  "1970-01-01T00:00:20+00:00" )     
+	   blocks (since:  "2022-03-09T16:10:42+00:00" )     
 		{
 			 header
 			 {
@@ -284,3 +290,93 @@ func TestBlocksByDate(t *testing.T) {
 	`
 	assertQuery(t, query, response)
 }
+
+func TestDecodedBlock(t *testing.T) {
+	assert := assert.New(t)
+
+	var blk *block.Block
+
+	assert.NoError(db.View(func(t database.Transaction) error {
+		hash, err := hex.DecodeString(block2)
+		if err != nil {
+			return err
+		}
+
+		blk, err = t.FetchBlock(hash)
+		return err
+	}))
+
+	decoded, err := blk.Txs[0].Decode()
+	assert.NoError(err)
+
+	outputs := make([]map[string]interface{}, 0)
+	for _, n := range decoded.Notes {
+		outputs = append(outputs, map[string]interface{}{
+			"type":            n.Type,
+			"valuecommitment": hex.EncodeToString(n.ValueCommitment),
+		})
+	}
+
+	cert := blk.Header.Certificate
+	decodedBlock := map[string]interface{}{
+		"header": map[string]interface{}{
+			"height":        1,
+			"timestamp":     "2022-03-09T16:10:42Z",
+			"hash":          block2,
+			"prevblockhash": hex.EncodeToString(blk.Header.PrevBlockHash),
+		},
+		"certificate": map[string]interface{}{
+			"step":              cert.Step,
+			"steponebatchedsig": hex.EncodeToString(cert.StepOneBatchedSig),
+			"steponecommittee":  cert.StepOneCommittee,
+		},
+		"transactions": []map[string]interface{}{
+			{
+				"txid":    bid2Hash,
+				"txtype":  "1",
+				"fee":     blk.Txs[0].GasSpent() * decoded.Fee.GasPrice,
+				"outputs": outputs,
+			},
+		},
+	}
+
+	response, err := json.Marshal(map[string]interface{}{
+		"data": map[string]interface{}{
+			"byheight": decodedBlock,
+			"byhash":   decodedBlock,
+		},
+	})
+	assert.NoError(err)
+
+	fields := `{
+			header {
+			   height
+			   timestamp
+			   hash
+			   prevblockhash
+			}
+			certificate {
+			   step
+			   steponebatchedsig
+			   steponecommittee
+			}
+			transactions {
+			   txid
+			   txtype
+			   fee
+			   outputs {
+				  type
+				  valuecommitment
+			   }
+			}
+		  }`
+
+	query := fmt.Sprintf(`
+		{
+		  byheight: block(height: 1) %s,
+		  byhash: block(hash: "%s") %s
+		}
+		`, fields, block2, fields)
+
+	assertQuery(t, query, string(response))
+}
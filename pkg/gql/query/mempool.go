@@ -31,14 +31,22 @@ func (t mempool) getQuery() *graphql.Field {
 			txidArg: &graphql.ArgumentConfig{
 				Type: graphql.String,
 			},
+			txkindArg: &graphql.ArgumentConfig{
+				Type: graphql.String,
+			},
 		},
 		Resolve: t.resolve,
 	}
 }
 
 func (t mempool) resolve(p graphql.ResolveParams) (interface{}, error) {
+	kind, kindOK := p.Args[txkindArg].(string)
+	if kindOK && kind != txKindConsensus {
+		return nil, errors.New("invalid ``" + txkindArg + "`` argument")
+	}
+
 	txid, ok := p.Args[txidArg].(string)
-	if ok {
+	if ok || kindOK {
 		payload := bytes.Buffer{}
 
 		if txid != "" {
@@ -58,16 +66,21 @@ func (t mempool) resolve(p graphql.ResolveParams) (interface{}, error) {
 		}
 
 		r := resp.([]txs.ContractCall)
-		txs := make([]queryTx, 0)
+		queried := make([]queryTx, 0)
 
 		for i := 0; i < len(r); i++ {
+			// Only stake and unstake txs are consensus txs
+			if kindOK && !txs.IsConsensusTx(r[i]) {
+				continue
+			}
+
 			d, err := newQueryTx(r[i], nil, 0, 0)
 			if err == nil {
-				txs = append(txs, d)
+				queried = append(queried, d)
 			}
 		}
 
-		return txs, nil
+		return queried, nil
 	}
 
 	return nil, nil
@@ -0,0 +1,71 @@
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT License was not distributed with this
+// file, you can obtain one at https://opensource.org/licenses/MIT.
+//
+// Copyright (c) DUSK NETWORK. All rights reserved.
+
+package query
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"testing"
+
+	core "github.com/dusk-network/dusk-blockchain/pkg/core/data/ipc/transactions"
+	"github.com/dusk-network/dusk-blockchain/pkg/p2p/wire/topics"
+	"github.com/dusk-network/dusk-blockchain/pkg/util/nativeutils/rpcbus"
+	"github.com/graphql-go/graphql"
+	assert "github.com/stretchr/testify/require"
+)
+
+func TestMempoolConsensusTxs(t *testing.T) {
+	assert := assert.New(t)
+
+	stake := core.MockStakeTx()
+	pending := []core.ContractCall{core.RandTx(), stake, core.RandTx()}
+
+	rb := rpcbus.New()
+	reqChan := make(chan rpcbus.Request, 1)
+	assert.NoError(rb.Register(topics.GetMempoolTxs, reqChan))
+
+	go func() {
+		for r := range reqChan {
+			r.RespChan <- rpcbus.NewResponse(pending, nil)
+		}
+	}()
+
+	schema, err := graphql.NewSchema(graphql.SchemaConfig{Query: NewRoot(rb).Query})
+	assert.NoError(err)
+
+	stakeID, err := stake.CalculateHash()
+	assert.NoError(err)
+
+	// Only the stake tx is returned
+	response, err := json.Marshal(map[string]interface{}{
+		"data": map[string]interface{}{
+			"mempool": []map[string]interface{}{
+				{"txid": hex.EncodeToString(stakeID)},
+			},
+		},
+	})
+	assert.NoError(err)
+
+	result, err := json.Marshal(execute(`{ mempool(kind: "consensus") { txid } }`, schema, db))
+	assert.NoError(err)
+
+	equal, err := assertJSONs(result, response)
+	assert.NoError(err)
+	assert.True(equal, string(result))
+
+	// All txs are returned without filter
+	var all struct {
+		Data struct {
+			Mempool []struct{ TxID string }
+		}
+	}
+
+	result, err = json.Marshal(execute(`{ mempool(txid: "") { txid } }`, schema, db))
+	assert.NoError(err)
+	assert.NoError(json.Unmarshal(result, &all))
+	assert.Len(all.Data.Mempool, len(pending))
+}
@@ -15,6 +15,7 @@ import (
 	"reflect"
 	"testing"
 
+	"github.com/dusk-network/dusk-blockchain/pkg/config/genesis"
 	"github.com/dusk-network/dusk-blockchain/pkg/core/data/block"
 	core "github.com/dusk-network/dusk-blockchain/pkg/core/data/ipc/transactions"
 	"github.com/dusk-network/dusk-blockchain/pkg/core/database"
@@ -92,7 +93,7 @@ func initializeDB(db database.DB) error {
 		return err
 	}
 
-	b1.Header.Timestamp = 10
+	b1.Header.Timestamp = genesis.Timestamp + 10
 
 	b1.Header.Hash, err = b1.CalculateHash()
 	if err != nil {
@@ -106,7 +107,7 @@ func initializeDB(db database.DB) error {
 	b2 := helper.RandomBlock(1, 1)
 	b2.Txs = make([]core.ContractCall, 0)
 	b2.Txs = append(b2.Txs, bid2)
-	b2.Header.Timestamp = 20
+	b2.Header.Timestamp = genesis.Timestamp + 20
 
 	b2.Header.Hash, err = b2.CalculateHash()
 	if err != nil {
@@ -120,7 +121,7 @@ func initializeDB(db database.DB) error {
 	b3 := helper.RandomBlock(2, 1)
 	b3.Txs = make([]core.ContractCall, 0)
 	b3.Txs = append(b3.Txs, bid3)
-	b3.Header.Timestamp = 30
+	b3.Header.Timestamp = genesis.Timestamp + 30
 
 	b3.Header.Hash, err = b3.CalculateHash()
 	if err != nil {
@@ -25,9 +25,12 @@ func NewRoot(rpcBus *rpcbus.RPCBus) *Root {
 			graphql.ObjectConfig{
 				Name: "Query",
 				Fields: graphql.Fields{
-					"blocks":       blocks{}.getQuery(),
-					"transactions": transactions{}.getQuery(),
-					"mempool":      m.getQuery(),
+					"blocks":        blocks{}.getQuery(),
+					"transactions":  transactions{}.getQuery(),
+					"txhistory":     transactions{}.getHistoryQuery(),
+					"mempool":       m.getQuery(),
+					"blockmetadata": blocks{}.getMetadataQuery(),
+					"block":         blocks{}.getDecodedQuery(),
 				},
 			},
 		),
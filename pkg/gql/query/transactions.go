@@ -29,6 +29,15 @@ const (
 	txlastArg     = "last"
 	txblocksArg   = "blocks"
 	txblocksRange = "blocksrange"
+
+	txaddressArg = "address"
+	txoffsetArg  = "offset"
+	txlimitArg   = "limit"
+
+	// txkindArg filters the mempool txs by kind. The only kind is
+	// txKindConsensus.
+	txkindArg       = "kind"
+	txKindConsensus = "consensus"
 )
 
 type (
@@ -351,3 +360,117 @@ func (t transactions) fetchTxsByBlocksHeights(db database.DB, from, to int64) ([
 
 	return txs, err
 }
+
+func (t transactions) getHistoryQuery() *graphql.Field {
+	return &graphql.Field{
+		Type: graphql.NewList(Transaction),
+		Args: graphql.FieldConfigArgument{
+			txaddressArg: &graphql.ArgumentConfig{
+				Type: graphql.NewNonNull(graphql.String),
+			},
+			txoffsetArg: &graphql.ArgumentConfig{
+				Type: graphql.Int,
+			},
+			txlimitArg: &graphql.ArgumentConfig{
+				Type: graphql.Int,
+			},
+		},
+		Resolve: t.resolveHistory,
+	}
+}
+
+func (t transactions) resolveHistory(p graphql.ResolveParams) (interface{}, error) {
+	// Retrieve DB conn from context
+	db, ok := p.Context.Value("database").(database.DB)
+	if !ok {
+		return nil, errors.New("context does not store database conn")
+	}
+
+	encAddr, _ := p.Args[txaddressArg].(string)
+
+	address, err := hex.DecodeString(encAddr)
+	if err != nil {
+		return nil, err
+	}
+
+	offset, _ := p.Args[txoffsetArg].(int)
+	if offset < 0 {
+		return nil, errors.New("invalid ``" + txoffsetArg + "`` argument")
+	}
+
+	limit, ok := p.Args[txlimitArg].(int)
+	if !ok {
+		limit = txsFetchLimit
+	}
+
+	if limit <= 0 || limit > txsFetchLimit {
+		return nil, errors.New("invalid ``" + txlimitArg + "`` argument")
+	}
+
+	return t.fetchTxHistory(db, address, uint64(offset), uint64(limit))
+}
+
+// Fetch the confirmed txs involving an address, newest first. The entries of
+// reverted blocks are skipped before paging, so that a page is only short at
+// the end of the history.
+func (t transactions) fetchTxHistory(db database.DB, address []byte, offset, limit uint64) ([]queryTx, error) {
+	txs := make([]queryTx, 0)
+
+	err := db.View(func(t database.Transaction) error {
+		var skipped, from uint64
+
+		for uint64(len(txs)) < limit {
+			entries, err := t.FetchTxHistory(address, from, limit)
+			if err != nil {
+				return err
+			}
+
+			if len(entries) == 0 {
+				return nil
+			}
+
+			from += uint64(len(entries))
+
+			for _, e := range entries {
+				tx, _, hash, err := t.FetchBlockTxByHash(e.TxID)
+				if err == database.ErrTxNotFound {
+					// The block was reverted since it was indexed
+					continue
+				}
+
+				if err != nil {
+					return err
+				}
+
+				header, err := t.FetchBlockHeader(hash)
+				if err != nil {
+					return err
+				}
+
+				if header.Height != e.Height {
+					continue
+				}
+
+				d, err := newQueryTx(tx, header.Hash, header.Timestamp, header.Height)
+				if err != nil {
+					continue
+				}
+
+				if skipped < offset {
+					skipped++
+					continue
+				}
+
+				txs = append(txs, d)
+
+				if uint64(len(txs)) == limit {
+					return nil
+				}
+			}
+		}
+
+		return nil
+	})
+
+	return txs, err
+}
@@ -7,8 +7,15 @@
 package query
 
 import (
+	"bytes"
 	"fmt"
 	"testing"
+
+	core "github.com/dusk-network/dusk-blockchain/pkg/core/data/ipc/transactions"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/database"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/database/lite"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/tests/helper"
+	assert "github.com/stretchr/testify/require"
 )
 
 func TestTxByTxID(t *testing.T) {
@@ -195,3 +202,46 @@ func TestTxSize(t *testing.T) {
 	`
 	assertQuery(t, query, response)
 }
+
+func TestTxHistoryPaging(t *testing.T) {
+	_, db := lite.CreateDBConnection()
+
+	addr := bytes.Repeat([]byte{0xa}, 64)
+
+	blk := helper.RandomBlock(1, 0)
+	blk.Txs = []core.ContractCall{core.MockTxWithAddresses(addr), core.MockTxWithAddresses(addr)}
+
+	txIDs := make([][]byte, len(blk.Txs))
+	for i, tx := range blk.Txs {
+		txIDs[i], _ = tx.CalculateHash()
+	}
+
+	// The newest entry is of a reverted block
+	assert.NoError(t, db.Update(func(t database.Transaction) error {
+		if err := t.StoreBlock(blk, true); err != nil {
+			return err
+		}
+
+		for i, txID := range txIDs {
+			if err := t.StoreTxHistory(addr, 1, uint32(i), txID); err != nil {
+				return err
+			}
+		}
+
+		return t.StoreTxHistory(addr, 2, 0, core.Rand32Bytes())
+	}))
+
+	txs, err := transactions{}.fetchTxHistory(db, addr, 0, 1)
+	assert.NoError(t, err)
+	assert.Len(t, txs, 1)
+	assert.Equal(t, txIDs[1], txs[0].TxID)
+
+	txs, err = transactions{}.fetchTxHistory(db, addr, 1, 1)
+	assert.NoError(t, err)
+	assert.Len(t, txs, 1)
+	assert.Equal(t, txIDs[0], txs[0].TxID)
+
+	txs, err = transactions{}.fetchTxHistory(db, addr, 2, 1)
+	assert.NoError(t, err)
+	assert.Empty(t, txs)
+}
@@ -79,6 +79,170 @@ var Header = graphql.NewObject(
 	},
 )
 
+// Certificate is the graphql object representing a block certificate.
+var Certificate = graphql.NewObject(
+	graphql.ObjectConfig{
+		Name: "Certificate",
+		Fields: graphql.Fields{
+			"step": &graphql.Field{
+				Type: graphql.Int,
+			},
+			"steponebatchedsig": &graphql.Field{
+				Type: Hex,
+			},
+			"steptwobatchedsig": &graphql.Field{
+				Type: Hex,
+			},
+			"steponecommittee": &graphql.Field{
+				Type: graphql.Float,
+			},
+			"steptwocommittee": &graphql.Field{
+				Type: graphql.Float,
+			},
+		},
+	},
+)
+
+// DecodedBlock is the graphql object representing a fully decoded block, as
+// displayed by block explorers.
+var DecodedBlock = graphql.NewObject(
+	graphql.ObjectConfig{
+		Name: "DecodedBlock",
+		Fields: graphql.Fields{
+			"header": &graphql.Field{
+				Type: DecodedHeader,
+			},
+			"certificate": &graphql.Field{
+				Type: Certificate,
+			},
+			"transactions": &graphql.Field{
+				Type: graphql.NewList(DecodedTransaction),
+			},
+		},
+	},
+)
+
+// DecodedHeader is the graphql object representing a decoded block header.
+var DecodedHeader = graphql.NewObject(
+	graphql.ObjectConfig{
+		Name: "DecodedHeader",
+		Fields: graphql.Fields{
+			"version": &graphql.Field{
+				Type: graphql.Int,
+			},
+			"height": &graphql.Field{
+				Type: graphql.Float,
+			},
+			"timestamp": &graphql.Field{
+				Type: graphql.String,
+			},
+			"gaslimit": &graphql.Field{
+				Type: graphql.Float,
+			},
+			"hash": &graphql.Field{
+				Type: Hex,
+			},
+			"prevblockhash": &graphql.Field{
+				Type: Hex,
+			},
+			"seed": &graphql.Field{
+				Type: Hex,
+			},
+			"generatorblspubkey": &graphql.Field{
+				Type: Hex,
+			},
+			"statehash": &graphql.Field{
+				Type: Hex,
+			},
+		},
+	},
+)
+
+// DecodedTransaction is the graphql object representing a decoded
+// transaction of a block.
+var DecodedTransaction = graphql.NewObject(
+	graphql.ObjectConfig{
+		Name: "DecodedTransaction",
+		Fields: graphql.Fields{
+			"txid": &graphql.Field{
+				Type: Hex,
+			},
+			"txtype": &graphql.Field{
+				Type: graphql.String,
+			},
+			"gaslimit": &graphql.Field{
+				Type: graphql.Float,
+			},
+			"gasprice": &graphql.Field{
+				Type: graphql.Float,
+			},
+			"gasspent": &graphql.Field{
+				Type: graphql.Float,
+			},
+			"fee": &graphql.Field{
+				Type: graphql.Float,
+			},
+			"txerror": &graphql.Field{
+				Type: graphql.String,
+			},
+			"contractinfo": &graphql.Field{
+				Type: ContractInfo,
+			},
+			"outputs": &graphql.Field{
+				Type: graphql.NewList(Note),
+			},
+		},
+	},
+)
+
+// Note is the graphql object representing a transaction output.
+var Note = graphql.NewObject(
+	graphql.ObjectConfig{
+		Name: "Note",
+		Fields: graphql.Fields{
+			"type": &graphql.Field{
+				Type: graphql.Int,
+			},
+			"valuecommitment": &graphql.Field{
+				Type: Hex,
+			},
+			"nonce": &graphql.Field{
+				Type: Hex,
+			},
+			"stealthaddress": &graphql.Field{
+				Type: Hex,
+			},
+			"pos": &graphql.Field{
+				Type: graphql.Float,
+			},
+			"encrypteddata": &graphql.Field{
+				Type: Hex,
+			},
+		},
+	},
+)
+
+// BlockMetadata is the graphql object representing the metadata of a block.
+var BlockMetadata = graphql.NewObject(
+	graphql.ObjectConfig{
+		Name: "BlockMetadata",
+		Fields: graphql.Fields{
+			"txcount": &graphql.Field{
+				Type: graphql.Int,
+			},
+			"totalfees": &graphql.Field{
+				Type: graphql.Float,
+			},
+			"coinbaseamount": &graphql.Field{
+				Type: graphql.Float,
+			},
+			"size": &graphql.Field{
+				Type: graphql.Float,
+			},
+		},
+	},
+)
+
 // Transaction is the graphql object representing transactions.
 var Transaction = graphql.NewObject(
 	graphql.ObjectConfig{
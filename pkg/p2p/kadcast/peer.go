@@ -7,15 +7,20 @@
 package kadcast
 
 import (
+	"bytes"
 	"context"
+	"sync"
 	"time"
 
 	"github.com/dusk-network/dusk-blockchain/pkg/config"
 	"github.com/dusk-network/dusk-blockchain/pkg/p2p/kadcast/writer"
 	"github.com/dusk-network/dusk-blockchain/pkg/p2p/peer"
+	"github.com/dusk-network/dusk-blockchain/pkg/p2p/wire/encoding"
 	"github.com/dusk-network/dusk-blockchain/pkg/p2p/wire/protocol"
+	"github.com/dusk-network/dusk-blockchain/pkg/p2p/wire/topics"
 	"github.com/dusk-network/dusk-blockchain/pkg/util/container/ring"
 	"github.com/dusk-network/dusk-blockchain/pkg/util/nativeutils/eventbus"
+	"github.com/dusk-network/dusk-blockchain/pkg/util/nativeutils/rpcbus"
 	"github.com/dusk-network/dusk-protobuf/autogen/go/rusk"
 	logger "github.com/sirupsen/logrus"
 	"google.golang.org/grpc"
@@ -28,6 +33,7 @@ var log = logger.WithFields(logger.Fields{"process": "kadcast"})
 type Peer struct {
 	// dusk node components
 	eventBus  *eventbus.EventBus
+	rpcBus    *rpcbus.RPCBus
 	processor *peer.MessageProcessor
 	gossip    *protocol.Gossip
 
@@ -39,13 +45,20 @@ type Peer struct {
 
 	ctx    context.Context
 	cancel context.CancelFunc
+
+	// wg tracks the reader and request handler goroutines.
+	wg sync.WaitGroup
+
+	// banID is the subscription of the reader to topics.PeerBanned.
+	banID uint32
 }
 
 // NewKadcastPeer returns a new kadcast (gRPC interface) peer instance.
-func NewKadcastPeer(pCtx context.Context, eventBus *eventbus.EventBus, processor *peer.MessageProcessor, gossip *protocol.Gossip) *Peer {
+func NewKadcastPeer(pCtx context.Context, eventBus *eventbus.EventBus, rpcBus *rpcbus.RPCBus, processor *peer.MessageProcessor, gossip *protocol.Gossip) *Peer {
 	ctx, cancel := context.WithCancel(pCtx)
 	return &Peer{
 		eventBus:    eventBus,
+		rpcBus:      rpcBus,
 		processor:   processor,
 		gossip:      gossip,
 		cancel:      cancel,
@@ -74,10 +87,65 @@ func (p *Peer) Launch() {
 	// a reader for Kadcast messages
 	client, conn := CreateNetworkClient(ctx, cfg.Grpc.Network, cfg.Grpc.Address, cfg.Grpc.DialTimeout)
 	p.reader = NewReader(ctx, p.eventBus, p.gossip, p.processor, client)
+	p.banID = p.eventBus.Subscribe(topics.PeerBanned, eventbus.NewCallbackListener(p.reader.Ban))
 
 	p.connections = append(p.connections, conn)
 
-	go p.reader.Listen()
+	p.wg.Add(1)
+
+	go func() {
+		defer p.wg.Done()
+		p.reader.Listen()
+	}()
+
+	// serve topics.GetPeerCount requests
+	getPeerCountChan := make(chan rpcbus.Request, 1)
+	if err := p.rpcBus.Register(topics.GetPeerCount, getPeerCountChan); err != nil {
+		log.WithError(err).Error("failed to register topics.GetPeerCount")
+		return
+	}
+
+	p.wg.Add(1)
+
+	go func() {
+		defer p.wg.Done()
+		p.servePeerCount(ctx, client, getPeerCountChan)
+	}()
+}
+
+// servePeerCount responds to topics.GetPeerCount requests with the amount of
+// network nodes considered alive by the kadcast layer. The request param is
+// the maximum amount of nodes to look up.
+func (p *Peer) servePeerCount(ctx context.Context, client rusk.NetworkClient, reqChan <-chan rpcbus.Request) {
+	for {
+		select {
+		case r := <-reqChan:
+			count, err := countPeers(ctx, client, r)
+
+			timeout := time.Duration(config.Get().Timeout.TimeoutSendResponse) * time.Millisecond
+			if err := r.Respond(rpcbus.NewResponse(count, err), timeout); err != nil {
+				log.WithError(err).Warn("failed to send peer count response")
+			}
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+func countPeers(ctx context.Context, client rusk.NetworkClient, r rpcbus.Request) (int, error) {
+	var maxNodes uint32
+
+	params := r.Params.(bytes.Buffer)
+	if err := encoding.ReadUint32LE(&params, &maxNodes); err != nil {
+		return 0, err
+	}
+
+	resp, err := client.AliveNodes(ctx, &rusk.AliveNodesRequest{MaxNodes: maxNodes})
+	if err != nil {
+		return 0, err
+	}
+
+	return len(resp.Address), nil
 }
 
 func (p *Peer) createWriters(ctx context.Context) {
@@ -102,12 +170,20 @@ func (p *Peer) createWriters(ctx context.Context) {
 	p.writers = append(p.writers, w)
 }
 
-// Close terminates kadcast peer instance.
+// Close terminates kadcast peer instance. It waits for the reader to stop
+// before closing the writers, so that no response is published to a closed
+// writer.
 func (p *Peer) Close() {
 	if p.ctx != nil {
 		p.cancel()
 	}
 
+	p.wg.Wait()
+
+	if p.reader != nil {
+		p.eventBus.Unsubscribe(topics.PeerBanned, p.banID)
+	}
+
 	// close writer
 	for _, w := range p.writers {
 		_ = w.Close()
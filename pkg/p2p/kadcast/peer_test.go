@@ -90,6 +90,53 @@ func TestListenStreamReader(t *testing.T) {
 	srv.Stop()
 }
 
+// TestReaderDropsBannedPeer tests that the messages of a peer banned over
+// topics.PeerBanned are dropped until its ban ends.
+func TestReaderDropsBannedPeer(t *testing.T) {
+	assert := assert.New(t)
+
+	eb := eventbus.New()
+	p := peer.NewMessageProcessor(eb)
+	g := protocol.NewGossip()
+
+	var received []string
+
+	p.Register(topics.Block, func(srcPeerID string, m message.Message) ([]bytes.Buffer, error) {
+		received = append(received, srcPeerID)
+		return nil, nil
+	})
+
+	r := NewReader(context.Background(), eb, g, p, nil)
+	eb.Subscribe(topics.PeerBanned, eventbus.NewCallbackListener(r.Ban))
+
+	deliver := func(addr string) {
+		buf, err := createBlockMessage()
+		assert.NoError(err)
+		assert.NoError(g.Process(buf))
+
+		r.processMessage(&rusk.Message{
+			Message:  buf.Bytes(),
+			Metadata: &rusk.MessageMetadata{SrcAddress: addr},
+		})
+	}
+
+	eb.Publish(topics.PeerBanned, message.New(topics.PeerBanned, message.PeerBanned{Address: "banned", Duration: time.Hour}))
+	eb.Publish(topics.PeerBanned, message.New(topics.PeerBanned, message.PeerBanned{Address: "lifted", Duration: -time.Second}))
+
+	assert.Eventually(func() bool {
+		r.lock.Lock()
+		defer r.lock.Unlock()
+
+		return len(r.banned) == 2
+	}, time.Second, 10*time.Millisecond)
+
+	deliver("banned")
+	deliver("lifted")
+	deliver("other")
+
+	assert.Equal([]string{"lifted", "other"}, received)
+}
+
 // TestNoBroadcastWriter tests the kadcli.Writer by broadcasting
 // a block message that should not be repropagated.
 func TestNoBroadcastWriter(t *testing.T) {
@@ -11,6 +11,8 @@ import (
 	"context"
 	"encoding/hex"
 	"errors"
+	"sync"
+	"time"
 
 	"github.com/dusk-network/dusk-blockchain/pkg/p2p/peer"
 	"github.com/dusk-network/dusk-blockchain/pkg/p2p/wire/checksum"
@@ -34,6 +36,11 @@ type Reader struct {
 
 	client rusk.NetworkClient
 
+	// banned maps the address of the peers banned for misbehaving to the
+	// point in time their ban ends.
+	lock   sync.Mutex
+	banned map[string]time.Time
+
 	ctx context.Context
 }
 
@@ -44,11 +51,43 @@ func NewReader(ctx context.Context, publisher eventbus.Publisher, g *protocol.Go
 		processor: p,
 		gossip:    g,
 		client:    rusk,
+		banned:    make(map[string]time.Time),
 		ctx:       ctx,
 	}
 }
 
-// Listen starts accepting and processing stream data.
+// Ban drops the messages of a peer notified over topics.PeerBanned, for the
+// duration of its ban.
+func (r *Reader) Ban(m message.Message) {
+	b := m.Payload().(message.PeerBanned)
+
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	r.banned[b.Address] = time.Now().Add(b.Duration)
+}
+
+// isBanned returns true if the peer at addr is banned, and lifts the ban if
+// it ended.
+func (r *Reader) isBanned(addr string) bool {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	end, ok := r.banned[addr]
+	if !ok {
+		return false
+	}
+
+	if time.Now().Before(end) {
+		return true
+	}
+
+	delete(r.banned, addr)
+	return false
+}
+
+// Listen accepts and processes stream data until the stream is closed, e.g.
+// on reader context cancellation.
 func (r *Reader) Listen() {
 	// create stream handler
 	stream, err := r.client.Listen(r.ctx, &rusk.Null{})
@@ -58,23 +97,27 @@ func (r *Reader) Listen() {
 	}
 
 	// listen for messages
-	go func() {
-		for {
-			// receive a message
-			msg, err := stream.Recv()
-			if err != nil {
-				reportStreamErr(err)
-				return
-			}
-
-			// Message received
-			go r.processMessage(msg)
+	for {
+		// receive a message
+		msg, err := stream.Recv()
+		if err != nil {
+			reportStreamErr(err)
+			return
 		}
-	}()
+
+		// Message received
+		go r.processMessage(msg)
+	}
 }
 
 // processMessage propagates the received kadcast message into the event bus.
 func (r *Reader) processMessage(msg *rusk.Message) {
+	if r.isBanned(msg.Metadata.SrcAddress) {
+		log.WithField("r_addr", msg.Metadata.SrcAddress).
+			Trace("drop message from banned peer")
+		return
+	}
+
 	reader := bytes.NewReader(msg.Message)
 
 	// read message (extract length and magic)
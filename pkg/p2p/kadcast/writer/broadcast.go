@@ -9,6 +9,7 @@ package writer
 import (
 	"bytes"
 	"context"
+	"time"
 
 	"github.com/dusk-network/dusk-blockchain/pkg/config"
 	"github.com/dusk-network/dusk-blockchain/pkg/p2p/wire/message"
@@ -24,6 +25,8 @@ import (
 // propagates the message to Kadcast service.
 type Broadcast struct {
 	Base
+
+	dedup *dedupCache
 }
 
 // NewBroadcast ...
@@ -38,6 +41,9 @@ func NewBroadcast(ctx context.Context, s eventbus.Subscriber, g *protocol.Gossip
 		},
 	}
 
+	cfg := config.Get().Kadcast
+	b.dedup = newDedupCache(time.Duration(cfg.DedupWindow)*time.Millisecond, cfg.DedupCapacity)
+
 	b.Subscribe()
 	return b
 }
@@ -90,6 +96,11 @@ func (w *Broadcast) broadcast(data []byte, metadata *message.Metadata, _ byte) e
 		h = metadata.KadcastHeight - 1
 	}
 
+	if w.dedup.seenRecently(data) {
+		log.WithField("handler", w.topic.String()).Trace("duplicated payload not broadcast")
+		return nil
+	}
+
 	// create the message
 	b := bytes.NewBuffer(data)
 	if err := w.gossip.Process(b); err != nil {
@@ -0,0 +1,106 @@
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT License was not distributed with this
+// file, you can obtain one at https://opensource.org/licenses/MIT.
+//
+// Copyright (c) DUSK NETWORK. All rights reserved.
+
+package writer
+
+import (
+	"sync"
+	"time"
+
+	crypto "github.com/dusk-network/dusk-crypto/hash"
+)
+
+// defaultDedupCapacity is the maximum number of payload hashes remembered if
+// Kadcast.DedupCapacity is not set.
+const defaultDedupCapacity = 1000
+
+// dedupCache remembers the hash of the recently broadcast payloads, so that
+// an identical payload written again within the window is sent only once.
+// The cache is bounded: when full, the expired entries are dropped first and,
+// if none expired, the oldest one is evicted.
+type dedupCache struct {
+	lock     sync.Mutex
+	window   time.Duration
+	capacity int
+	seen     map[[32]byte]time.Time
+
+	// now is replaceable for testing purposes.
+	now func() time.Time
+}
+
+// newDedupCache returns nil if the window is not positive, which disables
+// de-duplication.
+func newDedupCache(window time.Duration, capacity int) *dedupCache {
+	if window <= 0 {
+		return nil
+	}
+
+	if capacity <= 0 {
+		capacity = defaultDedupCapacity
+	}
+
+	return &dedupCache{
+		window:   window,
+		capacity: capacity,
+		seen:     make(map[[32]byte]time.Time, capacity),
+		now:      time.Now,
+	}
+}
+
+// seenRecently reports whether the payload was already marked within the
+// window. If not, the payload is marked as seen.
+func (d *dedupCache) seenRecently(payload []byte) bool {
+	if d == nil {
+		return false
+	}
+
+	digest, err := crypto.Sha3256(payload)
+	if err != nil {
+		return false
+	}
+
+	var key [32]byte
+	copy(key[:], digest)
+
+	d.lock.Lock()
+	defer d.lock.Unlock()
+
+	now := d.now()
+
+	if t, ok := d.seen[key]; ok && now.Sub(t) < d.window {
+		return true
+	}
+
+	if len(d.seen) >= d.capacity {
+		d.evict(now)
+	}
+
+	d.seen[key] = now
+	return false
+}
+
+// evict drops the expired entries or, if none expired, the oldest one.
+func (d *dedupCache) evict(now time.Time) {
+	var (
+		oldestKey [32]byte
+		oldest    time.Time
+	)
+
+	for k, t := range d.seen {
+		if now.Sub(t) >= d.window {
+			delete(d.seen, k)
+			continue
+		}
+
+		if oldest.IsZero() || t.Before(oldest) {
+			oldestKey, oldest = k, t
+		}
+	}
+
+	if len(d.seen) >= d.capacity {
+		delete(d.seen, oldestKey)
+	}
+}
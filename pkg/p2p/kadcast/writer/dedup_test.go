@@ -0,0 +1,82 @@
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT License was not distributed with this
+// file, you can obtain one at https://opensource.org/licenses/MIT.
+//
+// Copyright (c) DUSK NETWORK. All rights reserved.
+
+package writer
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/dusk-network/dusk-blockchain/pkg/p2p/wire/protocol"
+	"github.com/dusk-network/dusk-protobuf/autogen/go/rusk"
+	assert "github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+)
+
+// countingClient counts the messages broadcast through it.
+type countingClient struct {
+	rusk.NetworkClient
+	broadcasts int
+}
+
+func (c *countingClient) Broadcast(ctx context.Context, in *rusk.BroadcastMessage, opts ...grpc.CallOption) (*rusk.Null, error) {
+	c.broadcasts++
+	return &rusk.Null{}, nil
+}
+
+func newDedupBroadcast(client rusk.NetworkClient, window time.Duration) (*Broadcast, *time.Time) {
+	now := time.Now()
+
+	b := &Broadcast{
+		Base: Base{
+			gossip: protocol.NewGossip(),
+			client: client,
+			ctx:    context.Background(),
+		},
+		dedup: newDedupCache(window, 0),
+	}
+	b.dedup.now = func() time.Time { return now }
+
+	return b, &now
+}
+
+// TestBroadcastDedup ensures that an identical payload written twice within
+// the de-duplication window is broadcast once, and twice otherwise.
+func TestBroadcastDedup(t *testing.T) {
+	assert := assert.New(t)
+	payload := []byte("payload")
+
+	// quickly
+	client := new(countingClient)
+	b, _ := newDedupBroadcast(client, time.Second)
+
+	assert.NoError(b.broadcast(payload, nil, 0))
+	assert.NoError(b.broadcast(payload, nil, 0))
+	assert.Equal(1, client.broadcasts)
+
+	// slowly
+	client = new(countingClient)
+	b, now := newDedupBroadcast(client, time.Second)
+
+	assert.NoError(b.broadcast(payload, nil, 0))
+	*now = now.Add(time.Second)
+	assert.NoError(b.broadcast(payload, nil, 0))
+	assert.Equal(2, client.broadcasts)
+}
+
+// TestDedupCacheBounded ensures the cache never grows beyond its capacity.
+func TestDedupCacheBounded(t *testing.T) {
+	assert := assert.New(t)
+
+	d := newDedupCache(time.Minute, 2)
+	assert.False(d.seenRecently([]byte{1}))
+	assert.False(d.seenRecently([]byte{2}))
+	assert.False(d.seenRecently([]byte{3}))
+	assert.Len(d.seen, 2)
+
+	assert.Nil(newDedupCache(0, 2))
+}
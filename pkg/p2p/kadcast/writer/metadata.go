@@ -0,0 +1,59 @@
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT License was not distributed with this
+// file, you can obtain one at https://opensource.org/licenses/MIT.
+//
+// Copyright (c) DUSK NETWORK. All rights reserved.
+
+package writer
+
+import (
+	"errors"
+	"fmt"
+	"net"
+
+	"github.com/dusk-network/dusk-blockchain/pkg/p2p/wire/message"
+)
+
+var (
+	// ErrEmptyMetadata is returned when a message to send has no metadata
+	// to route it with.
+	ErrEmptyMetadata = errors.New("empty message metadata")
+	// ErrInvalidTarget is returned when the address a point-to-point message
+	// is sent to is not a host:port pair.
+	ErrInvalidTarget = errors.New("invalid target address")
+	// ErrNoTargetNodes is returned when a message is sent to zero nodes.
+	ErrNoTargetNodes = errors.New("no target nodes")
+)
+
+// checkPointMetadata ensures the metadata of a point-to-point message holds
+// the host:port address of the node it is sent to.
+func checkPointMetadata(m *message.Metadata) error {
+	if m == nil {
+		return ErrEmptyMetadata
+	}
+
+	host, port, err := net.SplitHostPort(m.Source)
+	if err != nil {
+		return fmt.Errorf("%w %q: %v", ErrInvalidTarget, m.Source, err)
+	}
+
+	if len(host) == 0 || len(port) == 0 {
+		return fmt.Errorf("%w %q: missing host or port", ErrInvalidTarget, m.Source)
+	}
+
+	return nil
+}
+
+// checkManyMetadata ensures the metadata of a message sent to random nodes
+// holds a number of nodes.
+func checkManyMetadata(m *message.Metadata) error {
+	if m == nil {
+		return ErrEmptyMetadata
+	}
+
+	if m.NumNodes == 0 {
+		return ErrNoTargetNodes
+	}
+
+	return nil
+}
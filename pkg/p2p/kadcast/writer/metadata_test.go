@@ -0,0 +1,37 @@
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT License was not distributed with this
+// file, you can obtain one at https://opensource.org/licenses/MIT.
+//
+// Copyright (c) DUSK NETWORK. All rights reserved.
+
+package writer
+
+import (
+	"testing"
+
+	"github.com/dusk-network/dusk-blockchain/pkg/p2p/wire/message"
+	assert "github.com/stretchr/testify/require"
+)
+
+func TestCheckPointMetadata(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.NoError(checkPointMetadata(&message.Metadata{Source: "127.0.0.1:9000"}))
+	assert.NoError(checkPointMetadata(&message.Metadata{Source: "[::1]:9000"}))
+
+	assert.ErrorIs(checkPointMetadata(nil), ErrEmptyMetadata)
+
+	for _, source := range []string{"", "1", "127.0.0.1", ":9000", "127.0.0.1:", "a:b:c"} {
+		err := checkPointMetadata(&message.Metadata{Source: source})
+		assert.ErrorIs(err, ErrInvalidTarget, source)
+	}
+}
+
+func TestCheckManyMetadata(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.NoError(checkManyMetadata(&message.Metadata{NumNodes: 3}))
+
+	assert.ErrorIs(checkManyMetadata(nil), ErrEmptyMetadata)
+	assert.ErrorIs(checkManyMetadata(&message.Metadata{}), ErrNoTargetNodes)
+}
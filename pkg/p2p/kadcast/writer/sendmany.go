@@ -8,7 +8,6 @@ package writer
 
 import (
 	"context"
-	"errors"
 
 	"github.com/dusk-network/dusk-blockchain/pkg/p2p/wire/message"
 	"github.com/dusk-network/dusk-blockchain/pkg/p2p/wire/protocol"
@@ -57,8 +56,8 @@ func (w *SendToMany) Write(data []byte, metadata *message.Metadata, priority byt
 
 // sendToMany sends a message to N random endpoints returned by AliveNodes.
 func (w *SendToMany) sendToMany(data []byte, metadata *message.Metadata, _ byte) error {
-	if metadata == nil {
-		return errors.New("empty message metadata")
+	if err := checkManyMetadata(metadata); err != nil {
+		return err
 	}
 
 	// get N active nodes
@@ -8,7 +8,6 @@ package writer
 
 import (
 	"context"
-	"errors"
 
 	"github.com/dusk-network/dusk-blockchain/pkg/p2p/wire/message"
 	"github.com/dusk-network/dusk-blockchain/pkg/p2p/wire/protocol"
@@ -55,8 +54,8 @@ func (w *SendToOne) Write(data []byte, metadata *message.Metadata, priority byte
 }
 
 func (w *SendToOne) sendToOne(data []byte, metadata *message.Metadata, _ byte) error {
-	if metadata == nil {
-		return errors.New("empty message metadata")
+	if err := checkPointMetadata(metadata); err != nil {
+		return err
 	}
 
 	return w.Send(data, metadata.Source)
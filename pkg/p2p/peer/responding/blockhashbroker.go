@@ -32,7 +32,7 @@ func NewBlockHashBroker(db database.DB) *BlockHashBroker {
 
 // AdvertiseMissingBlocks takes a GetBlocks wire message, finds the requesting peer's
 // height, and returns an inventory message of up to config.MaxInvBlocks blocks which follow the
-// provided locator.
+// provided locator, or fewer if the message asks for less.
 func (b *BlockHashBroker) AdvertiseMissingBlocks(srcPeerID string, m message.Message) ([]bytes.Buffer, error) {
 	msg := m.Payload().(message.GetBlocks)
 
@@ -42,6 +42,11 @@ func (b *BlockHashBroker) AdvertiseMissingBlocks(srcPeerID string, m message.Mes
 		return nil, err
 	}
 
+	limit := cfg.MaxInvBlocks
+	if msg.Count > 0 && msg.Count < uint64(limit) {
+		limit = int(msg.Count)
+	}
+
 	// Fill an inv message with all block hashes between the locator
 	// and the chain tip.
 	inv := &message.Inv{}
@@ -64,7 +69,7 @@ func (b *BlockHashBroker) AdvertiseMissingBlocks(srcPeerID string, m message.Mes
 
 		inv.AddItem(message.InvTypeBlock, hash)
 
-		if len(inv.InvList) >= cfg.MaxInvBlocks {
+		if len(inv.InvList) >= limit {
 			break
 		}
 	}
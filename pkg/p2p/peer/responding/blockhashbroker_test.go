@@ -49,9 +49,27 @@ func TestAdvertiseBlocks(t *testing.T) {
 	assert.NoError(inv.Decode(&blksBuf[0]))
 
 	// Check that block hashes match up with those we generated
+	assert.Len(inv.InvList, 4)
+
 	for i, item := range inv.InvList {
 		assert.Equal(item.Hash, hashes[i+1])
 	}
+
+	// Only the requested number of blocks is advertised
+	msg = createGetBlocks(hashes[0])
+	getBlocks := msg.Payload().(message.GetBlocks)
+	getBlocks.Count = 2
+
+	blksBuf, err = blockHashBroker.AdvertiseMissingBlocks("", message.New(topics.GetBlocks, getBlocks))
+	assert.NoError(err)
+
+	_, _ = topics.Extract(&blksBuf[0])
+
+	inv = &message.Inv{}
+	assert.NoError(inv.Decode(&blksBuf[0]))
+	assert.Len(inv.InvList, 2)
+	assert.Equal(hashes[1], inv.InvList[0].Hash)
+	assert.Equal(hashes[2], inv.InvList[1].Hash)
 }
 
 // Generate a set of random blocks, which follow each other up in the chain.
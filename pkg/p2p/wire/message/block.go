@@ -9,20 +9,130 @@ package message
 import (
 	"bytes"
 	"errors"
+	"fmt"
 	"math"
 
+	"github.com/dusk-network/dusk-blockchain/pkg/config"
+	"github.com/dusk-network/dusk-blockchain/pkg/config/genesis"
 	"github.com/dusk-network/dusk-blockchain/pkg/core/data/block"
 	"github.com/dusk-network/dusk-blockchain/pkg/core/data/ipc/transactions"
 	"github.com/dusk-network/dusk-blockchain/pkg/p2p/wire/encoding"
 )
 
+// Maximum amount of transactions we can decode at once is
+// math.MaxInt32 / 8, since they are pointers (uint64).
+const maxBlockTxs = math.MaxInt32 / 8
+
+var errTxCountTooLarge = errors.New("block tx count too large")
+
+// ErrBlockTooLarge is returned when a block exceeds the transactions count or
+// the size decoded from a single block.
+var ErrBlockTooLarge = errors.New("block too large")
+
+var (
+	// ErrTruncatedHeader is returned when a buffer ends before a full block
+	// header could be decoded from it.
+	ErrTruncatedHeader = errors.New("truncated block header")
+	// ErrTruncatedCertificate is returned when a buffer ends before a full
+	// block certificate could be decoded from it.
+	ErrTruncatedCertificate = errors.New("truncated block certificate")
+)
+
+// Minimum encoded sizes, with empty seed and signatures. Each variable length
+// field takes at least its one byte length prefix.
+const (
+	// Step, committees and the two signature length prefixes.
+	minCertificateSize = 1 + 8 + 8 + 2
+	// Fields following the seed of a header.
+	minHeaderTailSize = 32 + 96 + 8 + minCertificateSize + 32
+	// Version, height, timestamp, prev block hash and seed length prefix.
+	minHeaderSize = 1 + 8 + 8 + 32 + 1 + minHeaderTailSize
+)
+
+// checkRemaining ensures at least n bytes are left to decode in r.
+func checkRemaining(r *bytes.Buffer, n int, errTruncated error) error {
+	if r.Len() < n {
+		return fmt.Errorf("%w: %d bytes left, %d expected", errTruncated, r.Len(), n)
+	}
+
+	return nil
+}
+
+// readVarBytes reads a variable length field, reporting errTruncated if its
+// length prefix exceeds the bytes left in r.
+func readVarBytes(r *bytes.Buffer, b *[]byte, errTruncated error) error {
+	l, err := encoding.ReadVarInt(r)
+	if err != nil {
+		return err
+	}
+
+	if l > uint64(r.Len()) {
+		return fmt.Errorf("%w: %d bytes left, %d expected", errTruncated, r.Len(), l)
+	}
+
+	*b = make([]byte, l)
+	_, err = r.Read(*b)
+	return err
+}
+
+// checkTxCount makes sure that a block with the given amount of transactions
+// can be decoded on the other end of the wire.
+func checkTxCount(lenTxs uint64) error {
+	if lenTxs > maxBlockTxs {
+		return errTxCountTooLarge
+	}
+
+	return nil
+}
+
+// blockBudget returns the maximum number of transactions and of bytes decoded
+// from a single block.
+func blockBudget() (maxTxs uint64, maxSize uint64) {
+	cfg := config.Get().Network
+
+	maxTxs, maxSize = cfg.MaxBlockTxs, cfg.MaxBlockSize
+	if maxTxs == 0 {
+		maxTxs = config.DefaultMaxBlockTxs
+	}
+
+	if maxSize == 0 {
+		maxSize = config.DefaultMaxBlockSize
+	}
+
+	return maxTxs, maxSize
+}
+
+// checkTimestamp ensures a header timestamp fits the wire format, which
+// carries it as uint64, and lies within a sane range, from the genesis block
+// on.
+func checkTimestamp(timestamp int64) error {
+	if timestamp < 0 {
+		return fmt.Errorf("invalid block timestamp %d: negative value", timestamp)
+	}
+
+	if timestamp < genesis.Timestamp {
+		return fmt.Errorf("invalid block timestamp %d: before genesis %d", timestamp, genesis.Timestamp)
+	}
+
+	if timestamp > config.MaxBlockTimestamp {
+		return fmt.Errorf("invalid block timestamp %d: exceeds upper bound %d", timestamp, config.MaxBlockTimestamp)
+	}
+
+	return nil
+}
+
 // MarshalBlock marshals a block into a binary buffer.
 func MarshalBlock(r *bytes.Buffer, b *block.Block) error {
+	// Fail fast, instead of emitting a block that no peer can decode.
+	lenTxs := uint64(len(b.Txs))
+	if err := checkTxCount(lenTxs); err != nil {
+		return err
+	}
+
 	if err := MarshalHeader(r, b.Header); err != nil {
 		return err
 	}
 
-	lenTxs := uint64(len(b.Txs))
 	if err := encoding.WriteVarInt(r, lenTxs); err != nil {
 		return err
 	}
@@ -51,29 +161,42 @@ func UnmarshalBlockMessage(r *bytes.Buffer, m SerializableMessage) error {
 
 // UnmarshalBlock unmarshals a block from a binary buffer.
 func UnmarshalBlock(r *bytes.Buffer, b *block.Block) error {
+	size := r.Len()
+
 	if err := UnmarshalHeader(r, b.Header); err != nil {
 		return err
 	}
 
 	lTxs, err := encoding.ReadVarInt(r)
 	if err != nil {
+		return fmt.Errorf("tx count: %w", err)
+	}
+
+	if err := checkTxCount(lTxs); err != nil {
 		return err
 	}
 
-	// Maximum amount of transactions we can decode at once is
-	// math.MaxInt32 / 8, since they are pointers (uint64)
-	if lTxs > (math.MaxInt32 / 8) {
-		return errors.New("block tx count too large")
+	maxTxs, maxSize := blockBudget()
+	if lTxs > maxTxs {
+		return fmt.Errorf("%w: %d txs, %d allowed", ErrBlockTooLarge, lTxs, maxTxs)
 	}
 
-	b.Txs = make([]transactions.ContractCall, lTxs)
-	for i := range b.Txs {
+	// The txs are appended as they are decoded, so that the memory allocated
+	// is bounded by the data received, rather than by the announced count.
+	b.Txs = make([]transactions.ContractCall, 0)
+	for i := uint64(0); i < lTxs; i++ {
+		offset := size - r.Len()
+
 		c := transactions.NewTransaction()
 		if err := transactions.Unmarshal(r, c); err != nil {
-			return err
+			return fmt.Errorf("tx %d at offset %d: %w", i, offset, err)
 		}
 
-		b.Txs[i] = c
+		if decoded := uint64(size - r.Len()); decoded > maxSize {
+			return fmt.Errorf("%w: %d bytes decoded at tx %d, %d allowed", ErrBlockTooLarge, decoded, i, maxSize)
+		}
+
+		b.Txs = append(b.Txs, c)
 	}
 
 	return nil
@@ -89,6 +212,10 @@ func MarshalHashable(r *bytes.Buffer, h *block.Header) error {
 		return err
 	}
 
+	if err := checkTimestamp(h.Timestamp); err != nil {
+		return err
+	}
+
 	if err := encoding.WriteUint64LE(r, uint64(h.Timestamp)); err != nil {
 		return err
 	}
@@ -134,65 +261,87 @@ func MarshalHeader(r *bytes.Buffer, h *block.Header) error {
 }
 
 // UnmarshalHeader unmarshal a block header from a binary buffer.
+// It returns ErrTruncatedHeader if the buffer is too short to hold one.
 func UnmarshalHeader(r *bytes.Buffer, h *block.Header) error {
-	if err := encoding.ReadUint8(r, &h.Version); err != nil {
+	if err := checkRemaining(r, minHeaderSize, ErrTruncatedHeader); err != nil {
 		return err
 	}
 
+	if err := encoding.ReadUint8(r, &h.Version); err != nil {
+		return fmt.Errorf("header version: %w", err)
+	}
+
 	if err := encoding.ReadUint64LE(r, &h.Height); err != nil {
-		return err
+		return fmt.Errorf("header height: %w", err)
 	}
 
 	var timestamp uint64
 	if err := encoding.ReadUint64LE(r, &timestamp); err != nil {
-		return err
+		return fmt.Errorf("header timestamp: %w", err)
 	}
 
 	h.Timestamp = int64(timestamp)
+	if err := checkTimestamp(h.Timestamp); err != nil {
+		return err
+	}
 
 	h.PrevBlockHash = make([]byte, 32)
 	if err := encoding.Read256(r, h.PrevBlockHash); err != nil {
-		return err
+		return fmt.Errorf("header prev block hash: %w", err)
 	}
 
 	h.Seed = make([]byte, 0)
-	if err := encoding.ReadVarBytes(r, &h.Seed); err != nil {
-		return err
+	if err := readVarBytes(r, &h.Seed, ErrTruncatedHeader); err != nil {
+		return fmt.Errorf("header seed: %w", err)
+	}
+
+	if err := checkRemaining(r, minHeaderTailSize, ErrTruncatedHeader); err != nil {
+		return fmt.Errorf("header seed: %w", err)
 	}
 
 	h.StateHash = make([]byte, 32)
 	if err := encoding.Read256(r, h.StateHash); err != nil {
-		return err
+		return fmt.Errorf("header state hash: %w", err)
 	}
 
 	h.GeneratorBlsPubkey = make([]byte, 96)
 	if err := encoding.ReadBLSPKey(r, h.GeneratorBlsPubkey); err != nil {
-		return err
+		return fmt.Errorf("header generator pubkey: %w", err)
 	}
 
 	if err := encoding.ReadUint64LE(r, &h.GasLimit); err != nil {
-		return err
+		return fmt.Errorf("header gas limit: %w", err)
 	}
 
 	if err := UnmarshalCertificate(r, h.Certificate); err != nil {
-		return err
+		return fmt.Errorf("header certificate: %w", err)
+	}
+
+	if err := checkRemaining(r, 32, ErrTruncatedHeader); err != nil {
+		return fmt.Errorf("header hash: %w", err)
 	}
 
 	h.Hash = make([]byte, 32)
 	if err := encoding.Read256(r, h.Hash); err != nil {
-		return err
+		return fmt.Errorf("header hash: %w", err)
 	}
 
 	return nil
 }
 
-// MarshalCertificate marshals a certificate.
+// emptySigSize is the size of the signatures of block.EmptyCertificate.
+const emptySigSize = 33
+
+// MarshalCertificate marshals a certificate. If Network.CompactCertificates
+// is set, empty signatures are written as zero length fields.
 func MarshalCertificate(r *bytes.Buffer, c *block.Certificate) error {
-	if err := encoding.WriteVarBytes(r, c.StepOneBatchedSig); err != nil {
+	compact := config.Get().Network.CompactCertificates
+
+	if err := writeSig(r, c.StepOneBatchedSig, compact); err != nil {
 		return err
 	}
 
-	if err := encoding.WriteVarBytes(r, c.StepTwoBatchedSig); err != nil {
+	if err := writeSig(r, c.StepTwoBatchedSig, compact); err != nil {
 		return err
 	}
 
@@ -211,28 +360,68 @@ func MarshalCertificate(r *bytes.Buffer, c *block.Certificate) error {
 	return nil
 }
 
-// UnmarshalCertificate unmarshals a certificate.
-func UnmarshalCertificate(r *bytes.Buffer, c *block.Certificate) error {
-	c.StepOneBatchedSig = make([]byte, 0)
-	if err := encoding.ReadVarBytes(r, &c.StepOneBatchedSig); err != nil {
+// writeSig writes a certificate signature. An empty signature is written as a
+// zero length field when compact is set.
+func writeSig(r *bytes.Buffer, sig []byte, compact bool) error {
+	if compact && isEmptySig(sig) {
+		sig = nil
+	}
+
+	return encoding.WriteVarBytes(r, sig)
+}
+
+// isEmptySig tells if sig is the signature of block.EmptyCertificate.
+func isEmptySig(sig []byte) bool {
+	return len(sig) == emptySigSize && bytes.Equal(sig, make([]byte, emptySigSize))
+}
+
+// readSig reads a certificate signature. A zero length signature, as written
+// by the compact encoding, is decoded as the empty one.
+func readSig(r *bytes.Buffer, sig *[]byte) error {
+	if err := readVarBytes(r, sig, ErrTruncatedCertificate); err != nil {
 		return err
 	}
 
-	c.StepTwoBatchedSig = make([]byte, 0)
-	if err := encoding.ReadVarBytes(r, &c.StepTwoBatchedSig); err != nil {
+	if len(*sig) == 0 {
+		*sig = make([]byte, emptySigSize)
+	}
+
+	return nil
+}
+
+// UnmarshalCertificate unmarshals a certificate. It returns
+// ErrTruncatedCertificate if the buffer is too short to hold one.
+func UnmarshalCertificate(r *bytes.Buffer, c *block.Certificate) error {
+	if err := checkRemaining(r, minCertificateSize, ErrTruncatedCertificate); err != nil {
 		return err
 	}
 
+	if err := readSig(r, &c.StepOneBatchedSig); err != nil {
+		return fmt.Errorf("step one signature: %w", err)
+	}
+
+	if err := checkRemaining(r, minCertificateSize-1, ErrTruncatedCertificate); err != nil {
+		return fmt.Errorf("step one signature: %w", err)
+	}
+
+	if err := readSig(r, &c.StepTwoBatchedSig); err != nil {
+		return fmt.Errorf("step two signature: %w", err)
+	}
+
+	if err := checkRemaining(r, minCertificateSize-2, ErrTruncatedCertificate); err != nil {
+		return fmt.Errorf("step two signature: %w", err)
+	}
+
 	if err := encoding.ReadUint8(r, &c.Step); err != nil {
-		return err
+		return fmt.Errorf("step: %w", err)
 	}
 
 	if err := encoding.ReadUint64LE(r, &c.StepOneCommittee); err != nil {
-		return err
+		return fmt.Errorf("step one committee: %w", err)
 	}
 
 	if err := encoding.ReadUint64LE(r, &c.StepTwoCommittee); err != nil {
-		return err
+		return fmt.Errorf("step two committee: %w", err)
 	}
 
 	return nil
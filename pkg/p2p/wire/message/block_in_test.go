@@ -0,0 +1,21 @@
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT License was not distributed with this
+// file, you can obtain one at https://opensource.org/licenses/MIT.
+//
+// Copyright (c) DUSK NETWORK. All rights reserved.
+
+package message
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestMarshalTxCountCap makes sure the marshal-side cap mirrors the one
+// enforced when decoding.
+func TestMarshalTxCountCap(t *testing.T) {
+	assert.NoError(t, checkTxCount(0))
+	assert.NoError(t, checkTxCount(maxBlockTxs))
+	assert.Equal(t, errTxCountTooLarge, checkTxCount(maxBlockTxs+1))
+}
@@ -8,12 +8,19 @@ package message_test
 
 import (
 	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+	"runtime"
 	"testing"
 
+	"github.com/dusk-network/dusk-blockchain/pkg/config"
 	"github.com/dusk-network/dusk-blockchain/pkg/config/genesis"
 	"github.com/dusk-network/dusk-blockchain/pkg/core/data/block"
 	"github.com/dusk-network/dusk-blockchain/pkg/core/data/ipc/transactions"
 	"github.com/dusk-network/dusk-blockchain/pkg/core/tests/helper"
+	"github.com/dusk-network/dusk-blockchain/pkg/p2p/wire/encoding"
 	"github.com/dusk-network/dusk-blockchain/pkg/p2p/wire/message"
 	assert "github.com/stretchr/testify/require"
 )
@@ -70,6 +77,39 @@ func TestEncodeDecodeCert(t *testing.T) {
 	assert.True(cert.Equals(decCert))
 }
 
+func TestCompactCertificate(t *testing.T) {
+	assert := assert.New(t)
+
+	orig := config.Get()
+	defer config.Mock(&orig)
+
+	for _, compact := range []bool{false, true} {
+		r := config.Get()
+		r.Network.CompactCertificates = compact
+		config.Mock(&r)
+
+		for _, cert := range []*block.Certificate{block.EmptyCertificate(), helper.RandomCertificate()} {
+			buf := new(bytes.Buffer)
+			assert.NoError(message.MarshalCertificate(buf, cert))
+
+			decCert := &block.Certificate{}
+			assert.NoError(message.UnmarshalCertificate(bytes.NewBuffer(buf.Bytes()), decCert))
+			assert.True(cert.Equals(decCert))
+		}
+
+		// Empty signatures take a single byte each in the compact form
+		buf := new(bytes.Buffer)
+		assert.NoError(message.MarshalCertificate(buf, block.EmptyCertificate()))
+
+		size := 1 + 8 + 8 + 2*(1+33)
+		if compact {
+			size = 1 + 8 + 8 + 2
+		}
+
+		assert.Equal(size, buf.Len())
+	}
+}
+
 func TestEncodeDecodeHeader(t *testing.T) {
 	assert := assert.New(t)
 
@@ -97,6 +137,160 @@ func TestEncodeDecodeHeader(t *testing.T) {
 	assert.True(hdr.Equals(decHdr))
 }
 
+func TestMarshalHeaderInvalidTimestamp(t *testing.T) {
+	assert := assert.New(t)
+
+	for _, ts := range []int64{-1, 0, genesis.Timestamp - 1, math.MaxInt64} {
+		hdr := helper.RandomHeader(200)
+		hdr.Timestamp = ts
+
+		err := message.MarshalHeader(new(bytes.Buffer), hdr)
+		assert.Error(err, "timestamp %d", ts)
+	}
+}
+
+func TestMarshalHeaderGenesisTimestamp(t *testing.T) {
+	assert := assert.New(t)
+
+	hdr := helper.RandomHeader(200)
+	hdr.Timestamp = genesis.Timestamp
+	hdr.Hash, _ = hdr.CalculateHash()
+
+	assert.NoError(message.MarshalHeader(new(bytes.Buffer), hdr))
+}
+
+func TestUnmarshalHeaderInvalidTimestamp(t *testing.T) {
+	assert := assert.New(t)
+
+	hdr := helper.RandomHeader(200)
+	hdr.Hash, _ = hdr.CalculateHash()
+
+	buf := new(bytes.Buffer)
+	assert.NoError(message.MarshalHeader(buf, hdr))
+
+	// Timestamp follows the version (1 byte) and height (8 bytes) fields
+	for _, ts := range []uint64{0, uint64(genesis.Timestamp) - 1, math.MaxUint64, uint64(config.MaxBlockTimestamp) + 1} {
+		data := append([]byte{}, buf.Bytes()...)
+		binary.LittleEndian.PutUint64(data[9:17], ts)
+
+		err := message.UnmarshalHeader(bytes.NewBuffer(data), block.NewHeader())
+		assert.Error(err, "timestamp %d", ts)
+	}
+}
+
+func TestUnmarshalBlockTruncatedTx(t *testing.T) {
+	assert := assert.New(t)
+
+	blk := helper.RandomBlock(200, 1)
+	blk.Txs = []transactions.ContractCall{transactions.RandTx(), transactions.RandTx(), transactions.RandTx()}
+
+	buf := new(bytes.Buffer)
+	assert.NoError(message.MarshalBlock(buf, blk))
+
+	lastTx := new(bytes.Buffer)
+	assert.NoError(transactions.Marshal(lastTx, blk.Txs[2]))
+
+	// Truncate the block in the middle of its third tx
+	data := buf.Bytes()[:buf.Len()-lastTx.Len()/2]
+	offset := buf.Len() - lastTx.Len()
+
+	err := message.UnmarshalBlock(bytes.NewBuffer(data), block.NewBlock())
+	assert.Error(err)
+	assert.Contains(err.Error(), fmt.Sprintf("tx 2 at offset %d", offset))
+
+	// A header cut after the version, height and timestamp (17 bytes) is
+	// reported as truncated before any field is decoded
+	err = message.UnmarshalBlock(bytes.NewBuffer(data[:17]), block.NewBlock())
+	assert.ErrorIs(err, message.ErrTruncatedHeader)
+}
+
+func TestUnmarshalBlockTooLarge(t *testing.T) {
+	assert := assert.New(t)
+
+	orig := config.Get()
+	defer config.Mock(&orig)
+
+	h := helper.RandomHeader(200)
+	h.Hash, _ = h.CalculateHash()
+
+	hdr := new(bytes.Buffer)
+	assert.NoError(message.MarshalHeader(hdr, h))
+
+	// A block announcing count txs, of which only one is sent
+	announce := func(count uint64) *bytes.Buffer {
+		buf := bytes.NewBuffer(append([]byte{}, hdr.Bytes()...))
+		assert.NoError(encoding.WriteVarInt(buf, count))
+		assert.NoError(transactions.Marshal(buf, transactions.RandTx()))
+		return buf
+	}
+
+	// Above the default count, the block is rejected before any tx is decoded
+	err := message.UnmarshalBlock(announce(100_000_000), block.NewBlock())
+	assert.ErrorIs(err, message.ErrBlockTooLarge)
+
+	r := config.Get()
+	r.Network.MaxBlockTxs = 100_000_000
+	config.Mock(&r)
+
+	// Within the count, no memory is allocated for the txs not received, as
+	// the former pre-allocation (1.6GB) did
+	var before, after runtime.MemStats
+
+	runtime.ReadMemStats(&before)
+	err = message.UnmarshalBlock(announce(100_000_000), block.NewBlock())
+	runtime.ReadMemStats(&after)
+
+	assert.Error(err)
+	assert.Contains(err.Error(), "tx 1 at offset")
+	assert.Less(after.TotalAlloc-before.TotalAlloc, uint64(1<<20))
+
+	// Above the size, the block is rejected once the tx exceeding it is decoded
+	r.Network.MaxBlockSize = uint64(hdr.Len())
+	config.Mock(&r)
+
+	err = message.UnmarshalBlock(announce(1), block.NewBlock())
+	assert.ErrorIs(err, message.ErrBlockTooLarge)
+	assert.Contains(err.Error(), "at tx 0")
+}
+
+func TestUnmarshalTruncatedHeader(t *testing.T) {
+	assert := assert.New(t)
+
+	hdr := helper.RandomHeader(200)
+	hdr.Hash, _ = hdr.CalculateHash()
+
+	buf := new(bytes.Buffer)
+	assert.NoError(message.MarshalHeader(buf, hdr))
+
+	data := buf.Bytes()
+
+	// Whatever the field the buffer ends in, truncation is reported as such
+	for i := 0; i < len(data); i++ {
+		err := message.UnmarshalHeader(bytes.NewBuffer(data[:i]), block.NewHeader())
+		if !errors.Is(err, message.ErrTruncatedCertificate) {
+			assert.ErrorIs(err, message.ErrTruncatedHeader, "truncated at %d", i)
+		}
+	}
+
+	assert.NoError(message.UnmarshalHeader(bytes.NewBuffer(data), block.NewHeader()))
+}
+
+func TestUnmarshalTruncatedCertificate(t *testing.T) {
+	assert := assert.New(t)
+
+	buf := new(bytes.Buffer)
+	assert.NoError(message.MarshalCertificate(buf, helper.RandomCertificate()))
+
+	data := buf.Bytes()
+
+	for i := 0; i < len(data); i++ {
+		err := message.UnmarshalCertificate(bytes.NewBuffer(data[:i]), &block.Certificate{})
+		assert.ErrorIs(err, message.ErrTruncatedCertificate, "truncated at %d", i)
+	}
+
+	assert.NoError(message.UnmarshalCertificate(bytes.NewBuffer(data), &block.Certificate{}))
+}
+
 func TestDecodeLegacyGenesis(t *testing.T) { //nolint
 	genesis.Decode()
 }
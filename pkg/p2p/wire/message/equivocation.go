@@ -0,0 +1,31 @@
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT License was not distributed with this
+// file, you can obtain one at https://opensource.org/licenses/MIT.
+//
+// Copyright (c) DUSK NETWORK. All rights reserved.
+
+package message
+
+import (
+	"github.com/dusk-network/dusk-blockchain/pkg/core/data/block"
+	"github.com/dusk-network/dusk-blockchain/pkg/p2p/wire/message/payload"
+)
+
+// Equivocation is the internal message published over
+// topics.EquivocationDetected when two blocks are certified at the same
+// height and consensus iteration.
+type Equivocation struct {
+	// Kept is the header of the block first accepted at that height.
+	Kept *block.Header
+	// Rejected is the header of the competing block.
+	Rejected *block.Header
+}
+
+// Copy complies with payload.Safe interface. It returns a deep copy of
+// the message safe to publish to multiple subscribers.
+func (e Equivocation) Copy() payload.Safe {
+	return Equivocation{
+		Kept:     e.Kept.Copy(),
+		Rejected: e.Rejected.Copy(),
+	}
+}
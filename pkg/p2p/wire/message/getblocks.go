@@ -18,6 +18,11 @@ import (
 // request blocks from another peer.
 type GetBlocks struct {
 	Locators [][]byte
+
+	// Count is the maximum number of blocks requested. Zero means up to
+	// config.MaxInvBlocks. It is optional on the wire, so that the requests
+	// of older nodes are still understood.
+	Count uint64
 }
 
 // Copy a GetBlocks message.
@@ -25,7 +30,7 @@ type GetBlocks struct {
 func (g GetBlocks) Copy() payload.Safe {
 	l := make([][]byte, len(g.Locators))
 	copy(l, g.Locators)
-	return &GetBlocks{l}
+	return &GetBlocks{Locators: l, Count: g.Count}
 }
 
 // Encode a GetBlocks struct and write it to w.
@@ -40,7 +45,7 @@ func (g *GetBlocks) Encode(w *bytes.Buffer) error {
 		}
 	}
 
-	return nil
+	return encoding.WriteVarInt(w, g.Count)
 }
 
 // UnmarshalGetBlocksMessage unmarshals a GetBlocks message into a
@@ -77,5 +82,13 @@ func (g *GetBlocks) Decode(r *bytes.Buffer) error {
 		}
 	}
 
+	// Older nodes do not send the count
+	g.Count = 0
+	if r.Len() > 0 {
+		if g.Count, err = encoding.ReadVarInt(r); err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
@@ -10,6 +10,7 @@ import (
 	"bytes"
 	"testing"
 
+	"github.com/dusk-network/dusk-blockchain/pkg/p2p/wire/encoding"
 	"github.com/dusk-network/dusk-blockchain/pkg/p2p/wire/message"
 	crypto "github.com/dusk-network/dusk-crypto/hash"
 	"github.com/stretchr/testify/assert"
@@ -23,7 +24,7 @@ func TestEncodeDecodeGetBlocks(t *testing.T) {
 		hashes = append(hashes, hash)
 	}
 
-	getBlocks := &message.GetBlocks{hashes}
+	getBlocks := &message.GetBlocks{Locators: hashes, Count: 50}
 
 	buf := new(bytes.Buffer)
 	if err := getBlocks.Encode(buf); err != nil {
@@ -37,3 +38,17 @@ func TestEncodeDecodeGetBlocks(t *testing.T) {
 
 	assert.Equal(t, getBlocks, getBlocks2)
 }
+
+func TestDecodeGetBlocksWithoutCount(t *testing.T) {
+	hash, _ := crypto.RandEntropy(32)
+
+	// A request from a node which does not send the count
+	buf := new(bytes.Buffer)
+	assert.NoError(t, encoding.WriteVarInt(buf, 1))
+	assert.NoError(t, encoding.Write256(buf, hash))
+
+	getBlocks := &message.GetBlocks{}
+	assert.NoError(t, getBlocks.Decode(buf))
+	assert.Equal(t, [][]byte{hash}, getBlocks.Locators)
+	assert.Zero(t, getBlocks.Count)
+}
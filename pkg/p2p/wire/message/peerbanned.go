@@ -0,0 +1,31 @@
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT License was not distributed with this
+// file, you can obtain one at https://opensource.org/licenses/MIT.
+//
+// Copyright (c) DUSK NETWORK. All rights reserved.
+
+package message
+
+import (
+	"time"
+
+	"github.com/dusk-network/dusk-blockchain/pkg/p2p/wire/message/payload"
+)
+
+// PeerBanned is the internal message published over topics.PeerBanned when
+// the misbehavior score of a peer reaches the ban score.
+type PeerBanned struct {
+	// Address is the network address of the peer.
+	Address string
+	// Reason is the misbehavior which made the score cross the ban score.
+	Reason string
+	// Score is the misbehavior score of the peer.
+	Score uint32
+	// Duration is the time until the score decays below the ban score.
+	Duration time.Duration
+}
+
+// Copy complies with payload.Safe interface.
+func (b PeerBanned) Copy() payload.Safe {
+	return b
+}
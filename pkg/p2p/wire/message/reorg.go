@@ -0,0 +1,43 @@
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT License was not distributed with this
+// file, you can obtain one at https://opensource.org/licenses/MIT.
+//
+// Copyright (c) DUSK NETWORK. All rights reserved.
+
+package message
+
+import (
+	"github.com/dusk-network/dusk-blockchain/pkg/core/data/ipc/transactions"
+	"github.com/dusk-network/dusk-blockchain/pkg/p2p/wire/message/payload"
+)
+
+// ChainReorg is the internal message published over topics.ChainReorg when
+// the chain abandons a branch in favor of a competing one.
+type ChainReorg struct {
+	// Evicted holds the transactions of the orphaned branch.
+	Evicted []transactions.ContractCall
+	// Accepted holds the transactions of the new branch.
+	Accepted []transactions.ContractCall
+}
+
+// Copy complies with payload.Safe interface. It returns a deep copy of
+// the message safe to publish to multiple subscribers.
+func (r ChainReorg) Copy() payload.Safe {
+	return ChainReorg{
+		Evicted:  copyTxs(r.Evicted),
+		Accepted: copyTxs(r.Accepted),
+	}
+}
+
+func copyTxs(txs []transactions.ContractCall) []transactions.ContractCall {
+	if txs == nil {
+		return nil
+	}
+
+	cpy := make([]transactions.ContractCall, len(txs))
+	for i, tx := range txs {
+		cpy[i] = tx.Copy().(transactions.ContractCall)
+	}
+
+	return cpy
+}
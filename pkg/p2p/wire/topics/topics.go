@@ -95,6 +95,41 @@ const (
 
 	// KadcastSendToMany send to many nodes.
 	KadcastSendToMany
+
+	// Internal chain reorganization notification.
+	ChainReorg
+
+	// RPCBus topic to query the confirmation status of a transaction.
+	GetTxStatus
+
+	// RPCBus topic to query the amount of network peers.
+	GetPeerCount
+
+	// RPCBus topic to query the committee which finalized a past round.
+	GetCommitteeAtRound
+
+	// RPCBus topic to submit an externally constructed block.
+	SubmitBlock
+
+	// RPCBus topic to drop the mempool txs already confirmed or invalidated
+	// by the chain.
+	ReconcileMempool
+
+	// Internal notification of two blocks certified at the same height and
+	// consensus iteration.
+	EquivocationDetected
+
+	// RPCBus topic to query the round and step the consensus is running.
+	GetConsensusState
+
+	// RPCBus topic to query whether the mempool is ready to provide txs.
+	GetMempoolReady
+
+	// RPCBus topic to fetch an accepted block by height.
+	GetBlockAtHeight
+
+	// Internal notification of a peer banned for misbehaving.
+	PeerBanned
 )
 
 type topicBuf struct {
@@ -156,6 +191,19 @@ var Topics = [...]topicBuf{
 	{GetCandidate, *(bytes.NewBuffer([]byte{byte(GetCandidate)})), "getcandidate"},
 	{SyncProgress, *(bytes.NewBuffer([]byte{byte(SyncProgress)})), "syncprogress"},
 	{Kadcast, *(bytes.NewBuffer([]byte{byte(Kadcast)})), "kadcast"},
+	{KadcastSendToOne, *(bytes.NewBuffer([]byte{byte(KadcastSendToOne)})), "kadcastsendtoone"},
+	{KadcastSendToMany, *(bytes.NewBuffer([]byte{byte(KadcastSendToMany)})), "kadcastsendtomany"},
+	{ChainReorg, *(bytes.NewBuffer([]byte{byte(ChainReorg)})), "chainreorg"},
+	{GetTxStatus, *(bytes.NewBuffer([]byte{byte(GetTxStatus)})), "gettxstatus"},
+	{GetPeerCount, *(bytes.NewBuffer([]byte{byte(GetPeerCount)})), "getpeercount"},
+	{GetCommitteeAtRound, *(bytes.NewBuffer([]byte{byte(GetCommitteeAtRound)})), "getcommitteeatround"},
+	{SubmitBlock, *(bytes.NewBuffer([]byte{byte(SubmitBlock)})), "submitblock"},
+	{ReconcileMempool, *(bytes.NewBuffer([]byte{byte(ReconcileMempool)})), "reconcilemempool"},
+	{EquivocationDetected, *(bytes.NewBuffer([]byte{byte(EquivocationDetected)})), "equivocationdetected"},
+	{GetConsensusState, *(bytes.NewBuffer([]byte{byte(GetConsensusState)})), "getconsensusstate"},
+	{GetMempoolReady, *(bytes.NewBuffer([]byte{byte(GetMempoolReady)})), "getmempoolready"},
+	{GetBlockAtHeight, *(bytes.NewBuffer([]byte{byte(GetBlockAtHeight)})), "getblockatheight"},
+	{PeerBanned, *(bytes.NewBuffer([]byte{byte(PeerBanned)})), "peerbanned"},
 }
 
 func checkConsistency(topics []topicBuf) {
@@ -26,6 +26,8 @@ type (
 	EventBus struct {
 		listeners       *listenerMap
 		defaultListener *multiListener
+		retained        *retainedMap
+		interceptors    *interceptorChain
 	}
 )
 
@@ -34,6 +36,8 @@ func New() *EventBus {
 	return &EventBus{
 		listeners:       newListenerMap(),
 		defaultListener: newMultiListener(),
+		retained:        newRetainedMap(),
+		interceptors:    new(interceptorChain),
 	}
 }
 
@@ -9,9 +9,11 @@ package eventbus
 import (
 	"bytes"
 	"errors"
+	"sync"
 	"testing"
 	"time"
 
+	"github.com/dusk-network/dusk-blockchain/pkg/config"
 	"github.com/dusk-network/dusk-blockchain/pkg/p2p/wire/message"
 	"github.com/dusk-network/dusk-blockchain/pkg/p2p/wire/topics"
 	"github.com/sirupsen/logrus"
@@ -49,6 +51,198 @@ func TestSubscribe(t *testing.T) {
 	assert.NotNil(t, eb.Subscribe(topics.Test, cl))
 }
 
+func TestSubscribeChanBufferSize(t *testing.T) {
+	eb := New()
+	bufSize := 3
+
+	msgChan, _ := eb.SubscribeChan(topics.Test, bufSize)
+
+	// The channel should hold exactly bufSize messages
+	for i := 0; i < bufSize; i++ {
+		m := message.New(topics.Test, *bytes.NewBufferString("pluto"))
+		assert.Empty(t, eb.Publish(topics.Test, m))
+	}
+
+	m := message.New(topics.Test, *bytes.NewBufferString("pluto"))
+	errList := eb.Publish(topics.Test, m)
+	assert.Equal(t, []error{ErrMsgChanFull}, errList)
+
+	assert.Equal(t, bufSize, len(msgChan))
+}
+
+func TestRetainedTopic(t *testing.T) {
+	eb := New()
+	eb.Retain(topics.Test)
+
+	eb.Publish(topics.Test, message.New(topics.Test, *bytes.NewBufferString("pippo")))
+	eb.Publish(topics.Test, message.New(topics.Test, *bytes.NewBufferString("pluto")))
+
+	// A late subscriber receives the last retained message only
+	msgChan, _ := eb.SubscribeChan(topics.Test, 10)
+	assert.Equal(t, 1, len(msgChan))
+
+	m := <-msgChan
+	buf := m.Payload().(message.SafeBuffer)
+	assert.Equal(t, "pluto", buf.String())
+
+	// Non retained topics are not replayed
+	eb.Publish(topics.Gossip, message.New(topics.Gossip, *bytes.NewBufferString("pippo")))
+
+	gossipChan, _ := eb.SubscribeChan(topics.Gossip, 10)
+	assert.Empty(t, gossipChan)
+}
+
+// Test that an ordered listener receives the messages of each publisher in
+// publish order, even under concurrent publishers.
+func TestOrderedDelivery(t *testing.T) {
+	eb := New()
+
+	publishers, count := 4, 200
+	received := make(chan []byte, publishers*count)
+
+	id := eb.Subscribe(topics.Test, NewSafeOrderedCallbackListener(func(m message.Message) {
+		buf := m.Payload().(message.SafeBuffer)
+		received <- buf.Bytes()
+	}))
+	defer eb.Unsubscribe(topics.Test, id)
+
+	var wg sync.WaitGroup
+
+	for p := 0; p < publishers; p++ {
+		wg.Add(1)
+
+		go func(p byte) {
+			defer wg.Done()
+
+			for i := 0; i < count; i++ {
+				payload := []byte{p, byte(i >> 8), byte(i)}
+				eb.Publish(topics.Test, message.New(topics.Test, *bytes.NewBuffer(payload)))
+			}
+		}(byte(p))
+	}
+
+	wg.Wait()
+
+	next := make([]int, publishers)
+
+	for i := 0; i < publishers*count; i++ {
+		select {
+		case payload := <-received:
+			p, seq := payload[0], int(payload[1])<<8|int(payload[2])
+			assert.Equal(t, next[p], seq)
+			next[p]++
+		case <-time.After(5 * time.Second):
+			t.Fatal("ordered listener did not receive all messages")
+		}
+	}
+}
+
+// Test that a panicking callback does not stop the delivery of the next
+// messages.
+func TestCallbackPanicRecovery(t *testing.T) {
+	orig := config.Get()
+	defer config.Mock(&orig)
+
+	r := config.Get()
+	r.Node.RecoverListenerPanics = true
+	config.Mock(&r)
+
+	eb := New()
+
+	for _, delivery := range []Delivery{Ordered, Concurrent} {
+		received := make(chan byte, 3)
+
+		id := eb.Subscribe(topics.Test, NewCallbackListenerWithDelivery(func(m message.Message) {
+			buf := m.Payload().(message.SafeBuffer)
+			if buf.Bytes()[0] == 1 {
+				panic("faulty subscriber")
+			}
+
+			received <- buf.Bytes()[0]
+		}, true, delivery))
+
+		for i := byte(0); i < 3; i++ {
+			eb.Publish(topics.Test, message.New(topics.Test, *bytes.NewBuffer([]byte{i})))
+		}
+
+		got := make(map[byte]bool)
+
+		for len(got) < 2 {
+			select {
+			case b := <-received:
+				got[b] = true
+			case <-time.After(time.Second):
+				t.Fatalf("messages not delivered after a panic, got %v", got)
+			}
+		}
+
+		assert.Equal(t, map[byte]bool{0: true, 2: true}, got)
+
+		eb.Unsubscribe(topics.Test, id)
+	}
+}
+
+// Test that a concurrent listener does not wait for a callback to return
+// before delivering the next message, hence the order is not preserved.
+func TestConcurrentDelivery(t *testing.T) {
+	eb := New()
+
+	first := []byte("first")
+	released := make(chan struct{})
+	received := make(chan string, 2)
+
+	eb.Subscribe(topics.Test, NewCallbackListenerWithDelivery(func(m message.Message) {
+		buf := m.Payload().(message.SafeBuffer)
+		if bytes.Equal(buf.Bytes(), first) {
+			// Hold the first message until the second is delivered
+			<-released
+		} else {
+			close(released)
+		}
+
+		received <- buf.String()
+	}, true, Concurrent))
+
+	eb.Publish(topics.Test, message.New(topics.Test, *bytes.NewBuffer(first)))
+	eb.Publish(topics.Test, message.New(topics.Test, *bytes.NewBufferString("second")))
+
+	assert.Equal(t, "second", <-received)
+	assert.Equal(t, "first", <-received)
+}
+
+func TestInterceptors(t *testing.T) {
+	eb := New()
+
+	var order []string
+
+	// Drop everything published on topics.Gossip
+	eb.AddInterceptor(func(topic topics.Topic, m message.Message) (message.Message, bool) {
+		order = append(order, "filter")
+		return m, topic != topics.Gossip
+	})
+
+	// Replace the payload of the messages which went through
+	eb.AddInterceptor(func(topic topics.Topic, m message.Message) (message.Message, bool) {
+		order = append(order, "rewrite")
+		return message.New(topic, *bytes.NewBufferString("pluto")), true
+	})
+
+	testChan, _ := eb.SubscribeChan(topics.Test, 10)
+	gossipChan, _ := eb.SubscribeChan(topics.Gossip, 10)
+
+	eb.Publish(topics.Gossip, message.New(topics.Gossip, *bytes.NewBufferString("pippo")))
+	assert.Empty(t, gossipChan)
+	assert.Equal(t, []string{"filter"}, order)
+
+	eb.Publish(topics.Test, message.New(topics.Test, *bytes.NewBufferString("pippo")))
+	assert.Equal(t, []string{"filter", "filter", "rewrite"}, order)
+	assert.Equal(t, 1, len(testChan))
+
+	m := <-testChan
+	buf := m.Payload().(message.SafeBuffer)
+	assert.Equal(t, "pluto", buf.String())
+}
+
 func TestUnsubscribe(t *testing.T) {
 	eb, myChan, id := newEB(t)
 	eb.Unsubscribe(topics.Test, id)
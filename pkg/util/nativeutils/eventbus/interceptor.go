@@ -0,0 +1,51 @@
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT License was not distributed with this
+// file, you can obtain one at https://opensource.org/licenses/MIT.
+//
+// Copyright (c) DUSK NETWORK. All rights reserved.
+
+package eventbus
+
+import (
+	"sync"
+
+	"github.com/dusk-network/dusk-blockchain/pkg/p2p/wire/message"
+	"github.com/dusk-network/dusk-blockchain/pkg/p2p/wire/topics"
+)
+
+// Interceptor is run on every message published on the EventBus, before it is
+// delivered to any listener. It returns the message to be passed down the
+// chain, which may differ from the one received, or false to drop it.
+type Interceptor func(topic topics.Topic, m message.Message) (message.Message, bool)
+
+// interceptorChain is an ordered list of Interceptors.
+type interceptorChain struct {
+	lock sync.RWMutex
+	fns  []Interceptor
+}
+
+// AddInterceptor appends an Interceptor to the chain run on Publish.
+// Interceptors run in the order they are added. As soon as one of them drops
+// a message, the following ones are skipped.
+func (bus *EventBus) AddInterceptor(fn Interceptor) {
+	bus.interceptors.lock.Lock()
+	defer bus.interceptors.lock.Unlock()
+
+	bus.interceptors.fns = append(bus.interceptors.fns, fn)
+}
+
+// run passes a message through the chain. It returns false if the message
+// should be dropped.
+func (c *interceptorChain) run(topic topics.Topic, m message.Message) (message.Message, bool) {
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+
+	for _, fn := range c.fns {
+		var ok bool
+		if m, ok = fn(topic, m); !ok {
+			return nil, false
+		}
+	}
+
+	return m, true
+}
@@ -10,12 +10,14 @@ import (
 	"crypto/rand"
 	"errors"
 	"math/big"
+	"runtime/debug"
 	"sync"
 	"sync/atomic"
 
 	"github.com/sirupsen/logrus"
 	log "github.com/sirupsen/logrus"
 
+	"github.com/dusk-network/dusk-blockchain/pkg/config"
 	"github.com/dusk-network/dusk-blockchain/pkg/p2p/wire/message"
 	"github.com/dusk-network/dusk-blockchain/pkg/p2p/wire/topics"
 	"github.com/dusk-network/dusk-blockchain/pkg/util/container/ring"
@@ -42,16 +44,30 @@ type Listener interface {
 	Close()
 }
 
+// Delivery is the ordering guarantee of a CallbackListener.
+type Delivery uint8
+
+const (
+	// Concurrent delivery runs each callback in its own goroutine. Callbacks
+	// may run in parallel and complete in any order.
+	Concurrent Delivery = iota
+
+	// Ordered delivery runs the callbacks one at a time, from a single
+	// goroutine, in the order the messages have been notified.
+	Ordered
+)
+
 // CallbackListener subscribes using callbacks.
 type CallbackListener struct {
 	callback func(message.Message)
 	safe     bool
+	queue    *orderedQueue
 }
 
 // Notify the copy of a message as a parameter to a callback.
 func (c *CallbackListener) Notify(m message.Message) error {
 	if !c.safe {
-		go c.callback(m)
+		c.dispatch(m)
 		return nil
 	}
 
@@ -61,26 +77,144 @@ func (c *CallbackListener) Notify(m message.Message) error {
 		return err
 	}
 
-	go c.callback(clone)
+	c.dispatch(clone)
 	return nil
 }
 
+func (c *CallbackListener) dispatch(m message.Message) {
+	if c.queue != nil {
+		c.queue.push(m)
+		return
+	}
+
+	go invoke(c.callback, m, config.Get().Node.RecoverListenerPanics)
+}
+
+// invoke calls a callback with a message. If recoverPanics is set, a panic of
+// the callback is logged instead of crashing the node.
+func invoke(callback func(message.Message), m message.Message, recoverPanics bool) {
+	if recoverPanics {
+		defer func() {
+			if r := recover(); r != nil {
+				log.WithField("topic", m.Category().String()).
+					WithField("stack", string(debug.Stack())).
+					Errorf("eventbus callback recovered from panic: %v", r)
+			}
+		}()
+	}
+
+	callback(m)
+}
+
 // NewSafeCallbackListener creates a callback based dispatcher.
 func NewSafeCallbackListener(callback func(message.Message)) Listener {
-	return &CallbackListener{callback, true}
+	return NewCallbackListenerWithDelivery(callback, true, Concurrent)
 }
 
 // NewCallbackListener creates a callback based dispatcher.
 func NewCallbackListener(callback func(message.Message)) Listener {
-	return &CallbackListener{callback, false}
+	return NewCallbackListenerWithDelivery(callback, false, Concurrent)
+}
+
+// NewSafeOrderedCallbackListener creates a thread-safe callback based
+// dispatcher, delivering messages in FIFO order.
+func NewSafeOrderedCallbackListener(callback func(message.Message)) Listener {
+	return NewCallbackListenerWithDelivery(callback, true, Ordered)
+}
+
+// NewCallbackListenerWithDelivery creates a callback based dispatcher with the
+// given ordering guarantee. If safe, the callback is passed a message clone.
+func NewCallbackListenerWithDelivery(callback func(message.Message), safe bool, delivery Delivery) Listener {
+	c := &CallbackListener{callback: callback, safe: safe}
+	if delivery == Ordered {
+		c.queue = newOrderedQueue(callback)
+	}
+
+	return c
 }
 
 // SetLogLevel empty implementation.
 func (c *CallbackListener) SetLogLevel(logrus.Level) {
 }
 
-// Close as part of the Listener method.
+// Close stops the delivery goroutine of an ordered listener. Messages still
+// queued are dropped.
 func (c *CallbackListener) Close() {
+	if c.queue != nil {
+		c.queue.close()
+	}
+}
+
+// orderedQueue is an unbounded FIFO queue, drained by a single goroutine.
+// Pushing never blocks, so that a slow callback does not stall the publisher.
+type orderedQueue struct {
+	lock   sync.Mutex
+	msgs   []message.Message
+	wake   chan struct{}
+	quit   chan struct{}
+	closed bool
+}
+
+func newOrderedQueue(callback func(message.Message)) *orderedQueue {
+	q := &orderedQueue{
+		wake: make(chan struct{}, 1),
+		quit: make(chan struct{}),
+	}
+
+	go q.drain(callback)
+	return q
+}
+
+func (q *orderedQueue) push(m message.Message) {
+	q.lock.Lock()
+	defer q.lock.Unlock()
+
+	if q.closed {
+		return
+	}
+
+	q.msgs = append(q.msgs, m)
+
+	select {
+	case q.wake <- struct{}{}:
+	default:
+	}
+}
+
+func (q *orderedQueue) drain(callback func(message.Message)) {
+	for {
+		select {
+		case <-q.quit:
+			return
+		case <-q.wake:
+		}
+
+		q.lock.Lock()
+		msgs := q.msgs
+		q.msgs = nil
+		q.lock.Unlock()
+
+		for _, m := range msgs {
+			select {
+			case <-q.quit:
+				return
+			default:
+			}
+
+			invoke(callback, m, config.Get().Node.RecoverListenerPanics)
+		}
+	}
+}
+
+func (q *orderedQueue) close() {
+	q.lock.Lock()
+	defer q.lock.Unlock()
+
+	if !q.closed {
+		q.closed = true
+		q.msgs = nil
+		close(q.quit)
+	}
 }
 
 var ringBufferLength = 2000
@@ -21,7 +21,7 @@ type Publisher interface {
 // topic is explicitly set as it might be different from the message Category
 // (i.e. in the Gossip case).
 // Publishing is a fire and forget. If there is no listener for a topic, the
-// messages are lost.
+// messages are lost. Messages dropped by an Interceptor are lost as well.
 // FIXME: Publish should fail fast and return one error. Since the code is largely
 // asynchronous, we don't expect errors and if they happen, this should be
 // reported asap.
@@ -30,13 +30,26 @@ func (bus *EventBus) Publish(topic topics.Topic, m message.Message) (errorList [
 	//	"topic":    topic,
 	//	"category": m.Category(),
 	//}).Traceln("publishing on the eventbus")
+	m, ok := bus.interceptors.run(topic, m)
+	if !ok {
+		return nil
+	}
+
 	// first serve the default topic listeners as they are most likely to need more time to process topics
 	go func() {
 		newErrList := bus.defaultListener.Forward(topic, m)
 		diagnostics.LogPublishErrors("eventbus/publisher.go, Publish", newErrList)
 	}()
 
+	bus.retained.lock.Lock()
+
+	if _, ok := bus.retained.msgs[topic]; ok {
+		bus.retained.msgs[topic] = m
+	}
+
 	listeners := bus.listeners.Load(topic)
+	bus.retained.lock.Unlock()
+
 	for _, listener := range listeners {
 		if err := listener.Notify(m); err != nil {
 			errorList = append(errorList, err)
@@ -0,0 +1,49 @@
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT License was not distributed with this
+// file, you can obtain one at https://opensource.org/licenses/MIT.
+//
+// Copyright (c) DUSK NETWORK. All rights reserved.
+
+package eventbus
+
+import (
+	"sync"
+
+	"github.com/dusk-network/dusk-blockchain/pkg/p2p/wire/message"
+	"github.com/dusk-network/dusk-blockchain/pkg/p2p/wire/topics"
+)
+
+// retainedMap holds the last message published on each retained topic.
+// The lock is also held while storing a listener and while loading the
+// listeners of a publish, so that a late subscriber gets each message
+// exactly once (either replayed or notified).
+type retainedMap struct {
+	lock sync.Mutex
+	msgs map[topics.Topic]message.Message
+}
+
+func newRetainedMap() *retainedMap {
+	return &retainedMap{
+		msgs: make(map[topics.Topic]message.Message),
+	}
+}
+
+// Retain marks a topic as retained. A new subscriber of a retained topic is
+// immediately notified of the last message published on it, if any.
+func (bus *EventBus) Retain(topic topics.Topic) {
+	bus.retained.lock.Lock()
+	defer bus.retained.lock.Unlock()
+
+	if _, ok := bus.retained.msgs[topic]; !ok {
+		bus.retained.msgs[topic] = nil
+	}
+}
+
+// IsRetained returns whether the topic has been marked as retained.
+func (bus *EventBus) IsRetained(topic topics.Topic) bool {
+	bus.retained.lock.Lock()
+	defer bus.retained.lock.Unlock()
+
+	_, ok := bus.retained.msgs[topic]
+	return ok
+}
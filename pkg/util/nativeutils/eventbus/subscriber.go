@@ -7,19 +7,53 @@
 package eventbus
 
 import (
+	"github.com/dusk-network/dusk-blockchain/pkg/p2p/wire/message"
 	"github.com/dusk-network/dusk-blockchain/pkg/p2p/wire/topics"
 	lg "github.com/sirupsen/logrus"
 )
 
+// DefaultChanBufferSize is the buffer size of the channels created by
+// SubscribeChan, unless specified otherwise.
+const DefaultChanBufferSize = 100
+
 // Subscriber subscribes a channel to Event notifications on a specific topic.
 type Subscriber interface {
 	Subscribe(topic topics.Topic, listener Listener) uint32
 	Unsubscribe(topics.Topic, uint32)
 }
 
-// Subscribe subscribes to a topic with a channel.
+// Subscribe subscribes to a topic with a channel. If the topic is retained,
+// the listener is notified straight away of the last message published on it.
 func (bus *EventBus) Subscribe(topic topics.Topic, listener Listener) uint32 {
-	return bus.listeners.Store(topic, listener)
+	bus.retained.lock.Lock()
+	defer bus.retained.lock.Unlock()
+
+	id := bus.listeners.Store(topic, listener)
+
+	if m := bus.retained.msgs[topic]; m != nil {
+		if err := listener.Notify(m); err != nil {
+			logEB.WithError(err).
+				WithField("topic", topic).
+				Warnln("could not replay retained message")
+		}
+	}
+
+	return id
+}
+
+// SubscribeChan subscribes to a topic with a newly created channel, buffering
+// up to bufSize messages (DefaultChanBufferSize if zero). The channel is
+// dispatched through a thread-safe ChanListener, hence messages published while
+// the buffer is full are dropped with ErrMsgChanFull.
+func (bus *EventBus) SubscribeChan(topic topics.Topic, bufSize int) (<-chan message.Message, uint32) {
+	if bufSize <= 0 {
+		bufSize = DefaultChanBufferSize
+	}
+
+	msgChan := make(chan message.Message, bufSize)
+	id := bus.Subscribe(topic, NewSafeChanListener(msgChan))
+
+	return msgChan, id
 }
 
 // Unsubscribe removes all listeners defined for a topic.
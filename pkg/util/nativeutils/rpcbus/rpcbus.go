@@ -36,8 +36,16 @@ var (
 	// ErrInvalidRequestChan is returned method is bound to nil chan.
 	ErrInvalidRequestChan = errors.New("invalid request channel")
 
+	// ErrResponseDropped is returned when the requester does not receive the
+	// response in time.
+	ErrResponseDropped = errors.New("response dropped")
+
 	// DefaultTimeout is used when 0 timeout is set on calling a method.
 	DefaultTimeout = 5 * time.Second
+
+	// DefaultRespondTimeout is used when 0 timeout is set on responding to a
+	// request.
+	DefaultRespondTimeout = 100 * time.Millisecond
 )
 
 // RPCBus is a request–response mechanism for internal communication between node
@@ -74,6 +82,32 @@ func NewRequest(p interface{}) Request {
 	}
 }
 
+// Respond sends a response back to the requester. If the requester is not
+// receiving, e.g. because it gave up on the request, Respond gives up after
+// timeout with ErrResponseDropped, so that the responding component can not
+// be blocked by an abandoned request.
+func (r Request) Respond(resp Response, timeOut time.Duration) error {
+	select {
+	case r.RespChan <- resp:
+		return nil
+	default:
+	}
+
+	if timeOut <= 0 {
+		timeOut = DefaultRespondTimeout
+	}
+
+	timer := time.NewTimer(timeOut)
+	defer timer.Stop()
+
+	select {
+	case r.RespChan <- resp:
+		return nil
+	case <-timer.C:
+		return ErrResponseDropped
+	}
+}
+
 // NewResponse builds a new response.
 func NewResponse(p interface{}, err error) Response {
 	return Response{
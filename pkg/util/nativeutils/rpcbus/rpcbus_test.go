@@ -174,3 +174,23 @@ func TestRPCallNoTimeout(t *testing.T) {
 		t.Errorf("expecting to retrieve response data")
 	}
 }
+
+func TestRespondAbandonedRequest(t *testing.T) {
+	// A buffered request receives the response straight away
+	req := NewRequest(bytes.Buffer{})
+	if err := req.Respond(NewResponse(nil, nil), time.Second); err != nil {
+		t.Fatalf("expecting response to be delivered but get %v", err)
+	}
+
+	// The requester gave up and nobody is receiving on the response channel
+	req = Request{Params: bytes.Buffer{}, RespChan: make(chan Response)}
+
+	start := time.Now()
+	if err := req.Respond(NewResponse(nil, nil), 100*time.Millisecond); err != ErrResponseDropped {
+		t.Fatalf("expecting ErrResponseDropped but get %v", err)
+	}
+
+	if time.Since(start) > time.Second {
+		t.Error("responding to an abandoned request blocked")
+	}
+}